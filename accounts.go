@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/oauth"
+)
+
+// addConnectMenu adds a tray item that signs in to or out of an account.
+// A nil session hides the item.
+func addConnectMenu(name, tooltip string, session *oauth.Session) *systray.MenuItem {
+	mConnect := systray.AddMenuItem("Connect "+name+"…", tooltip)
+	if session == nil {
+		mConnect.Hide()
+		return mConnect
+	}
+
+	update := func() {
+		if session.IsConnected() {
+			mConnect.SetTitle("Disconnect " + name)
+		} else {
+			mConnect.SetTitle("Connect " + name + "…")
+		}
+	}
+	update()
+
+	go func() {
+		for range mConnect.ClickedCh {
+			if session.IsConnected() {
+				err := session.Disconnect()
+				if err != nil {
+					log.Printf("❌ Failed to disconnect %s: %v", name, err)
+				}
+			} else {
+				connectAccount(name, session)
+			}
+			update()
+		}
+	}()
+	return mConnect
+}
+
+// connectAccount runs the device-code sign-in, showing the code to enter
+func connectAccount(name string, session *oauth.Session) {
+	ctx, cancel := context.WithCancel(appContext)
+	defer cancel()
+
+	err := session.Connect(ctx, func(code *oauth.DeviceCode) {
+		gui.Open(code.VerificationURL)
+		go gui.Info("AI Assistant - Connect "+name, fmt.Sprintf(
+			"Sign in at %s and enter this code:\n\n%s",
+			code.VerificationURL, code.UserCode))
+	})
+	if err != nil {
+		log.Printf("❌ %s sign-in failed: %v", name, err)
+		notifications.Notify(notify.Error, "❌ "+name+" sign-in failed")
+		return
+	}
+	log.Printf("🔑 Connected %s", name)
+	notifications.Notify(notify.Info, "🔑 Connected "+name)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"voice-assistant/internal/actions"
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/notify"
+)
+
+var actionRunner *actions.Runner
+
+// setupActions creates the runner for the configured allow-list and offers
+// it to Claude as a tool
+func setupActions() {
+	actionRunner = actions.NewRunner(appConfig.Actions)
+
+	registerTool(claude.Tool{
+		Name: "open_application",
+		Description: "Open an application, web page or file on the user's computer. " +
+			"Allow-listed names open immediately: " + strings.Join(actionRunner.Names(), ", ") + ". " +
+			"Anything else is a program path, URL or file path and the user is asked to confirm it first.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"target": map[string]interface{}{"type": "string", "description": "Allow-listed name, program, URL or file path"},
+			},
+			"required": []string{"target"},
+		},
+	}, openApplicationTool)
+}
+
+// openAction opens a target asked for by voice
+func openAction(target string) {
+	err := actionRunner.Open(target)
+	if err != nil {
+		log.Printf("❌ Failed to open %s: %v", target, err)
+		if !errors.Is(err, actions.ErrDeclined) {
+			notifications.Notify(notify.Error, "❌ Failed to open "+target)
+		}
+	}
+}
+
+// openApplicationTool lets Claude open applications, URLs and files
+func openApplicationTool(input json.RawMessage) (string, error) {
+	var args struct {
+		Target string `json:"target"`
+	}
+	err := json.Unmarshal(input, &args)
+	if err != nil {
+		return "", fmt.Errorf("invalid input: %v", err)
+	}
+
+	err = actionRunner.Open(args.Target)
+	if err != nil {
+		return "", err
+	}
+	return "Opened " + args.Target, nil
+}
@@ -0,0 +1,462 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/errs"
+	"voice-assistant/internal/events"
+	"voice-assistant/internal/history"
+	"voice-assistant/internal/latency"
+	"voice-assistant/internal/metrics"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/offline"
+	"voice-assistant/internal/speech"
+)
+
+// Responder answers a transcript; *claude.Client is the real one
+type Responder interface {
+	SendMessageWithOptions(ctx context.Context, userMessage string, options claude.RequestOptions) (string, error)
+	Model() string
+}
+
+// Speaker says a response aloud and returns once it has finished, or
+// once Stop cuts it off
+type Speaker interface {
+	Speak(text string)
+	Stop()
+}
+
+// Notifier shows a desktop notification, if that kind is enabled
+type Notifier interface {
+	Notify(event notify.Event, message string, actions ...notify.Action) error
+}
+
+// Commands handles transcripts that are local commands rather than
+// questions, reporting whether it did
+type Commands interface {
+	Handle(text string) bool
+}
+
+// History keeps answered turns
+type History interface {
+	Add(turn history.Turn) error
+}
+
+// Services are the dependencies of the turn pipeline. Tests and headless
+// runs can supply their own.
+type Services struct {
+	Speech   speech.Provider // nil when speech recognition isn't configured
+	Claude   Responder       // nil when Claude isn't configured
+	Speaker  Speaker
+	Notifier Notifier
+	History  History
+	Commands Commands        // nil when every transcript is a question
+	Budget   *latency.Budget // nil enforces no latency budget
+	State    *app.Machine
+	Bus      *events.Bus
+}
+
+// App runs the recording hotkey → transcript → Claude → speech pipeline
+// over injected services
+type App struct {
+	config   *config.Config
+	speech   speech.Provider
+	claude   Responder // Guarded by claudeMutex, as the provider can change
+	speaker  Speaker
+	notifier Notifier
+	history  History
+	commands Commands
+	budget   *latency.Budget
+	state    *app.Machine
+	bus      *events.Bus
+
+	handsFree      bool // Whether the session keeps re-opening the microphone
+	handsFreeMutex sync.Mutex
+
+	discardUntil time.Time // Transcripts of a cancelled utterance arriving before this are dropped
+	discardMutex sync.Mutex
+
+	claudeMutex sync.Mutex
+}
+
+// abortGrace is how long a cancelled utterance's transcript may still
+// arrive after recognition is stopped
+const abortGrace = 2 * time.Second
+
+// assistant is the running pipeline, built once the services are set up
+var assistant *App
+
+// NewApp creates the pipeline for a config and its services
+func NewApp(cfg *config.Config, services Services) *App {
+	if services.Budget == nil {
+		services.Budget = latency.NewBudget(0)
+	}
+	return &App{
+		config:   cfg,
+		speech:   services.Speech,
+		claude:   services.Claude,
+		speaker:  services.Speaker,
+		notifier: services.Notifier,
+		history:  services.History,
+		commands: services.Commands,
+		budget:   services.Budget,
+		state:    services.State,
+		bus:      services.Bus,
+	}
+}
+
+// newDefaultApp wires the pipeline to the services set up at startup
+func newDefaultApp() *App {
+	services := Services{
+		Speech:   speechService,
+		Speaker:  ttsSpeaker{},
+		Notifier: desktopNotifier{},
+		History:  storedHistory{},
+		Commands: localCommands{},
+		Budget:   latency.NewBudget(time.Duration(appConfig.Latency.BudgetMs) * time.Millisecond),
+		State:    stateMachine,
+		Bus:      eventBus,
+		Claude:   responder(),
+	}
+	return NewApp(appConfig, services)
+}
+
+// SetResponder replaces what answers transcripts from the next turn
+func (a *App) SetResponder(responder Responder) {
+	a.claudeMutex.Lock()
+	defer a.claudeMutex.Unlock()
+	a.claude = responder
+}
+
+// responder returns what answers transcripts, or nil if nothing does
+func (a *App) responder() Responder {
+	a.claudeMutex.Lock()
+	defer a.claudeMutex.Unlock()
+	return a.claude
+}
+
+// setState moves the pipeline's state machine, logging refused transitions
+func (a *App) setState(state app.State, reason string) {
+	err := a.state.Transition(state, reason)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+}
+
+// ttsSpeaker speaks through the configured text-to-speech provider
+type ttsSpeaker struct{}
+
+func (ttsSpeaker) Speak(text string) {
+	speakResponse(text)
+}
+
+func (ttsSpeaker) Stop() {
+	if audioPlayer != nil {
+		audioPlayer.Stop()
+	}
+}
+
+// desktopNotifier shows Windows toast notifications
+type desktopNotifier struct{}
+
+func (desktopNotifier) Notify(event notify.Event, message string, actions ...notify.Action) error {
+	return notifications.Notify(event, message, actions...)
+}
+
+// storedHistory keeps turns in the history database, when it is enabled
+type storedHistory struct{}
+
+func (storedHistory) Add(turn history.Turn) error {
+	return recordTurn(turn)
+}
+
+// localCommands are the voice intents and the user's command grammar
+type localCommands struct{}
+
+func (localCommands) Handle(text string) bool {
+	if handleIntent(text) {
+		return true
+	}
+	if handleConversationCommand(text) || handlePersonaCommand(text) || routeCommand(text) {
+		continueHandsFree()
+		return true
+	}
+	return false
+}
+
+// ToggleRecording handles the recording hotkey: it starts listening,
+// stops it, or cancels the request in flight, depending on the state
+func (a *App) ToggleRecording() {
+	log.Printf("🔑 F12 KEY PRESSED - Current state: %s", a.state.State())
+	markInteraction()
+
+	if a.speech == nil {
+		log.Printf("❌ Speech recognition not available")
+		a.notifier.Notify(notify.Error, "❌ Speech recognition not configured")
+		return
+	}
+
+	if isMeetingActive() {
+		a.notifier.Notify(notify.Info, "📝 Meeting transcription is running - stop it from the tray")
+		return
+	}
+
+	// An offline recording stops like a live session
+	if stopOfflineRecording() {
+		a.setState(app.Idle, "offline recording stopped")
+		return
+	}
+
+	if a.state.Is(app.Listening) {
+		// Stop recording
+		log.Printf("🛑 USER REQUESTED STOP")
+		err := a.notifier.Notify(notify.Status, "🔴 Stopping recognition...")
+		if err != nil {
+			log.Printf("Failed to show notification: %v", err)
+		}
+
+		err = a.speech.StopContinuousRecognition()
+		if err != nil {
+			log.Printf("❌ Failed to stop recognition: %v", err)
+			a.setState(app.Error, "stop failed")
+			a.notifier.Notify(notify.Error, "❌ Failed to stop recognition")
+		} else {
+			a.EndHandsFree("user stopped recording")
+			a.setState(app.Idle, "user stopped recording")
+			log.Printf("✅ Recording stopped successfully")
+		}
+		return
+	}
+
+	// The hotkey while Claude is thinking abandons the request
+	if a.state.Is(app.Processing) && a.Abort() {
+		return
+	}
+
+	// Mid-turn in a hands-free session the hotkey ends the session
+	if a.handsFreeOn() && a.state.Is(app.Processing, app.Speaking) {
+		a.EndHandsFree("hotkey")
+		return
+	}
+
+	// Start recording
+	log.Printf("🎤 USER REQUESTED START")
+	metrics.BeginInteraction()
+	metrics.Mark(metrics.MarkHotkey)
+	err := a.notifier.Notify(notify.Status, "🎤 Streaming live... Press F12 to stop.")
+	if err != nil {
+		log.Printf("Failed to show notification: %v", err)
+	}
+
+	err = a.speech.StartContinuousRecognition(appContext)
+	if err != nil {
+		log.Printf("❌ Failed to start recognition: %v", err)
+		if offline.IsOffline(err) && startOfflineRecording() {
+			a.setState(app.Listening, "recording offline")
+			return
+		}
+		a.setState(app.Error, "start failed")
+		a.notifier.Notify(notify.Error, errs.Describe(err, "❌ Failed to start recognition"))
+	} else {
+		metrics.Mark(metrics.MarkCaptureStart)
+		a.setHandsFree(a.config.Conversation.HandsFree)
+		a.setState(app.Listening, "user started recording")
+		log.Printf("✅ Live streaming started successfully")
+		log.Printf("💡 Now speak clearly - audio is streaming to %s in real-time!", a.speech.Name())
+	}
+}
+
+// Abort cancels the turn in progress: an utterance being recognized is
+// dropped, a Claude request is abandoned before it costs more tokens and
+// a spoken answer is cut off. It reports whether there was anything to
+// cancel.
+func (a *App) Abort() bool {
+	switch a.state.State() {
+	case app.Listening:
+		if isMeetingActive() {
+			return false
+		}
+		a.discardMutex.Lock()
+		a.discardUntil = time.Now().Add(abortGrace)
+		a.discardMutex.Unlock()
+		err := a.speech.StopContinuousRecognition()
+		if err != nil {
+			log.Printf("❌ Failed to stop recognition: %v", err)
+		}
+	case app.Processing:
+		if !cancelTurn() {
+			return false // Nothing in flight, e.g. a local command
+		}
+	case app.Speaking:
+		a.speaker.Stop()
+	default:
+		return false
+	}
+
+	log.Printf("⏹️ USER CANCELLED TURN")
+	a.EndHandsFree("cancelled")
+	err := a.state.Abort("cancelled by user")
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	a.notifier.Notify(notify.Status, "⏹️ Cancelled")
+	return true
+}
+
+// HandleTranscript runs a turn for a final transcript: local commands are
+// handled here, anything else is answered by Claude and spoken
+func (a *App) HandleTranscript(result speech.RecognitionResult) {
+	a.discardMutex.Lock()
+	discard := time.Now().Before(a.discardUntil)
+	a.discardMutex.Unlock()
+	if discard && !isMeetingActive() {
+		log.Printf("⏹️ Dropping transcript of a cancelled utterance: %s", result.Text)
+		return
+	}
+
+	text, language := result.Text, result.Language
+	// The latency budget starts at the final transcript; the user's own
+	// speaking time is not the assistant's to spend
+	turnStart := time.Now()
+	markInteraction()
+	log.Printf("🎉 SPEECH CALLBACK TRIGGERED")
+	log.Printf("   📝 Recognized text: '%s'", text)
+	log.Printf("   📏 Text length: %d characters", len(text))
+	log.Printf("   🌍 Language: %s, confidence: %.2f", language, result.Confidence)
+
+	a.bus.Publish(events.TranscriptFinal{Text: text, Language: language, Confidence: result.Confidence})
+
+	// Meetings are written to the transcript and keep listening
+	if isMeetingActive() {
+		appendMeetingPhrase(meetingSpeaker(), result)
+		return
+	}
+
+	metrics.Mark(metrics.MarkFinalTranscript)
+	rememberTranscript(text)
+	rememberSpokenLanguage(language)
+
+	// One utterance per turn: close the microphone while the answer is produced
+	err := a.speech.StopContinuousRecognition()
+	if err != nil {
+		log.Printf("❌ Failed to stop recognition: %v", err)
+	}
+	a.setState(app.Processing, "transcript received")
+
+	if a.handsFreeOn() && isStopWord(text) {
+		a.EndHandsFree("stop word")
+		a.setState(app.Idle, "stop word")
+		return
+	}
+
+	// Local commands are handled without calling Claude
+	if a.commands != nil && a.commands.Handle(text) {
+		return
+	}
+
+	// Send transcription to Claude API
+	if client := a.responder(); client != nil {
+		degradations := a.budget.Plan(time.Since(turnStart))
+		options := claude.RequestOptions{
+			Images:  attachedImages(text),
+			Context: joinContext(activeWindowContext(), languageContext(language)),
+		}
+		// The fallback is a Claude model
+		if latency.Contains(degradations, latency.FastModel) && a.config.LLM.Vendor() == nil {
+			options.Model = a.config.Latency.FallbackModel
+		}
+		if latency.Contains(degradations, latency.ShortResponse) {
+			options.MaxTokens = a.config.Latency.DegradedMaxTokens
+		}
+		if len(degradations) > 0 {
+			log.Printf("🐢 Degrading turn to stay within latency budget: %v", degradations)
+		}
+
+		var thinking string
+		options.OnThinking = func(text string) { thinking = text }
+		options.OnText = func(text string) { a.bus.Publish(events.LLMPartial{Text: text}) }
+
+		ctx, endTurn := beginTurn()
+		claudeResponse, err := client.SendMessageWithOptions(ctx, text, options)
+		cancelled := ctx.Err() != nil
+		endTurn()
+		if cancelled {
+			log.Printf("⏹️ Claude request abandoned")
+			return
+		}
+		metrics.Mark(metrics.MarkFirstToken)
+		a.budget.Record(time.Since(turnStart), degradations)
+		if offline.IsOffline(err) && deferTranscript(text) {
+			a.setHandsFree(false)
+			a.setState(app.Idle, "queued while offline")
+		} else if err != nil {
+			log.Printf("Claude API failed: %v", err)
+			a.bus.Publish(events.Error{Source: "claude", Err: err})
+			a.setHandsFree(false)
+			a.setState(app.Error, "Claude request failed")
+			a.notifier.Notify(notify.Error, errs.Describe(err, "❌ Claude API failed"), notify.Action{
+				Label: "Retry",
+				Run:   func() { a.retryTranscript(result) },
+			})
+		} else {
+			log.Printf("Claude response: %s", claudeResponse)
+			model := client.Model()
+			if options.Model != "" {
+				model = options.Model
+			}
+			err = a.history.Add(history.Turn{Transcript: text, Response: claudeResponse, Model: model, Thinking: thinking})
+			if err != nil {
+				log.Printf("⚠️  %v", err)
+			}
+			a.bus.Publish(events.LLMResponse{Prompt: text, Text: claudeResponse, Model: model, Thinking: thinking})
+
+			// Without speech the answer would otherwise only be in the log
+			if !a.config.Features.TTS {
+				a.notifier.Notify(notify.Response, claudeResponse, notify.Action{
+					Label: "Copy response",
+					Run:   func() { copyToClipboard("response", claudeResponse) },
+				})
+			}
+			a.speaker.Speak(speakableResponse(claudeResponse))
+			a.ContinueHandsFree()
+		}
+	} else {
+		log.Println("Claude not configured - skipping AI processing")
+		a.notifier.Notify(notify.Error, "⚠️ Claude API not configured")
+		a.setState(app.Idle, "Claude not configured")
+	}
+}
+
+// retryTranscript runs a failed turn again from a notification button,
+// unless another turn has started since
+func (a *App) retryTranscript(result speech.RecognitionResult) {
+	if !a.state.Is(app.Idle, app.Error) {
+		log.Printf("⚠️  Not retrying while the assistant is busy")
+		return
+	}
+	log.Printf("🔁 Retrying: %s", result.Text)
+	a.HandleTranscript(result)
+}
+
+// HandleSpeechError tears down a failed recognition session
+func (a *App) HandleSpeechError(err error) {
+	log.Printf("🚨 SPEECH ERROR CALLBACK TRIGGERED")
+	log.Printf("   ❌ Error details: %v", err)
+	a.bus.Publish(events.Error{Source: "speech", Err: err})
+	hint := errs.Hint(err)
+	if hint == "" {
+		log.Printf("   💡 Check your microphone, internet connection, and Azure credentials")
+	}
+
+	// Tear down the broken session so the next F12 starts cleanly
+	a.speech.StopContinuousRecognition()
+	a.setHandsFree(false)
+	a.setState(app.Error, "speech error")
+	a.notifier.Notify(notify.Error, errs.Describe(err, "❌ Speech recognition error"))
+}
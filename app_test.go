@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/events"
+	"voice-assistant/internal/fake"
+	"voice-assistant/internal/notify"
+)
+
+// pipeline is an App wired to fakes, with what the test needs to inspect
+type pipeline struct {
+	app     *App
+	speech  *fake.Speech
+	llm     *fake.Claude
+	output  *fake.Recorder
+	history *fake.History
+	state   *app.Machine
+}
+
+// newPipeline builds an App over fakes. The pipeline still reaches some
+// package state directly, so that is pointed at quiet stand-ins too.
+func newPipeline(t *testing.T) *pipeline {
+	t.Helper()
+
+	appConfig = config.DefaultConfig()
+	notifications = notify.New(config.NotificationsConfig{Level: config.NotifySilent})
+	stateMachine = app.NewMachine()
+	setupIntents()
+	setupActions()
+
+	p := &pipeline{
+		speech:  fake.NewSpeech(),
+		llm:     fake.NewClaude(),
+		output:  &fake.Recorder{},
+		history: &fake.History{},
+		state:   stateMachine,
+	}
+	speechService = p.speech
+	p.speech.SetCallbacks(onSpeechRecognized, onSpeechError)
+
+	p.app = NewApp(appConfig, Services{
+		Speech:   p.speech,
+		Claude:   p.llm,
+		Speaker:  p.output,
+		Notifier: p.output,
+		History:  p.history,
+		Commands: localCommands{},
+		State:    p.state,
+		Bus:      events.NewBus(),
+	})
+	assistant = p.app
+	return p
+}
+
+// say records an utterance and delivers its transcript
+func (p *pipeline) say(t *testing.T, text string) {
+	t.Helper()
+	p.app.ToggleRecording()
+	err := p.speech.Recognize(text)
+	if err != nil {
+		t.Fatalf("recognize %q: %v", text, err)
+	}
+}
+
+func TestAnsweredTurnIsSpokenAndRecorded(t *testing.T) {
+	p := newPipeline(t)
+	p.llm.SetReplies("Four.")
+
+	p.say(t, "what is two plus two")
+
+	if prompts := p.llm.Prompts(); len(prompts) != 1 || prompts[0] != "what is two plus two" {
+		t.Errorf("prompts %q, want the transcript once", prompts)
+	}
+	if spoken := p.output.Spoken(); len(spoken) != 1 || spoken[0] != "Four." {
+		t.Errorf("spoke %q, want %q", spoken, "Four.")
+	}
+	turns := p.history.Turns()
+	if len(turns) != 1 {
+		t.Fatalf("recorded %d turns, want 1", len(turns))
+	}
+	if turns[0].Transcript != "what is two plus two" || turns[0].Response != "Four." || turns[0].Model != "fake" {
+		t.Errorf("recorded %+v", turns[0])
+	}
+	if state := p.state.State(); state != app.Idle {
+		t.Errorf("state %s, want %s", state, app.Idle)
+	}
+}
+
+func TestFailedTurnIsReportedAndNotRecorded(t *testing.T) {
+	p := newPipeline(t)
+	p.llm.FailWith(fmt.Errorf("overloaded"))
+
+	p.say(t, "what is two plus two")
+
+	if spoken := p.output.Spoken(); len(spoken) != 0 {
+		t.Errorf("spoke %q after a failure", spoken)
+	}
+	if turns := p.history.Turns(); len(turns) != 0 {
+		t.Errorf("recorded %d turns after a failure", len(turns))
+	}
+	if !containsPart(p.output.Notifications(), "Claude API failed") {
+		t.Errorf("notifications %q, want the failure", p.output.Notifications())
+	}
+	if state := p.state.State(); state != app.Error {
+		t.Errorf("state %s, want %s", state, app.Error)
+	}
+}
+
+func TestHandsFreeListensAgainUntilStopWord(t *testing.T) {
+	p := newPipeline(t)
+	appConfig.Conversation.HandsFree = true
+	appConfig.Conversation.StopWords = []string{"goodbye"}
+	p.llm.SetReplies("Four.")
+
+	p.say(t, "what is two plus two")
+	if state := p.state.State(); state != app.Listening {
+		t.Fatalf("state %s after an answer, want %s", state, app.Listening)
+	}
+	if starts := p.speech.Starts(); starts != 2 {
+		t.Errorf("%d recognition sessions, want 2", starts)
+	}
+
+	err := p.speech.Recognize("goodbye")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.app.handsFreeOn() {
+		t.Errorf("hands-free still on after the stop word")
+	}
+	if state := p.state.State(); state != app.Idle {
+		t.Errorf("state %s, want %s", state, app.Idle)
+	}
+}
+
+func TestLocalCommandSkipsClaude(t *testing.T) {
+	p := newPipeline(t)
+
+	p.say(t, "stop listening")
+
+	if prompts := p.llm.Prompts(); len(prompts) != 0 {
+		t.Errorf("Claude was asked %q", prompts)
+	}
+	if turns := p.history.Turns(); len(turns) != 0 {
+		t.Errorf("recorded %d turns for a local command", len(turns))
+	}
+	if state := p.state.State(); state != app.Idle {
+		t.Errorf("state %s, want %s", state, app.Idle)
+	}
+}
+
+// containsPart reports whether any item contains part
+func containsPart(list []string, part string) bool {
+	for _, item := range list {
+		if strings.Contains(item, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/bench"
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/tts"
+	"voice-assistant/internal/usage"
+)
+
+// runBench runs the bench suite in dir (or the default bench directory)
+// through every configured provider and prints a comparison report.
+// It returns the process exit code.
+func runBench(dir string) int {
+	if dir == "" {
+		dir = filepath.Join(config.GetConfigDir(), "bench")
+	}
+	log.Printf("🏁 Running benchmark suite from %s", dir)
+
+	suite, err := bench.LoadSuite(dir)
+	if err != nil {
+		log.Printf("❌ Failed to load benchmark suite: %v", err)
+		return 1
+	}
+	log.Printf("   %d utterances, %d prompts", len(suite.Utterances), len(suite.Prompts))
+
+	var results []bench.Result
+	if len(suite.Utterances) > 0 {
+		results = append(results, benchSTT(suite)...)
+	}
+	if len(suite.Prompts) > 0 {
+		results = append(results, benchLLM(suite)...)
+		results = append(results, benchTTS(suite)...)
+	}
+
+	bench.WriteReport(os.Stdout, results)
+	return 0
+}
+
+// benchSTT transcribes the suite's utterances with the speech provider
+func benchSTT(suite *bench.Suite) []bench.Result {
+	provider := appConfig.STT.Provider + "/" + appConfig.Azure.Language
+
+	service, err := newSpeechProvider()
+	if err != nil {
+		return []bench.Result{bench.Skip("STT", provider, err.Error())}
+	}
+	if service == nil {
+		return []bench.Result{bench.Skip("STT", provider, "not configured")}
+	}
+	defer service.Close()
+
+	transcribe := func(samples []int16) (string, error) {
+		return service.TranscribePCM(context.Background(), samples)
+	}
+	return []bench.Result{bench.RunSTT(provider, transcribe, suite.Utterances)}
+}
+
+// benchLLM sends the suite's prompts to the configured and fallback models
+func benchLLM(suite *bench.Suite) []bench.Result {
+	models := []string{appConfig.Claude.Model}
+	if fallback := appConfig.Latency.FallbackModel; fallback != "" && fallback != appConfig.Claude.Model {
+		models = append(models, fallback)
+	}
+
+	var results []bench.Result
+	for _, model := range models {
+		provider := "claude/" + model
+		if !appConfig.Claude.IsConfigured() {
+			results = append(results, bench.Skip("LLM", provider, "not configured"))
+			continue
+		}
+
+		// A fresh client per model so history and usage don't leak between runs
+		client := claude.NewClientFromConfig(appConfig)
+		client.SetHistoryEnabled(false)
+		var cost float64
+		client.SetUsageCallback(func(model string, u claude.Usage) {
+			cost = usage.Cost(model, u.InputTokens, u.OutputTokens,
+				u.CacheCreationInputTokens, u.CacheReadInputTokens)
+		})
+
+		complete := func(prompt string) (float64, error) {
+			cost = 0
+			_, err := client.SendMessageWithOptions(context.Background(), prompt, claude.RequestOptions{Model: model})
+			return cost, err
+		}
+		results = append(results, bench.RunLLM(provider, complete, suite.Prompts))
+	}
+	return results
+}
+
+// benchTTS synthesizes the suite's prompts with the configured voice
+func benchTTS(suite *bench.Suite) []bench.Result {
+	provider, err := tts.NewProviderFromConfig(appConfig)
+	if err != nil {
+		return []bench.Result{bench.Skip("TTS", appConfig.TTS.Provider, err.Error())}
+	}
+
+	options := tts.Options{Voice: appConfig.TTS.Voice, Rate: appConfig.TTS.Rate}
+	synthesize := func(text string) error {
+		_, err := provider.Synthesize(text, options)
+		return err
+	}
+	return []bench.Result{bench.RunTTS(provider.Name(), synthesize, suite.Prompts)}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/schedule"
+)
+
+// How long a scheduled briefing waits for the assistant to be free
+const briefingWaitLimit = 10 * time.Minute
+
+var briefingScheduler *schedule.Scheduler
+
+// setupBriefing schedules the daily briefing, replacing any earlier schedule
+func setupBriefing() {
+	if briefingScheduler != nil {
+		briefingScheduler.Stop()
+		briefingScheduler = nil
+	}
+
+	cfg := appConfig.Briefing
+	s, err := schedule.Parse(cfg.Times, cfg.Days)
+	if err != nil {
+		log.Printf("⚠️  Daily briefing disabled: %v", err)
+		return
+	}
+	if s.IsEmpty() {
+		return
+	}
+
+	briefingScheduler = schedule.Start(s, func() {
+		if waitUntilIdle(briefingWaitLimit) {
+			runBriefing()
+		} else {
+			log.Printf("⏰ Skipped the daily briefing, the assistant stayed busy")
+		}
+	})
+	log.Printf("⏰ Daily briefing at %s", strings.Join(cfg.Times, ", "))
+}
+
+// waitUntilIdle waits for the assistant to finish what it is doing
+func waitUntilIdle(limit time.Duration) bool {
+	deadline := time.Now().Add(limit)
+	for !stateMachine.Is(app.Idle) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Second)
+	}
+	return true
+}
+
+// runBriefing gathers the day's facts through the tools, has Claude word
+// them as a briefing and speaks it. F12 cancels it like any request.
+func runBriefing() {
+	if !stateMachine.Is(app.Idle) {
+		return
+	}
+	log.Printf("⏰ Composing the daily briefing")
+	setState(app.Processing, "daily briefing")
+
+	facts := gatherBriefingFacts()
+	briefing := strings.Join(facts, "\n")
+
+	if claudeClient != nil {
+		ctx, endTurn := beginTurn()
+		prompt := appConfig.Briefing.Prompt + "\n\n" + briefing
+		response, err := claudeClient.SendConversation(ctx, []claude.Message{claude.TextMessage("user", prompt)})
+		cancelled := ctx.Err() != nil
+		endTurn()
+
+		if cancelled {
+			return
+		}
+		if err != nil {
+			log.Printf("⚠️  Failed to compose the briefing, reading the facts instead: %v", err)
+		} else {
+			briefing = response
+		}
+	}
+
+	rememberResponse(briefing)
+	speakResponse(claude.Speakable(briefing))
+	setState(app.Idle, "briefing done")
+}
+
+// gatherBriefingFacts calls the tools a briefing draws on, leaving out any
+// that aren't available
+func gatherBriefingFacts() []string {
+	var facts []string
+	add := func(label, tool string, input interface{}) {
+		data, _ := json.Marshal(input)
+		result, err := toolRegistry.Call(tool, data)
+		if err != nil {
+			log.Printf("⚠️  Briefing: %s unavailable: %v", label, err)
+			return
+		}
+		facts = append(facts, label+": "+result)
+	}
+
+	add("Now", "get_time", map[string]string{})
+	if appConfig.Briefing.Place != "" {
+		add("Weather", "get_weather", map[string]string{"place": appConfig.Briefing.Place})
+	}
+	if calendarClient != nil {
+		add("Upcoming events", "get_calendar_events", map[string]string{})
+	}
+	if timers := pendingTimers(); len(timers) > 0 {
+		facts = append(facts, "Reminders: "+strings.Join(timers, "; "))
+	}
+	return facts
+}
+
+// addBriefingMenu adds the item that gives the briefing now
+func addBriefingMenu() *systray.MenuItem {
+	mBriefing := systray.AddMenuItem("Brief me now", "Speak the daily briefing")
+	go func() {
+		for range mBriefing.ClickedCh {
+			go runBriefing()
+		}
+	}()
+	return mBriefing
+}
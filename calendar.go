@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/calendar"
+	"voice-assistant/internal/claude"
+)
+
+// Length of an event created without an end
+const defaultEventDuration = time.Hour
+
+// calendarClient reads and creates events; nil unless a calendar is configured
+var calendarClient *calendar.Client
+
+// setupCalendar creates the calendar client and offers it to Claude as tools
+func setupCalendar() {
+	cfg := appConfig.Calendar
+	if !cfg.IsConfigured() {
+		return
+	}
+
+	var provider calendar.Provider
+	if cfg.Provider == config.CalendarGoogle {
+		provider = calendar.NewGoogle(cfg.ClientID, cfg.ClientSecret)
+	} else {
+		provider = calendar.NewOutlook(cfg.ClientID, cfg.Tenant)
+	}
+	calendarClient = calendar.NewClient(provider)
+
+	registerTool(claude.Tool{
+		Name:        "get_calendar_events",
+		Description: "List the user's next calendar events, soonest first.",
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+		},
+	}, getCalendarEventsTool)
+
+	registerTool(claude.Tool{
+		Name:        "create_calendar_event",
+		Description: "Add an event to the user's calendar. Use get_time first to resolve relative dates like \"Friday\".",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"title":            map[string]interface{}{"type": "string"},
+				"start":            map[string]interface{}{"type": "string", "description": "Local start time as YYYY-MM-DDTHH:MM, or RFC 3339"},
+				"duration_minutes": map[string]interface{}{"type": "integer", "description": "Defaults to 60"},
+				"location":         map[string]interface{}{"type": "string"},
+			},
+			"required": []string{"title", "start"},
+		},
+	}, createCalendarEventTool)
+}
+
+// getCalendarEventsTool lists upcoming events
+func getCalendarEventsTool(input json.RawMessage) (string, error) {
+	events, err := calendarClient.Upcoming(appContext, appConfig.Calendar.Events)
+	if err != nil {
+		return "", err
+	}
+	if len(events) == 0 {
+		return "No upcoming events", nil
+	}
+
+	var lines []string
+	for _, event := range events {
+		line := event.Title + ": "
+		if event.AllDay {
+			line += event.Start.Format("Monday, January 2") + ", all day"
+		} else {
+			line += event.Start.Format("Monday, January 2 15:04") + " to " + event.End.Format("15:04")
+		}
+		if event.Location != "" {
+			line += " at " + event.Location
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// createCalendarEventTool adds an event
+func createCalendarEventTool(input json.RawMessage) (string, error) {
+	var args struct {
+		Title           string `json:"title"`
+		Start           string `json:"start"`
+		DurationMinutes int    `json:"duration_minutes"`
+		Location        string `json:"location"`
+	}
+	err := json.Unmarshal(input, &args)
+	if err != nil {
+		return "", fmt.Errorf("invalid input: %v", err)
+	}
+
+	start, err := time.Parse(time.RFC3339, args.Start)
+	if err != nil {
+		start, err = time.ParseInLocation("2006-01-02T15:04", args.Start, time.Local)
+		if err != nil {
+			return "", fmt.Errorf("start must be YYYY-MM-DDTHH:MM, got %q", args.Start)
+		}
+	}
+	duration := defaultEventDuration
+	if args.DurationMinutes > 0 {
+		duration = time.Duration(args.DurationMinutes) * time.Minute
+	}
+
+	event := calendar.Event{Title: args.Title, Start: start, End: start.Add(duration), Location: args.Location}
+	err = calendarClient.Create(appContext, event)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("📅 Created event %q at %s", event.Title, event.Start.Format(time.RFC3339))
+	return fmt.Sprintf("Created %q on %s", event.Title, event.Start.Format("Monday, January 2 at 15:04")), nil
+}
+
+// addCalendarMenu adds the item that connects or disconnects the calendar
+func addCalendarMenu() *systray.MenuItem {
+	if calendarClient == nil {
+		return addConnectMenu("calendar", "Sign in so Claude can read and add events", nil)
+	}
+	return addConnectMenu(calendarClient.Name(), "Sign in so Claude can read and add events", calendarClient.Session())
+}
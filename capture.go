@@ -0,0 +1,150 @@
+package main
+
+import (
+	"log"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/gui"
+)
+
+// Capture sources offered in the tray
+var captureSources = []struct {
+	name  string
+	title string
+}{
+	{config.CaptureMicrophone, "Microphone"},
+	{config.CaptureLoopback, "System audio (calls, videos)"},
+	{config.CaptureRemote, "Paired device"},
+}
+
+// capturePreprocessor cleans up whatever recognition listens to
+var capturePreprocessor = audio.NewPreprocessor(audio.PreprocessOptions{})
+
+// applyPreprocessing pushes the gain control and noise gate settings to
+// the capture pipeline
+func applyPreprocessing() {
+	capturePreprocessor.SetOptions(preprocessOptions(appConfig.Audio))
+}
+
+// preprocessOptions picks the cleanup settings out of the audio settings
+func preprocessOptions(settings config.AudioConfig) audio.PreprocessOptions {
+	return audio.PreprocessOptions{
+		AutoGain:     settings.AutoGain,
+		TargetDB:     settings.AutoGainTargetDB,
+		MaxGainDB:    settings.AutoGainMaxDB,
+		NoiseGate:    settings.NoiseGate,
+		GateMarginDB: settings.NoiseGateMarginDB,
+	}
+}
+
+// setupCaptureSource switches recognition to system audio, a paired
+// device or a replayed recording when configured, falling back to the
+// microphone if policy, consent or the remote server don't allow it
+func setupCaptureSource() {
+	applyPreprocessing()
+
+	name := appConfig.Audio.CaptureSource
+	if name == "" {
+		name = config.CaptureMicrophone
+	}
+
+	err := applyCaptureSource(name)
+	if err != nil && name != config.CaptureMicrophone {
+		log.Printf("⚠️  Capture source %q unavailable, using the microphone: %v", name, err)
+		err = applyCaptureSource(config.CaptureMicrophone)
+	}
+	if err != nil {
+		log.Printf("⚠️  Failed to set up the microphone: %v", err)
+	}
+}
+
+// applyCaptureSource points recognition at a capture source. System audio
+// goes through the recording policy and keeps the indicator on while used.
+func applyCaptureSource(name string) error {
+	loopback := name == config.CaptureLoopback
+	if loopback {
+		err := beginRecordingSession("system audio", true)
+		if err != nil {
+			return err
+		}
+	}
+
+	var source audio.Source
+	var err error
+	switch name {
+	case config.CaptureRemote:
+		source, err = remoteSource()
+	case config.CaptureFile:
+		source, err = audio.NewFileSource(appConfig.Audio.ReplayFile, appConfig.Audio.ReplaySpeed, appConfig.Audio.ReplayLoop)
+	default:
+		source, err = audio.NewSource(name)
+	}
+	if err == nil {
+		metered := audio.NewMeteredSource(source, inputMeter)
+		err = speechService.SetCaptureSource(audio.NewPreprocessedSource(metered, capturePreprocessor))
+	}
+	if err != nil || !loopback {
+		gui.SetRecordingIndicator(false, "")
+	}
+	if err != nil {
+		return err
+	}
+
+	appConfig.Audio.CaptureSource = name
+	log.Printf("🎧 Capture source: %s", source.Name())
+	return nil
+}
+
+// addCaptureMenu adds the "Listen to" submenu for choosing between the
+// microphone and system audio
+func addCaptureMenu() *systray.MenuItem {
+	mCapture := systray.AddMenuItem("Listen to", "Where recognized speech comes from")
+	if speechService == nil || appConfig.Privacy.BlockLoopbackCapture {
+		mCapture.Disable()
+		return mCapture
+	}
+
+	items := make([]*systray.MenuItem, len(captureSources))
+	for i, source := range captureSources {
+		items[i] = mCapture.AddSubMenuItemCheckbox(source.title, "Recognize "+source.title, source.name == appConfig.Audio.CaptureSource)
+		if source.name == config.CaptureRemote && remoteServer == nil {
+			items[i].Hide()
+		}
+	}
+	for i := range items {
+		go func(index int) {
+			for range items[index].ClickedCh {
+				if selectCaptureSource(captureSources[index].name) {
+					checkOnly(items, index)
+				}
+			}
+		}(i)
+	}
+	return mCapture
+}
+
+// selectCaptureSource switches capture source and remembers it
+func selectCaptureSource(name string) bool {
+	if name == appConfig.Audio.CaptureSource {
+		return true
+	}
+	if isMeetingActive() || speechService.IsListening() {
+		log.Printf("⚠️  Can't switch capture source while listening")
+		return false
+	}
+
+	err := applyCaptureSource(name)
+	if err != nil {
+		log.Printf("❌ Failed to switch capture source: %v", err)
+		return false
+	}
+
+	err = appConfig.Save()
+	if err != nil {
+		log.Printf("Failed to save capture source: %v", err)
+	}
+	return true
+}
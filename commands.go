@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/commands"
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/notify"
+)
+
+// How often the grammar file is checked for changes
+const commandReloadInterval = 2 * time.Second
+
+var (
+	commandRouter    *commands.Router
+	mCommands        *systray.MenuItem
+	commandsLoaded   int
+	commandsProblems []error
+)
+
+// setupCommandRouter loads the user's command grammar, registers the
+// built-in action handlers and starts watching the file for edits
+func setupCommandRouter() {
+	commandRouter = commands.NewRouter(appConfig.Commands.Path())
+	commandRouter.Handle(commands.ActionShell, runShellAction)
+	commandRouter.Handle(commands.ActionURL, openURLAction)
+	commandRouter.Handle(commands.ActionPersona, switchPersonaAction)
+	commandRouter.Handle(commands.ActionTool, runToolAction)
+	commandRouter.SetReloadCallback(onCommandsReloaded)
+
+	commandRouter.Load()
+	commandRouter.Watch(commandReloadInterval)
+}
+
+// runShellAction starts a program without waiting for it to finish
+func runShellAction(action commands.Action, slots map[string]string) error {
+	args := make([]string, len(action.Args))
+	for i, arg := range action.Args {
+		args[i] = commands.Fill(arg, slots)
+	}
+
+	program := commands.Fill(action.Target, slots)
+	log.Printf("▶️  Running: %s %s", program, strings.Join(args, " "))
+	cmd := exec.Command(program, args...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Reap the process once it exits
+	go cmd.Wait()
+	return nil
+}
+
+// openURLAction opens a URL, escaping slot values for use in a query string
+func openURLAction(action commands.Action, slots map[string]string) error {
+	escaped := make(map[string]string, len(slots))
+	for name, value := range slots {
+		escaped[name] = url.QueryEscape(value)
+	}
+	return gui.Open(commands.Fill(action.Target, escaped))
+}
+
+// onCommandsReloaded surfaces grammar validation results in the tray
+func onCommandsReloaded(loaded int, errs []error) {
+	commandsLoaded = loaded
+	commandsProblems = errs
+	updateCommandsMenu()
+
+	if len(errs) > 0 {
+		notifications.Notify(notify.Error, fmt.Sprintf("⚠️ Command grammar has %d problem(s) - see tray menu", len(errs)))
+	}
+}
+
+// updateCommandsMenu refreshes the tray item showing the grammar status
+func updateCommandsMenu() {
+	if mCommands == nil {
+		return
+	}
+	if len(commandsProblems) > 0 {
+		mCommands.SetTitle(fmt.Sprintf("⚠️ Commands: %d loaded, %d problems", commandsLoaded, len(commandsProblems)))
+	} else {
+		mCommands.SetTitle(fmt.Sprintf("Commands: %d loaded", commandsLoaded))
+	}
+}
+
+// showCommandProblems displays the grammar validation errors
+func showCommandProblems() {
+	if len(commandsProblems) == 0 {
+		notifications.Show(notify.Info, "Commands", fmt.Sprintf("%d commands loaded from %s", commandsLoaded, appConfig.Commands.Path()))
+		return
+	}
+
+	var lines []string
+	for _, err := range commandsProblems {
+		lines = append(lines, err.Error())
+	}
+	notifications.Show(notify.Info, "Command grammar problems", strings.Join(lines, "\n"))
+}
+
+// routeCommand runs a matching user command; it reports whether the
+// transcript was handled locally and should not be sent to Claude
+func routeCommand(text string) bool {
+	if commandRouter == nil {
+		return false
+	}
+
+	handled, err := commandRouter.Route(text)
+	if !handled {
+		return false
+	}
+
+	if err != nil {
+		log.Printf("❌ Command failed: %v", err)
+		notifications.Notify(notify.Error, "❌ Command failed: "+err.Error())
+	}
+	return true
+}
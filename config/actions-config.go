@@ -0,0 +1,28 @@
+package config
+
+// ActionConfig is an allow-listed program that can be started by name
+type ActionConfig struct {
+	Name    string   `json:"name"`    // Spoken name, e.g. "browser"
+	Program string   `json:"program"` // Executable, URL or file opened with its default handler
+	Args    []string `json:"args,omitempty"`
+}
+
+// ActionsConfig holds the applications, URLs and files that can be opened
+// by voice or by Claude
+type ActionsConfig struct {
+	Allowed         []ActionConfig `json:"allowed"`
+	ConfirmUnlisted bool           `json:"confirm_unlisted"` // Ask before opening anything not allow-listed; false refuses it
+}
+
+// DefaultActionsConfig returns default actions configuration
+func DefaultActionsConfig() ActionsConfig {
+	return ActionsConfig{
+		Allowed: []ActionConfig{
+			{Name: "browser", Program: "https://www.google.com"},
+			{Name: "notepad", Program: "notepad.exe"},
+			{Name: "calculator", Program: "calc.exe"},
+			{Name: "file explorer", Program: "explorer.exe"},
+		},
+		ConfirmUnlisted: true,
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+// Output modes decide whether the microphone can hear the assistant
+const (
+	OutputSpeakers   = "speakers"   // Mute recognition while speaking
+	OutputHeadphones = "headphones" // No echo, keep listening during playback
+)
+
+// Capture sources recognition can listen to
+const (
+	CaptureMicrophone = "microphone" // The default input device
+	CaptureLoopback   = "loopback"   // What is playing on the computer (calls, videos)
+	CaptureRemote     = "remote"     // A paired device streaming over the LAN
+	CaptureFile       = "file"       // A WAV recording replayed in place of a device
+)
+
+// AudioConfig holds audio capture settings
+type AudioConfig struct {
+	CaptureSource string `json:"capture_source"` // "microphone", "loopback", "remote" or "file"
+	PreRollMs     int    `json:"preroll_ms"`     // Audio kept from before F12 is pressed, 0 disables
+	OutputMode    string `json:"output_mode"`    // "speakers" or "headphones"
+	EchoTailMs    int    `json:"echo_tail_ms"`   // How long input stays muted after speech ends
+
+	MaxListenSeconds int `json:"max_listen_seconds"` // How long one session listens before stopping, 0 for no limit
+
+	ReplayFile  string  `json:"replay_file"`  // WAV file the "file" capture source plays
+	ReplaySpeed float64 `json:"replay_speed"` // 1 is real time, 0 replays as fast as possible
+	ReplayLoop  bool    `json:"replay_loop"`  // Start the recording over when it ends
+
+	AutoGain          bool    `json:"auto_gain"`            // Even out quiet and loud microphones
+	AutoGainTargetDB  float64 `json:"auto_gain_target_db"`  // Speech level aimed for, in dBFS
+	AutoGainMaxDB     float64 `json:"auto_gain_max_db"`     // Most a quiet microphone is boosted
+	NoiseGate         bool    `json:"noise_gate"`           // Turn down background noise between words
+	NoiseGateMarginDB float64 `json:"noise_gate_margin_db"` // How far above the background speech must be
+
+	OutputDevice string  `json:"output_device"` // Device responses play on; empty uses the system default
+	Volume       float64 `json:"volume"`        // Playback volume from 0 to 1
+	DuckPercent  int     `json:"duck_percent"`  // How much other apps are lowered while active, 0 disables
+}
+
+// DefaultAudioConfig returns default audio configuration
+func DefaultAudioConfig() AudioConfig {
+	return AudioConfig{
+		CaptureSource: CaptureMicrophone,
+		PreRollMs:     1500,
+		OutputMode:    OutputSpeakers,
+		EchoTailMs:    300,
+		ReplaySpeed:   1.0,
+
+		AutoGain:          false,
+		AutoGainTargetDB:  -20,
+		AutoGainMaxDB:     24,
+		NoiseGate:         false,
+		NoiseGateMarginDB: 8,
+
+		Volume: 1.0,
+
+		DuckPercent: 60,
+	}
+}
+
+// GateEcho reports whether input should be muted during playback
+func (c *AudioConfig) GateEcho() bool {
+	return c.OutputMode != OutputHeadphones
+}
+
+// UsesLoopback reports whether recognition listens to system audio
+func (c *AudioConfig) UsesLoopback() bool {
+	return c.CaptureSource == CaptureLoopback
+}
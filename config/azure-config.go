@@ -1,35 +1,117 @@
 package config
 
+import (
+	"fmt"
+	"strings"
+)
+
+// Azure clouds, which serve speech from different domains
+const (
+	AzurePublic = "public"
+	AzureUSGov  = "usgov" // Azure Government
+	AzureChina  = "china" // Azure operated by 21Vianet
+)
+
+// azureSpeechDomains maps each cloud to the domain its regional speech
+// hosts live under
+var azureSpeechDomains = map[string]string{
+	AzurePublic: "speech.microsoft.com",
+	AzureUSGov:  "speech.azure.us",
+	AzureChina:  "speech.azure.cn",
+}
+
 // AzureConfig holds Azure Speech Service settings
 type AzureConfig struct {
-	SubscriptionKey string `json:"subscription_key"`
-	Region          string `json:"region"`
-	Language        string `json:"language"`
+	SubscriptionKey  string   `json:"subscription_key"`
+	SubscriptionKeys []string `json:"subscription_keys,omitempty"` // Extra keys to rotate through
+	KeyRotation      string   `json:"key_rotation,omitempty"`      // "round_robin" or "failover"
+	Region           string   `json:"region"`
+	Language         string   `json:"language"`
+	Languages        []string `json:"languages,omitempty"`       // Candidates to auto-detect between, e.g. ["en-US", "es-MX"]
+	Profanity        string   `json:"profanity"`                 // "masked", "removed" or "raw"
+	Mode             string   `json:"mode,omitempty"`            // "interactive", "conversation" or "dictation"; empty picks per use case
+	ProfanityWords   []string `json:"profanity_words,omitempty"` // Extra words the local filter catches
+	AudioFormat      string   `json:"audio_format,omitempty"`    // "pcm" or "opus", a tenth of the bandwidth for metered connections
+	Prewarm          bool     `json:"prewarm,omitempty"`         // Keep a connection open between sessions so audio flows the moment listening starts
+
+	Cloud      string `json:"cloud,omitempty"`       // "public", "usgov" or "china"
+	STTHost    string `json:"stt_host,omitempty"`    // Replaces the regional recognition host, e.g. a private endpoint
+	TTSHost    string `json:"tts_host,omitempty"`    // Replaces the regional speech synthesis host
+	EndpointID string `json:"endpoint_id,omitempty"` // Custom Speech deployment to recognize with
 }
 
 // DefaultAzureConfig returns default Azure configuration
 func DefaultAzureConfig() AzureConfig {
 	return AzureConfig{
-		Language: "en-US",
+		Language:    "en-US",
+		Profanity:   "masked",
+		AudioFormat: "pcm",
+		Cloud:       AzurePublic,
 		// SubscriptionKey and Region need to be set by user
 	}
 }
 
 // IsConfigured checks if Azure credentials are set
 func (c *AzureConfig) IsConfigured() bool {
-	return c.SubscriptionKey != "" && c.Region != ""
+	return len(c.Keys()) > 0 && (c.Region != "" || c.STTHost != "")
+}
+
+// STTHostName returns the host speech recognition connects to
+func (c *AzureConfig) STTHostName() string {
+	return c.hostName(c.STTHost, "stt")
+}
+
+// TTSHostName returns the host speech synthesis is requested from
+func (c *AzureConfig) TTSHostName() string {
+	return c.hostName(c.TTSHost, "tts")
+}
+
+// hostName returns override when set, otherwise the regional host of the
+// service in the configured cloud. Overrides may be given as URLs.
+func (c *AzureConfig) hostName(override, service string) string {
+	if override != "" {
+		host := override
+		if i := strings.Index(host, "://"); i >= 0 {
+			host = host[i+3:]
+		}
+		return strings.SplitN(host, "/", 2)[0]
+	}
+	domain, ok := azureSpeechDomains[c.Cloud]
+	if !ok {
+		domain = azureSpeechDomains[AzurePublic]
+	}
+	return c.Region + "." + service + "." + domain
+}
+
+// Keys returns every configured subscription key, primary first
+func (c *AzureConfig) Keys() []string {
+	return mergeKeys(c.SubscriptionKey, c.SubscriptionKeys)
+}
+
+// CandidateLanguages returns the languages to auto-detect between, with
+// the configured language first. It is empty when detection is off.
+func (c *AzureConfig) CandidateLanguages() []string {
+	languages := mergeKeys(c.Language, c.Languages)
+	if len(languages) < 2 {
+		return nil
+	}
+	return languages
 }
 
 // Validate checks if the Azure configuration is valid
 func (c *AzureConfig) Validate() error {
-	if c.SubscriptionKey == "" {
+	if len(c.Keys()) == 0 {
 		return ErrMissingAzureKey
 	}
-	if c.Region == "" {
+	if c.Region == "" && c.STTHost == "" {
 		return ErrMissingAzureRegion
 	}
+	if _, ok := azureSpeechDomains[c.Cloud]; c.Cloud != "" && !ok {
+		return fmt.Errorf("unknown Azure cloud %q", c.Cloud)
+	}
 	if c.Language == "" {
 		c.Language = "en-US" // Set default
 	}
+
 	return nil
 }
@@ -0,0 +1,19 @@
+package config
+
+// BriefingConfig controls the spoken daily briefing
+type BriefingConfig struct {
+	Times  []string `json:"times"`  // Times of day as HH:MM, e.g. "07:30"; empty disables the briefing
+	Days   []string `json:"days"`   // Days it runs, e.g. "mon"; empty means every day
+	Place  string   `json:"place"`  // Where to get the weather for; empty leaves it out
+	Prompt string   `json:"prompt"` // How Claude turns the gathered facts into the briefing
+}
+
+// DefaultBriefingConfig returns default briefing configuration
+func DefaultBriefingConfig() BriefingConfig {
+	return BriefingConfig{
+		Times: []string{},
+		Days:  []string{"mon", "tue", "wed", "thu", "fri"},
+		Prompt: "Turn these facts into a short, friendly spoken morning briefing of a few sentences. " +
+			"Mention the weather, then the day's events in order. Don't use lists or markdown.",
+	}
+}
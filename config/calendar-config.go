@@ -0,0 +1,31 @@
+package config
+
+// Calendar providers
+const (
+	CalendarGoogle  = "google"
+	CalendarOutlook = "outlook"
+)
+
+// CalendarConfig holds settings for the calendar tools. The client is an
+// OAuth app registered for the device-code flow ("TVs and Limited Input
+// devices" in Google Cloud, a public client in Microsoft Entra).
+type CalendarConfig struct {
+	Provider     string `json:"provider"` // google, outlook, or empty to disable
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret,omitempty"` // Google only
+	Tenant       string `json:"tenant"`                  // Microsoft only
+	Events       int    `json:"events"`                  // How many upcoming events to read
+}
+
+// DefaultCalendarConfig returns default calendar configuration
+func DefaultCalendarConfig() CalendarConfig {
+	return CalendarConfig{
+		Tenant: "common",
+		Events: 5,
+	}
+}
+
+// IsConfigured reports whether a calendar provider is set up
+func (c CalendarConfig) IsConfigured() bool {
+	return (c.Provider == CalendarGoogle || c.Provider == CalendarOutlook) && c.ClientID != ""
+}
@@ -2,28 +2,75 @@ package config
 
 // ClaudeConfig holds Claude API settings
 type ClaudeConfig struct {
-	APIKey       string `json:"api_key"`
-	Model        string `json:"model"`
-	SystemPrompt string `json:"system_prompt"`
+	APIKey        string   `json:"api_key"`
+	APIKeys       []string `json:"api_keys,omitempty"`     // Extra keys to rotate through
+	KeyRotation   string   `json:"key_rotation,omitempty"` // "round_robin" or "failover"
+	Model         string   `json:"model"`
+	Models        []string `json:"models,omitempty"` // Models offered in the tray menu
+	SystemPrompt  string   `json:"system_prompt"`
+	PromptCaching bool     `json:"prompt_caching"` // Cache the system prompt between requests
+
+	// Generation controls
+	MaxTokens          int      `json:"max_tokens"`
+	Temperature        *float64 `json:"temperature,omitempty"` // Unset uses the API default
+	VoiceBrevity       bool     `json:"voice_brevity"`         // Ask for short spoken answers when TTS is on
+	BrevityInstruction string   `json:"brevity_instruction"`
+
+	// Network timeouts; a long answer is never cut off while it is arriving
+	ConnectTimeoutSeconds  int `json:"connect_timeout_seconds"`  // Longest to wait for a connection to the API
+	ResponseTimeoutSeconds int `json:"response_timeout_seconds"` // Longest the API may go quiet before or during its answer
+
+	// Request limits
+	MaxConcurrent     int `json:"max_concurrent"`      // Turns sent at once; later utterances wait their turn
+	RequestsPerMinute int `json:"requests_per_minute"` // Requests are delayed to stay under this, 0 for no limit
+
+	// Extended thinking, switched on with the "Extended thinking" feature
+	ThinkingBudget int `json:"thinking_budget"` // Tokens Claude may spend thinking before it answers, at least 1024
 }
 
+// DefaultBrevityInstruction asks for answers that work when read aloud
+const DefaultBrevityInstruction = "Your answers are read aloud by a text-to-speech voice. " +
+	"Answer in one to three short spoken sentences of plain language, without markdown, " +
+	"lists, tables or code unless the user explicitly asks for them."
+
 // DefaultClaudeConfig returns default Claude configuration
 func DefaultClaudeConfig() ClaudeConfig {
 	return ClaudeConfig{
-		Model:        "claude-sonnet-4-20250514",
-		SystemPrompt: "You are a helpful AI assistant. Respond concisely and naturally for voice conversations.",
+		Model:         "claude-sonnet-4-20250514",
+		Models:        []string{"claude-sonnet-4-20250514", "claude-3-5-haiku-20241022"},
+		SystemPrompt:  "You are a helpful AI assistant. Respond concisely and naturally for voice conversations.",
+		PromptCaching: true,
+		MaxTokens:     1000,
+		VoiceBrevity:  true,
+
+		BrevityInstruction:     DefaultBrevityInstruction,
+		ConnectTimeoutSeconds:  10,
+		ResponseTimeoutSeconds: 60,
+		MaxConcurrent:          1,
+		RequestsPerMinute:      50,
+		ThinkingBudget:         4000,
 		// APIKey needs to be set by user
 	}
 }
 
 // IsConfigured checks if Claude credentials are set
 func (c *ClaudeConfig) IsConfigured() bool {
-	return c.APIKey != ""
+	return len(c.Keys()) > 0
+}
+
+// Keys returns every configured API key, primary first
+func (c *ClaudeConfig) Keys() []string {
+	return mergeKeys(c.APIKey, c.APIKeys)
+}
+
+// ModelChoices returns every model that can be selected, active model first
+func (c *ClaudeConfig) ModelChoices() []string {
+	return mergeKeys(c.Model, c.Models)
 }
 
 // Validate checks if the Claude configuration is valid
 func (c *ClaudeConfig) Validate() error {
-	if c.APIKey == "" {
+	if len(c.Keys()) == 0 {
 		return ErrMissingClaudeKey
 	}
 	if c.Model == "" {
@@ -32,5 +79,28 @@ func (c *ClaudeConfig) Validate() error {
 	if c.SystemPrompt == "" {
 		c.SystemPrompt = "You are a helpful AI assistant. Respond concisely and naturally for voice conversations."
 	}
+	if c.MaxTokens <= 0 {
+		c.MaxTokens = 1000
+	}
+	if c.ConnectTimeoutSeconds <= 0 {
+		c.ConnectTimeoutSeconds = 10
+	}
+	if c.ResponseTimeoutSeconds <= 0 {
+		c.ResponseTimeoutSeconds = 60
+	}
+	if c.MaxConcurrent <= 0 {
+		c.MaxConcurrent = 1
+	}
+	if c.RequestsPerMinute < 0 {
+		c.RequestsPerMinute = 0
+	}
+	if c.ThinkingBudget <= 0 {
+		c.ThinkingBudget = 4000
+	} else if c.ThinkingBudget < 1024 {
+		c.ThinkingBudget = 1024 // The API's minimum
+	}
+	if c.Temperature != nil && (*c.Temperature < 0 || *c.Temperature > 1) {
+		return ErrInvalidTemperature
+	}
 	return nil
 }
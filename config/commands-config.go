@@ -0,0 +1,25 @@
+package config
+
+import (
+	"path/filepath"
+)
+
+// CommandsConfig holds settings for user-defined voice commands
+type CommandsConfig struct {
+	File string `json:"file"` // Grammar file; relative paths are resolved against the config directory
+}
+
+// DefaultCommandsConfig returns default commands configuration
+func DefaultCommandsConfig() CommandsConfig {
+	return CommandsConfig{
+		File: "commands.yaml",
+	}
+}
+
+// Path returns the absolute path to the grammar file
+func (c *CommandsConfig) Path() string {
+	if filepath.IsAbs(c.File) {
+		return c.File
+	}
+	return filepath.Join(GetConfigDir(), c.File)
+}
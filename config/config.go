@@ -10,8 +10,49 @@ import (
 
 // Config holds all application configuration from params.json
 type Config struct {
-	Azure  AzureConfig  `json:"azure"`
-	Claude ClaudeConfig `json:"claude"`
+	Version       int                 `json:"version"` // Schema version, see CurrentVersion
+	Azure         AzureConfig         `json:"azure"`
+	Claude        ClaudeConfig        `json:"claude"`
+	LLM           LLMConfig           `json:"llm"`
+	Audio         AudioConfig         `json:"audio"`
+	Features      FeaturesConfig      `json:"features"`
+	Privacy       PrivacyConfig       `json:"privacy"`
+	Latency       LatencyConfig       `json:"latency"`
+	Kiosk         KioskConfig         `json:"kiosk"`
+	Handoff       HandoffConfig       `json:"handoff"`
+	Commands      CommandsConfig      `json:"commands"`
+	Conversation  ConversationConfig  `json:"conversation"`
+	Retention     RetentionConfig     `json:"retention"`
+	Intents       IntentsConfig       `json:"intents"`
+	Actions       ActionsConfig       `json:"actions"`
+	Screen        ScreenConfig        `json:"screen"`
+	Vision        VisionConfig        `json:"vision"`
+	TTS           TTSConfig           `json:"tts"`
+	STT           STTConfig           `json:"stt"`
+	Meeting       MeetingConfig       `json:"meeting"`
+	Notes         NotesConfig         `json:"notes"`
+	History       HistoryConfig       `json:"history"`
+	Metrics       MetricsConfig       `json:"metrics"`
+	Remote        RemoteConfig        `json:"remote"`
+	Hooks         HooksConfig         `json:"hooks"`
+	Calendar      CalendarConfig      `json:"calendar"`
+	Email         EmailConfig         `json:"email"`
+	Todo          TodoConfig          `json:"todo"`
+	Shell         ShellConfig         `json:"shell"`
+	Briefing      BriefingConfig      `json:"briefing"`
+	Plugins       PluginsConfig       `json:"plugins"`
+	Notifications NotificationsConfig `json:"notifications"`
+	Earcons       EarconsConfig       `json:"earcons"`
+	Overlay       OverlayConfig       `json:"overlay"`
+	Proxy         ProxyConfig         `json:"proxy"`
+	Offline       OfflineConfig       `json:"offline"`
+	Personas      []PersonaConfig     `json:"personas"`
+	Persona       string              `json:"persona"` // Name of the active persona
+	Profiles      []ProfileConfig     `json:"profiles,omitempty"`
+	Profile       string              `json:"profile,omitempty"` // Name of the active profile; empty uses the base settings
+
+	plaintextSecrets bool       // Credentials were read unencrypted
+	overrides        []override // Environment and flag values, not saved
 }
 
 // Configuration errors
@@ -19,6 +60,7 @@ var (
 	ErrMissingAzureKey    = errors.New("Azure subscription key is required")
 	ErrMissingAzureRegion = errors.New("Azure region is required")
 	ErrMissingClaudeKey   = errors.New("Claude API key is required")
+	ErrInvalidTemperature = errors.New("Claude temperature must be between 0 and 1")
 )
 
 // LoadConfig loads the entire configuration from params.json
@@ -42,20 +84,75 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %v", err)
 	}
 
-	var config Config
-	err = json.Unmarshal(data, &config)
+	// Upgrade files written by older versions
+	data, migrated, err := migrate(configPath, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start from defaults so sections missing from older files stay sensible
+	config := DefaultConfig()
+	err = json.Unmarshal(data, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %v", err)
 	}
 
-	return &config, nil
+	err = config.openSecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	if migrated {
+		err = config.Save()
+		if err != nil {
+			return nil, fmt.Errorf("failed to save migrated config: %v", err)
+		}
+	}
+
+	return config, nil
 }
 
 // DefaultConfig returns a config with default values
 func DefaultConfig() *Config {
 	return &Config{
-		Azure:  DefaultAzureConfig(),
-		Claude: DefaultClaudeConfig(),
+		Version:       CurrentVersion,
+		Azure:         DefaultAzureConfig(),
+		Claude:        DefaultClaudeConfig(),
+		LLM:           DefaultLLMConfig(),
+		Audio:         DefaultAudioConfig(),
+		Features:      DefaultFeaturesConfig(),
+		Privacy:       DefaultPrivacyConfig(),
+		Latency:       DefaultLatencyConfig(),
+		Kiosk:         DefaultKioskConfig(),
+		Handoff:       DefaultHandoffConfig(),
+		Commands:      DefaultCommandsConfig(),
+		Conversation:  DefaultConversationConfig(),
+		Retention:     DefaultRetentionConfig(),
+		Intents:       DefaultIntentsConfig(),
+		Actions:       DefaultActionsConfig(),
+		Screen:        DefaultScreenConfig(),
+		Vision:        DefaultVisionConfig(),
+		TTS:           DefaultTTSConfig(),
+		STT:           DefaultSTTConfig(),
+		Meeting:       DefaultMeetingConfig(),
+		Notes:         DefaultNotesConfig(),
+		History:       DefaultHistoryConfig(),
+		Metrics:       DefaultMetricsConfig(),
+		Remote:        DefaultRemoteConfig(),
+		Hooks:         DefaultHooksConfig(),
+		Calendar:      DefaultCalendarConfig(),
+		Email:         DefaultEmailConfig(),
+		Todo:          DefaultTodoConfig(),
+		Shell:         DefaultShellConfig(),
+		Briefing:      DefaultBriefingConfig(),
+		Plugins:       DefaultPluginsConfig(),
+		Notifications: DefaultNotificationsConfig(),
+		Earcons:       DefaultEarconsConfig(),
+		Overlay:       DefaultOverlayConfig(),
+		Proxy:         DefaultProxyConfig(),
+		Offline:       DefaultOfflineConfig(),
+		Personas:      DefaultPersonas(),
+		Persona:       "Assistant",
 	}
 }
 
@@ -70,8 +167,18 @@ func (c *Config) Save() error {
 		return fmt.Errorf("failed to create config directory: %v", err)
 	}
 
+	// Overrides belong to this run only, and credentials go to the keychain
+	// or are encrypted when the privacy policy asks for it
+	saved := c.withoutOverrides()
+	if saved.ProtectsSecrets() {
+		saved, err = saved.protected()
+		if err != nil {
+			return fmt.Errorf("failed to protect credentials: %v", err)
+		}
+	}
+
 	// Marshal config to JSON with nice formatting
-	data, err := json.MarshalIndent(c, "", "  ")
+	data, err := json.MarshalIndent(saved, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %v", err)
 	}
@@ -97,6 +204,14 @@ func (c *Config) ValidateAll() []error {
 		errors = append(errors, fmt.Errorf("Claude config: %v", err))
 	}
 
+	if err := c.Proxy.Validate(); err != nil {
+		errors = append(errors, fmt.Errorf("Proxy config: %v", err))
+	}
+
+	if err := c.LLM.Validate(); err != nil {
+		errors = append(errors, fmt.Errorf("LLM config: %v", err))
+	}
+
 	return errors
 }
 
@@ -122,6 +237,18 @@ func (c *Config) UpdateClaudeConfig(claude ClaudeConfig) error {
 	return c.Save()
 }
 
+// UpdateLLMConfig updates the provider configuration and saves
+func (c *Config) UpdateLLMConfig(llm LLMConfig) error {
+	c.LLM = llm
+	return c.Save()
+}
+
+// UpdateFeaturesConfig updates the subsystem toggles and saves
+func (c *Config) UpdateFeaturesConfig(features FeaturesConfig) error {
+	c.Features = features
+	return c.Save()
+}
+
 // getConfigPath returns the path to params.json
 func getConfigPath() string {
 	// For development, always check local params.json first
@@ -139,6 +266,20 @@ func getConfigPath() string {
 	return filepath.Join(userConfigDir, "voice-assistant", "params.json")
 }
 
+// mergeKeys combines a primary key with extra keys, dropping blanks and duplicates
+func mergeKeys(primary string, extra []string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range append([]string{primary}, extra...) {
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 // GetConfigPath returns the config file path (for display to user)
 func GetConfigPath() string {
 	return getConfigPath()
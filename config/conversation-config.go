@@ -0,0 +1,46 @@
+package config
+
+import "time"
+
+// DefaultConversationName is the conversation used until another is chosen
+const DefaultConversationName = "General"
+
+// ConversationConfig holds conversation flow settings
+type ConversationConfig struct {
+	HandsFree bool     `json:"hands_free"` // Re-open the microphone after every response
+	StopWords []string `json:"stop_words"` // Phrases that end a hands-free session
+
+	Active  string `json:"active"`  // Name of the current named conversation
+	Persist bool   `json:"persist"` // Save named conversations so they survive a restart
+
+	IdleResetMinutes int `json:"idle_reset_minutes"` // Archive the conversation and start afresh after this long without a turn; 0 never does
+}
+
+// DefaultConversationConfig returns default conversation configuration
+func DefaultConversationConfig() ConversationConfig {
+	return ConversationConfig{
+		HandsFree: false,
+		StopWords: []string{"stop listening", "goodbye", "that's all"},
+		Active:    DefaultConversationName,
+		Persist:   true,
+
+		IdleResetMinutes: 30,
+	}
+}
+
+// ActiveName returns the current conversation's name
+func (c *ConversationConfig) ActiveName() string {
+	if c.Active == "" {
+		return DefaultConversationName
+	}
+	return c.Active
+}
+
+// IdleReset returns how long a conversation may sit unused before it is
+// archived, or 0 if it never is
+func (c *ConversationConfig) IdleReset() time.Duration {
+	if c.IdleResetMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(c.IdleResetMinutes) * time.Minute
+}
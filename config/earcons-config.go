@@ -0,0 +1,19 @@
+package config
+
+// EarconsConfig controls the short sounds played when the assistant starts
+// or stops listening and when something fails
+type EarconsConfig struct {
+	Enabled bool    `json:"enabled"`
+	Volume  float64 `json:"volume"` // Relative to the playback volume, 0 to 1
+	Start   string  `json:"start"`  // WAV file replacing the start-listening chime; empty uses the built-in one
+	Stop    string  `json:"stop"`   // WAV file replacing the stop chime
+	Error   string  `json:"error"`  // WAV file replacing the error buzz
+}
+
+// DefaultEarconsConfig returns default earcon configuration
+func DefaultEarconsConfig() EarconsConfig {
+	return EarconsConfig{
+		Enabled: true,
+		Volume:  0.4,
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+// EmailConfig holds the SMTP account used to send dictated email
+type EmailConfig struct {
+	SMTPHost string            `json:"smtp_host"` // e.g. smtp.gmail.com; empty disables email
+	SMTPPort int               `json:"smtp_port"`
+	Username string            `json:"username"`
+	Password string            `json:"password,omitempty"` // An app password for Gmail and Outlook.com
+	From     string            `json:"from"`               // Defaults to the username
+	Contacts map[string]string `json:"contacts"`           // Spoken names to addresses, e.g. "ana": "ana@example.com"
+}
+
+// DefaultEmailConfig returns default email configuration
+func DefaultEmailConfig() EmailConfig {
+	return EmailConfig{
+		SMTPPort: 587,
+		Contacts: map[string]string{},
+	}
+}
+
+// IsConfigured reports whether an SMTP account is set up
+func (c EmailConfig) IsConfigured() bool {
+	return c.SMTPHost != "" && c.Username != ""
+}
+
+// Sender returns the address mail is sent from
+func (c EmailConfig) Sender() string {
+	if c.From != "" {
+		return c.From
+	}
+	return c.Username
+}
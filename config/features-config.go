@@ -0,0 +1,21 @@
+package config
+
+// FeaturesConfig holds runtime toggles for optional subsystems
+type FeaturesConfig struct {
+	TTS      bool `json:"tts"`
+	Tools    bool `json:"tools"`
+	Memory   bool `json:"memory"`
+	Overlay  bool `json:"overlay"`  // On-screen bar with live transcription
+	Thinking bool `json:"thinking"` // Let Claude think before answering hard questions
+}
+
+// DefaultFeaturesConfig returns default subsystem toggles
+func DefaultFeaturesConfig() FeaturesConfig {
+	return FeaturesConfig{
+		TTS:      true,
+		Tools:    true,
+		Memory:   true,
+		Overlay:  false,
+		Thinking: false,
+	}
+}
@@ -0,0 +1,13 @@
+package config
+
+// HandoffConfig controls where "Continue in browser" sends the conversation
+type HandoffConfig struct {
+	Target string `json:"target"` // "claude", "chatgpt", or "clipboard"
+}
+
+// DefaultHandoffConfig returns default handoff configuration
+func DefaultHandoffConfig() HandoffConfig {
+	return HandoffConfig{
+		Target: "claude",
+	}
+}
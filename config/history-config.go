@@ -0,0 +1,15 @@
+package config
+
+// HistoryConfig controls the local conversation history database
+type HistoryConfig struct {
+	Enabled   bool `json:"enabled"`    // Store every turn in history.db
+	TrayItems int  `json:"tray_items"` // Recent turns listed in the "History" menu
+}
+
+// DefaultHistoryConfig returns default history configuration
+func DefaultHistoryConfig() HistoryConfig {
+	return HistoryConfig{
+		Enabled:   true,
+		TrayItems: 10,
+	}
+}
@@ -0,0 +1,24 @@
+package config
+
+// HookConfig is one hook: an HTTP endpoint POSTed a JSON body, or a local
+// program run with the text as its last argument and on stdin
+type HookConfig struct {
+	URL     string   `json:"url,omitempty"`
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+}
+
+// HooksConfig lists the hooks run on recognition and response events
+type HooksConfig struct {
+	OnTranscript   []HookConfig `json:"on_transcript"`   // Every recognized phrase
+	OnResponse     []HookConfig `json:"on_response"`     // Every Claude response
+	OnError        []HookConfig `json:"on_error"`        // Speech and Claude failures
+	TimeoutSeconds int          `json:"timeout_seconds"` // How long a hook may run
+}
+
+// DefaultHooksConfig returns default hooks configuration
+func DefaultHooksConfig() HooksConfig {
+	return HooksConfig{
+		TimeoutSeconds: 10,
+	}
+}
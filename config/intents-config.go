@@ -0,0 +1,42 @@
+package config
+
+// IntentsConfig holds the phrases for local assistant control commands.
+// Keys are intent names: new_conversation, repeat, repeat_previous, slower, stop_listening,
+// copy, delete_today, delete_all, open, screen, clipboard_image, note, play_pause, next_track,
+// previous_track, volume_up, volume_down, mute, lock_screen, send_email,
+// discard_email and add_todo. Phrases
+// ending in {target} match any transcript that starts with the rest of the
+// phrase.
+type IntentsConfig struct {
+	Phrases map[string][]string `json:"phrases"`
+}
+
+// DefaultIntentsConfig returns default intent phrases
+func DefaultIntentsConfig() IntentsConfig {
+	return IntentsConfig{
+		Phrases: map[string][]string{
+			"new_conversation": {"new conversation", "start over", "forget that"},
+			"repeat":           {"repeat that", "say that again", "what did you say"},
+			"repeat_previous":  {"repeat the previous answer", "what did you say before that", "say the one before that again"},
+			"slower":           {"read that slower", "say that slower", "slower"},
+			"stop_listening":   {"stop listening"},
+			"copy":             {"copy that", "copy that to the clipboard"},
+			"delete_today":     {"delete everything recorded today", "delete today's recordings"},
+			"delete_all":       {"delete all my data", "wipe all my data"},
+			"open":             {"open {target}", "launch {target}", "start {target}"},
+			"screen":           {"look at my screen", "look at my screen {target}", "what's on my screen"},
+			"clipboard_image":  {"describe the image on my clipboard", "look at my clipboard", "look at my clipboard {target}", "look at the image on my clipboard {target}"},
+			"note":             {"note to self {target}", "take a note {target}", "make a note {target}"},
+			"play_pause":       {"play", "pause", "resume", "play music", "pause music", "pause the music"},
+			"next_track":       {"next", "next track", "next song", "skip", "skip this song"},
+			"previous_track":   {"previous", "previous track", "previous song", "go back a song"},
+			"volume_up":        {"volume up", "turn it up", "louder", "turn the volume up"},
+			"volume_down":      {"volume down", "turn it down", "quieter", "turn the volume down"},
+			"mute":             {"mute", "unmute", "mute the sound"},
+			"lock_screen":      {"lock the screen", "lock my computer", "lock screen"},
+			"send_email":       {"send it", "yes send it", "send the email"},
+			"discard_email":    {"discard the email", "don't send it", "cancel the email"},
+			"add_todo":         {"add to my todo list {target}", "add a task {target}", "remind me to {target}", "todo {target}"},
+		},
+	}
+}
@@ -0,0 +1,17 @@
+package config
+
+// KioskConfig holds settings for running on a shared demo machine
+type KioskConfig struct {
+	Enabled               bool     `json:"enabled"`
+	SystemPrompt          string   `json:"system_prompt"`           // Fixed persona; empty keeps the Claude system prompt
+	AllowedTools          []string `json:"allowed_tools"`           // Tools available in kiosk mode; empty allows none
+	SessionTimeoutMinutes int      `json:"session_timeout_minutes"` // Idle time before the conversation restarts
+}
+
+// DefaultKioskConfig returns default kiosk configuration
+func DefaultKioskConfig() KioskConfig {
+	return KioskConfig{
+		Enabled:               false,
+		SessionTimeoutMinutes: 2,
+	}
+}
@@ -0,0 +1,17 @@
+package config
+
+// LatencyConfig holds the end-to-end turn latency budget
+type LatencyConfig struct {
+	BudgetMs          int    `json:"budget_ms"`           // 0 disables degradation
+	FallbackModel     string `json:"fallback_model"`      // Faster model used when over budget
+	DegradedMaxTokens int    `json:"degraded_max_tokens"` // max_tokens used when over budget
+}
+
+// DefaultLatencyConfig returns default latency configuration
+func DefaultLatencyConfig() LatencyConfig {
+	return LatencyConfig{
+		BudgetMs:          6000,
+		FallbackModel:     "claude-3-5-haiku-20241022",
+		DegradedMaxTokens: 300,
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import "fmt"
+
+// LLM providers
+const (
+	LLMClaude  = "claude"
+	LLMGemini  = "gemini"
+	LLMMistral = "mistral"
+)
+
+// LLMConfig picks which vendor answers questions. Claude's settings stay
+// under "claude"; the others are here.
+type LLMConfig struct {
+	Provider string       `json:"provider"` // "claude", "gemini" or "mistral"
+	Gemini   VendorConfig `json:"gemini"`
+	Mistral  VendorConfig `json:"mistral"`
+}
+
+// VendorConfig holds the credentials and model for a non-Claude provider
+type VendorConfig struct {
+	APIKey string   `json:"api_key"`
+	Model  string   `json:"model"`
+	Models []string `json:"models,omitempty"` // Models offered in the tray menu
+}
+
+// DefaultLLMConfig returns default provider configuration
+func DefaultLLMConfig() LLMConfig {
+	return LLMConfig{
+		Provider: LLMClaude,
+		Gemini: VendorConfig{
+			Model:  "gemini-2.5-flash",
+			Models: []string{"gemini-2.5-flash", "gemini-2.5-pro"},
+		},
+		Mistral: VendorConfig{
+			Model:  "mistral-medium-latest",
+			Models: []string{"mistral-medium-latest", "mistral-large-latest", "mistral-small-latest"},
+		},
+	}
+}
+
+// Vendor returns the settings of the active provider other than Claude,
+// or nil when Claude answers
+func (c *LLMConfig) Vendor() *VendorConfig {
+	switch c.Provider {
+	case LLMGemini:
+		return &c.Gemini
+	case LLMMistral:
+		return &c.Mistral
+	}
+	return nil
+}
+
+// ModelChoices returns the models offered in the tray, the configured one
+// first
+func (c *VendorConfig) ModelChoices() []string {
+	return mergeKeys(c.Model, c.Models)
+}
+
+// ModelSetting returns the setting holding the active provider's model
+func (c *LLMConfig) ModelSetting() string {
+	if c.Vendor() == nil {
+		return "claude.model"
+	}
+	return "llm." + c.Provider + ".model"
+}
+
+// Validate checks the provider is known and has a key
+func (c *LLMConfig) Validate() error {
+	switch c.Provider {
+	case "":
+		c.Provider = LLMClaude
+	case LLMClaude:
+	case LLMGemini, LLMMistral:
+		if c.Vendor().APIKey == "" {
+			return fmt.Errorf("%s needs an api_key", c.Provider)
+		}
+	default:
+		return fmt.Errorf("unknown provider %q", c.Provider)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package config
+
+import "path/filepath"
+
+// MeetingConfig holds meeting transcription settings
+type MeetingConfig struct {
+	Directory  string `json:"directory"`   // Where transcripts are written; empty uses the config folder
+	DualSource bool   `json:"dual_source"` // Also transcribe system audio, labelling lines "Me:" and "Them:"
+}
+
+// DefaultMeetingConfig returns default meeting configuration
+func DefaultMeetingConfig() MeetingConfig {
+	return MeetingConfig{}
+}
+
+// TranscriptDir returns the folder meeting transcripts are written to
+func (c *MeetingConfig) TranscriptDir() string {
+	if c.Directory != "" {
+		return c.Directory
+	}
+	return filepath.Join(GetConfigDir(), "meetings")
+}
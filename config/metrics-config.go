@@ -0,0 +1,11 @@
+package config
+
+// MetricsConfig controls latency metrics export
+type MetricsConfig struct {
+	PrometheusAddr string `json:"prometheus_addr"` // e.g. "127.0.0.1:9464"; empty disables the /metrics endpoint
+}
+
+// DefaultMetricsConfig returns default metrics configuration
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{}
+}
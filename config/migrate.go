@@ -0,0 +1,65 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+)
+
+// CurrentVersion is the params.json schema this build writes
+const CurrentVersion = 1
+
+// migration upgrades a raw params.json document by one version
+type migration func(doc map[string]interface{}) error
+
+// migrations[n] upgrades version n to n+1. Add a function here, and bump
+// CurrentVersion, whenever a key is renamed or its meaning changes; new
+// sections need nothing since missing sections keep their defaults.
+var migrations = []migration{
+	// 0 -> 1: files written before versioning; the schema is unchanged
+	func(doc map[string]interface{}) error { return nil },
+}
+
+// migrate upgrades params.json data to CurrentVersion, keeping a backup of
+// the original next to it. It reports whether anything was migrated.
+func migrate(path string, data []byte) ([]byte, bool, error) {
+	var doc map[string]interface{}
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse config file: %v", err)
+	}
+
+	version := 0
+	if v, ok := doc["version"].(float64); ok {
+		version = int(v)
+	}
+	if version > CurrentVersion {
+		log.Printf("⚠️  %s is from a newer version (schema %d, this build knows %d); some settings may be ignored", path, version, CurrentVersion)
+		return data, false, nil
+	}
+	if version == CurrentVersion {
+		return data, false, nil
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	err = os.WriteFile(backup, data, 0600)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to back up config before migrating: %v", err)
+	}
+
+	for v := version; v < CurrentVersion; v++ {
+		err = migrations[v](doc)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to migrate config from version %d: %v", v, err)
+		}
+	}
+	doc["version"] = CurrentVersion
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, false, err
+	}
+	log.Printf("🔄 Migrated %s from schema %d to %d (backup: %s)", path, version, CurrentVersion, backup)
+	return migrated, true, nil
+}
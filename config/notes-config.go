@@ -0,0 +1,28 @@
+package config
+
+import "path/filepath"
+
+// NotesConfig controls where "note to self" writes. Path and Template
+// accept {date}; Template also accepts {time}, {text} and {tags}.
+type NotesConfig struct {
+	Path     string   `json:"path"`     // Markdown file or daily note, e.g. "C:/Vault/Daily/{date}.md"; empty uses notes.md in the config folder
+	Template string   `json:"template"` // How each note is written
+	Tags     []string `json:"tags"`     // Added to every note, e.g. "#voice"
+	CleanUp  bool     `json:"clean_up"` // Let Claude fix punctuation and filler before saving
+}
+
+// DefaultNotesConfig returns default notes configuration
+func DefaultNotesConfig() NotesConfig {
+	return NotesConfig{
+		Template: "- {time} {text} {tags}",
+		Tags:     []string{"#voice"},
+	}
+}
+
+// NotesPath returns the notes file path template
+func (c *NotesConfig) NotesPath() string {
+	if c.Path != "" {
+		return c.Path
+	}
+	return filepath.Join(GetConfigDir(), "notes.md")
+}
@@ -0,0 +1,32 @@
+package config
+
+// Notification verbosity levels
+const (
+	NotifySilent  = "silent"  // Show nothing
+	NotifyMinimal = "minimal" // Errors, reminders and results of what was asked
+	NotifyVerbose = "verbose" // Also progress such as recording started and stopped
+)
+
+// NotificationsConfig controls desktop notifications
+type NotificationsConfig struct {
+	Level   string          `json:"level"`   // "silent", "minimal" or "verbose"
+	Sound   bool            `json:"sound"`   // Play the notification sound; reminders always do
+	Actions bool            `json:"actions"` // Buttons such as "Copy response" and "Retry" on Windows toasts
+	Events  map[string]bool `json:"events"`  // "status", "info", "response", "reminder" and "error"; false hides that kind
+}
+
+// DefaultNotificationsConfig returns default notification configuration
+func DefaultNotificationsConfig() NotificationsConfig {
+	return NotificationsConfig{
+		Level:   NotifyVerbose,
+		Sound:   true,
+		Actions: true,
+		Events: map[string]bool{
+			"status":   true,
+			"info":     true,
+			"response": true,
+			"reminder": true,
+			"error":    true,
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package config
+
+import "path/filepath"
+
+// OfflineConfig holds what happens to requests made while the network is
+// down
+type OfflineConfig struct {
+	Enabled     bool `json:"enabled"`      // Queue questions Claude couldn't be sent and ask them once back online
+	RecordAudio bool `json:"record_audio"` // Record the microphone when speech recognition can't connect
+	MaxItems    int  `json:"max_items"`    // Oldest requests are dropped beyond this
+}
+
+// DefaultOfflineConfig returns default offline queue configuration
+func DefaultOfflineConfig() OfflineConfig {
+	return OfflineConfig{
+		Enabled:     true,
+		RecordAudio: true,
+		MaxItems:    20,
+	}
+}
+
+// OfflineQueuePath returns where requests wait while the network is down
+func OfflineQueuePath() string {
+	return filepath.Join(GetConfigDir(), "offline-queue.json")
+}
+
+// OfflineAudioDir returns where recordings wait to be transcribed
+func OfflineAudioDir() string {
+	return filepath.Join(GetConfigDir(), "offline-audio")
+}
@@ -0,0 +1,18 @@
+package config
+
+// OverlayConfig controls the on-screen live transcription bar, which is
+// turned on with the "overlay" feature
+type OverlayConfig struct {
+	Position     string `json:"position"`      // "corner" or "cursor"
+	HideSeconds  int    `json:"hide_seconds"`  // How long the last text stays once the assistant is idle
+	ShowResponse bool   `json:"show_response"` // Show Claude's answer after the transcript
+}
+
+// DefaultOverlayConfig returns default overlay configuration
+func DefaultOverlayConfig() OverlayConfig {
+	return OverlayConfig{
+		Position:     "corner",
+		HideSeconds:  6,
+		ShowResponse: true,
+	}
+}
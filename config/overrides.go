@@ -0,0 +1,224 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Configuration values are resolved in this order, later winning:
+//
+//  1. Built-in defaults
+//  2. params.json
+//  3. The active profile, chosen in params.json or with -profile
+//  4. Environment variables: VA_ followed by the JSON path in upper case
+//     with underscores, e.g. VA_CLAUDE_MODEL or VA_AZURE_REGION
+//  5. Command-line flags: -set claude.model=claude-sonnet-4-5, repeatable
+//
+// Lists take comma-separated values. Overrides apply to the running
+// process only and are never written back to params.json.
+const EnvPrefix = "VA_"
+
+// Short names for the settings most often overridden
+var envAliases = map[string]string{
+	"VA_AZURE_KEY":  "azure.subscription_key",
+	"VA_CLAUDE_KEY": "claude.api_key",
+}
+
+// override is a value applied on top of params.json
+type override struct {
+	path  string
+	value string
+	file  reflect.Value // The value from params.json, restored when saving
+}
+
+// ApplyOverrides applies VA_ environment variables from environ, then
+// -set flags in "path=value" form. Unknown environment variables are
+// ignored since VA_ is also used internally; unknown flag paths are errors.
+func (c *Config) ApplyOverrides(environ []string, flags []string) error {
+	fields := make(map[string]string)
+	walkFields(reflect.ValueOf(c).Elem(), "", func(path string, _ reflect.Value) {
+		fields[EnvPrefix+strings.ToUpper(strings.ReplaceAll(path, ".", "_"))] = path
+	})
+	for env, path := range envAliases {
+		fields[env] = path
+	}
+
+	for _, entry := range environ {
+		name, value, ok := strings.Cut(entry, "=")
+		path, known := fields[name]
+		if !ok || !known {
+			continue
+		}
+		err := c.override(path, value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+
+	for _, flag := range flags {
+		path, value, ok := strings.Cut(flag, "=")
+		if !ok {
+			return fmt.Errorf("-set %s: expected path=value", flag)
+		}
+		err := c.override(strings.TrimSpace(path), value)
+		if err != nil {
+			return fmt.Errorf("-set %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// override sets one value by its JSON path, remembering the file value
+func (c *Config) override(path, value string) error {
+	field, ok := lookupField(reflect.ValueOf(c).Elem(), path)
+	if !ok {
+		return fmt.Errorf("unknown setting %q", path)
+	}
+
+	file := reflect.New(field.Type()).Elem()
+	file.Set(field)
+	err := setField(field, value)
+	if err != nil {
+		return err
+	}
+
+	// Overriding a path twice keeps the value params.json had, not the
+	// first override's
+	for i := range c.overrides {
+		if c.overrides[i].path == path {
+			c.overrides[i].value = value
+			return nil
+		}
+	}
+	c.overrides = append(c.overrides, override{path: path, value: value, file: file})
+	return nil
+}
+
+// withoutOverrides returns a copy of the config with overridden values
+// that haven't been changed since put back to what params.json had
+func (c *Config) withoutOverrides() *Config {
+	saved := *c
+	for _, o := range c.overrides {
+		current, _ := lookupField(reflect.ValueOf(c).Elem(), o.path)
+		probe := reflect.New(current.Type()).Elem()
+		if setField(probe, o.value) != nil || !reflect.DeepEqual(current.Interface(), probe.Interface()) {
+			continue // Changed at runtime, e.g. from the tray; keep the new value
+		}
+
+		field, _ := lookupField(reflect.ValueOf(&saved).Elem(), o.path)
+		field.Set(o.file)
+	}
+	return &saved
+}
+
+// walkFields calls visit for every settable leaf field under v, by JSON path
+func walkFields(v reflect.Value, prefix string, visit func(path string, field reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if name == "" {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			walkFields(field, path, visit)
+			continue
+		}
+		if settable(field) {
+			visit(path, field)
+		}
+	}
+}
+
+// lookupField finds a field by JSON path, e.g. "claude.model"
+func lookupField(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		found := false
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if jsonName(t.Field(i)) == name {
+				v = v.Field(i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return reflect.Value{}, false
+		}
+	}
+	return v, settable(v)
+}
+
+// jsonName returns the JSON key of an exported field, or "" for skipped ones
+func jsonName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// settable reports whether a field's type can be parsed from a string
+func settable(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return field.Type().Elem().Kind() == reflect.String
+	}
+	return false
+}
+
+// setField parses value into a field
+func setField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("expected true or false, got %q", value)
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("expected a whole number, got %q", value)
+		}
+		field.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("expected a number, got %q", value)
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		field.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("can't be set from the environment or flags")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package config
+
+import "strings"
+
+// PersonaConfig is a named system prompt preset
+type PersonaConfig struct {
+	Name         string `json:"name"`
+	SystemPrompt string `json:"system_prompt"`   // Empty uses the Claude system prompt
+	Voice        string `json:"voice,omitempty"` // TTS voice; empty uses the default voice
+}
+
+// DefaultPersonas returns the built-in persona presets
+func DefaultPersonas() []PersonaConfig {
+	return []PersonaConfig{
+		{
+			Name: "Assistant",
+		},
+		{
+			Name: "Translator",
+			SystemPrompt: "You are a translator. Translate whatever the user says into English, " +
+				"or into the language they name. Reply with the translation only.",
+		},
+		{
+			Name: "Coder",
+			SystemPrompt: "You are a senior software engineer pairing with the user. " +
+				"Give precise technical answers and put any code in fenced code blocks.",
+		},
+	}
+}
+
+// FindPersona looks up a persona by name, ignoring case
+func (c *Config) FindPersona(name string) (PersonaConfig, bool) {
+	for _, persona := range c.Personas {
+		if strings.EqualFold(persona.Name, strings.TrimSpace(name)) {
+			return persona, true
+		}
+	}
+	return PersonaConfig{}, false
+}
+
+// ActivePersona returns the selected persona, falling back to a persona
+// built from the Claude system prompt
+func (c *Config) ActivePersona() PersonaConfig {
+	persona, ok := c.FindPersona(c.Persona)
+	if !ok {
+		persona = PersonaConfig{Name: c.Persona}
+	}
+	if persona.SystemPrompt == "" {
+		persona.SystemPrompt = c.Claude.SystemPrompt
+	}
+	return persona
+}
+
+// UpdatePersona selects the active persona and saves
+func (c *Config) UpdatePersona(name string) error {
+	c.Persona = name
+	return c.Save()
+}
@@ -0,0 +1,29 @@
+package config
+
+import "path/filepath"
+
+// PluginsConfig controls third-party plugins: programs in the plugins
+// folder that add Claude tools and voice intents
+type PluginsConfig struct {
+	Enabled        bool     `json:"enabled"`
+	Dir            string   `json:"dir"`             // Empty uses plugins/ in the config folder
+	Disabled       []string `json:"disabled"`        // Plugin names not to start
+	TimeoutSeconds int      `json:"timeout_seconds"` // Longest a plugin may take to answer
+}
+
+// DefaultPluginsConfig returns default plugins configuration
+func DefaultPluginsConfig() PluginsConfig {
+	return PluginsConfig{
+		Enabled:        true,
+		Disabled:       []string{},
+		TimeoutSeconds: 15,
+	}
+}
+
+// PluginsDir returns the folder plugins are discovered in
+func (c *PluginsConfig) PluginsDir() string {
+	if c.Dir != "" {
+		return c.Dir
+	}
+	return filepath.Join(GetConfigDir(), "plugins")
+}
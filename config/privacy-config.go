@@ -0,0 +1,23 @@
+package config
+
+// PrivacyConfig holds recording compliance and context sharing policies
+type PrivacyConfig struct {
+	RequireRecordingConsent bool `json:"require_recording_consent"` // Confirm before meeting/loopback capture starts
+	BlockLoopbackCapture    bool `json:"block_loopback_capture"`    // Never capture system audio
+	ShareActiveWindow       bool `json:"share_active_window"`       // Tell Claude which app and window are in front
+	EncryptAtRest           bool `json:"encrypt_at_rest"`           // Encrypt history, conversations, the offline queue and the keys in params.json with a DPAPI-protected key
+
+	CredentialStore string `json:"credential_store"` // "file" or "keychain"
+}
+
+// DefaultPrivacyConfig returns default privacy configuration
+func DefaultPrivacyConfig() PrivacyConfig {
+	return PrivacyConfig{
+		RequireRecordingConsent: true,
+		BlockLoopbackCapture:    false,
+		ShareActiveWindow:       false,
+		EncryptAtRest:           false,
+
+		CredentialStore: CredentialsFile,
+	}
+}
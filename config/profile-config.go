@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProfileConfig is a named set of settings, e.g. "work" and "home", applied
+// on top of the rest of params.json. Empty fields keep the base value.
+type ProfileConfig struct {
+	Name                 string   `json:"name"`
+	ClaudeAPIKey         string   `json:"claude_api_key,omitempty"`
+	AzureSubscriptionKey string   `json:"azure_subscription_key,omitempty"`
+	AzureRegion          string   `json:"azure_region,omitempty"`
+	Provider             string   `json:"provider,omitempty"` // "claude", "gemini" or "mistral"
+	Model                string   `json:"model,omitempty"`    // For the profile's provider
+	Language             string   `json:"language,omitempty"`
+	Languages            []string `json:"languages,omitempty"`
+	SystemPrompt         string   `json:"system_prompt,omitempty"`
+	Persona              string   `json:"persona,omitempty"`
+}
+
+// settings maps the profile's fields to the settings they replace
+func (p *ProfileConfig) settings() map[string]string {
+	return map[string]string{
+		"claude.api_key":         p.ClaudeAPIKey,
+		"azure.subscription_key": p.AzureSubscriptionKey,
+		"azure.region":           p.AzureRegion,
+		"llm.provider":           p.Provider,
+		"azure.language":         p.Language,
+		"azure.languages":        strings.Join(p.Languages, ","),
+		"claude.system_prompt":   p.SystemPrompt,
+		"persona":                p.Persona,
+	}
+}
+
+// FindProfile looks up a profile by name, ignoring case
+func (c *Config) FindProfile(name string) (ProfileConfig, bool) {
+	for _, profile := range c.Profiles {
+		if strings.EqualFold(profile.Name, strings.TrimSpace(name)) {
+			return profile, true
+		}
+	}
+	return ProfileConfig{}, false
+}
+
+// ApplyProfile applies a profile for this run, or the saved one when name
+// is empty. Like other overrides, profile values are not saved into the
+// base settings.
+func (c *Config) ApplyProfile(name string) error {
+	if name != "" && name != c.Profile {
+		err := c.override("profile", name)
+		if err != nil {
+			return err
+		}
+	}
+	if c.Profile == "" {
+		return nil
+	}
+
+	profile, ok := c.FindProfile(c.Profile)
+	if !ok {
+		return fmt.Errorf("unknown profile %q", c.Profile)
+	}
+	for path, value := range profile.settings() {
+		if value == "" {
+			continue
+		}
+		err := c.override(path, value)
+		if err != nil {
+			return fmt.Errorf("profile %s: %v", profile.Name, err)
+		}
+	}
+	// The model belongs to whichever provider the profile ended up with
+	if profile.Model != "" {
+		err := c.override(c.LLM.ModelSetting(), profile.Model)
+		if err != nil {
+			return fmt.Errorf("profile %s: %v", profile.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ProxyConfig routes Claude and Azure traffic through an HTTP proxy.
+// Without a URL the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+// variables decide.
+type ProxyConfig struct {
+	URL      string `json:"url"`      // e.g. "http://proxy.corp.example:8080"
+	Username string `json:"username"` // For proxies that ask for a login
+	Password string `json:"password"`
+}
+
+// DefaultProxyConfig returns default proxy configuration
+func DefaultProxyConfig() ProxyConfig {
+	return ProxyConfig{}
+}
+
+// ProxyURL returns the proxy with its credentials, or nil to follow the
+// environment
+func (c *ProxyConfig) ProxyURL() (*url.URL, error) {
+	if c.URL == "" {
+		return nil, nil
+	}
+
+	raw := c.URL
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	proxy, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %v", err)
+	}
+	if proxy.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: no host", c.URL)
+	}
+	if c.Username != "" {
+		proxy.User = url.UserPassword(c.Username, c.Password)
+	}
+	return proxy, nil
+}
+
+// Validate checks if the proxy configuration is valid
+func (c *ProxyConfig) Validate() error {
+	_, err := c.ProxyURL()
+	return err
+}
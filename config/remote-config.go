@@ -0,0 +1,25 @@
+package config
+
+// RemoteConfig controls the remote microphone server, which lets a paired
+// phone or other device on the LAN stream audio to the assistant
+type RemoteConfig struct {
+	Enabled        bool     `json:"enabled"`
+	Addr           string   `json:"addr"`            // Listen address, e.g. ":8765"
+	PairingMinutes int      `json:"pairing_minutes"` // How long a pairing code stays valid
+	Tokens         []string `json:"tokens"`          // Tokens of paired devices
+}
+
+// DefaultRemoteConfig returns default remote microphone configuration
+func DefaultRemoteConfig() RemoteConfig {
+	return RemoteConfig{
+		Enabled:        false,
+		Addr:           ":8765",
+		PairingMinutes: 5,
+	}
+}
+
+// AddRemoteToken remembers a newly paired device
+func (c *Config) AddRemoteToken(token string) error {
+	c.Remote.Tokens = append(c.Remote.Tokens, token)
+	return c.Save()
+}
@@ -0,0 +1,23 @@
+package config
+
+// RetentionConfig controls how long per-turn audio is kept
+type RetentionConfig struct {
+	Audio string `json:"audio"` // "never", "days", or "forever"
+	Days  int    `json:"days"`  // Used when audio is "days"
+
+	// Session archive: raw audio and transcript of each session, opt-in
+	ArchiveSessions bool    `json:"archive_sessions"`
+	ArchiveMaxDays  int     `json:"archive_max_days"` // 0 keeps sessions regardless of age
+	ArchiveMaxGB    float64 `json:"archive_max_gb"`   // 0 keeps sessions regardless of size
+}
+
+// DefaultRetentionConfig returns default retention configuration
+func DefaultRetentionConfig() RetentionConfig {
+	return RetentionConfig{
+		Audio: "never",
+		Days:  7,
+
+		ArchiveMaxDays: 30,
+		ArchiveMaxGB:   2,
+	}
+}
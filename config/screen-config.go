@@ -0,0 +1,17 @@
+package config
+
+// ScreenConfig holds settings for sharing screenshots with Claude
+type ScreenConfig struct {
+	Enabled bool   `json:"enabled"`  // Off by default; screenshots can contain anything on screen
+	Target  string `json:"target"`   // "monitor" or "window"
+	MaxEdge int    `json:"max_edge"` // Longest screenshot edge in pixels after downscaling
+}
+
+// DefaultScreenConfig returns default screen capture configuration
+func DefaultScreenConfig() ScreenConfig {
+	return ScreenConfig{
+		Enabled: false,
+		Target:  "monitor",
+		MaxEdge: 1568,
+	}
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"voice-assistant/internal/secure"
+)
+
+// The at-rest cipher is loaded once, on first use
+var (
+	secretCipher    *secure.Cipher
+	secretCipherErr error
+	secretOnce      sync.Once
+)
+
+// Cipher returns the cipher used for data encrypted at rest. Its key lives
+// next to params.json, protected by the user's Windows login.
+func Cipher() (*secure.Cipher, error) {
+	secretOnce.Do(func() {
+		secretCipher, secretCipherErr = secure.LoadCipher(filepath.Join(GetConfigDir(), "secret.key"))
+	})
+	return secretCipher, secretCipherErr
+}
+
+// Where credentials are kept
+const (
+	CredentialsFile     = "file"     // In params.json, encrypted when EncryptAtRest is set
+	CredentialsKeychain = "keychain" // In the Windows Credential Manager, referenced from params.json
+)
+
+// secret is a credential field and the keychain entry it is stored under
+type secret struct {
+	name  string
+	value *string
+}
+
+// secrets returns the credential fields of a config
+func (c *Config) secrets() []secret {
+	secrets := []secret{
+		{"azure_subscription_key", &c.Azure.SubscriptionKey},
+		{"claude_api_key", &c.Claude.APIKey},
+		{"calendar_client_secret", &c.Calendar.ClientSecret},
+		{"email_password", &c.Email.Password},
+		{"todo_api_token", &c.Todo.APIToken},
+		{"proxy_password", &c.Proxy.Password},
+		{"gemini_api_key", &c.LLM.Gemini.APIKey},
+		{"mistral_api_key", &c.LLM.Mistral.APIKey},
+	}
+	for i := range c.Azure.SubscriptionKeys {
+		secrets = append(secrets, secret{fmt.Sprintf("azure_subscription_key_%d", i+1), &c.Azure.SubscriptionKeys[i]})
+	}
+	for i := range c.Claude.APIKeys {
+		secrets = append(secrets, secret{fmt.Sprintf("claude_api_key_%d", i+1), &c.Claude.APIKeys[i]})
+	}
+	for i := range c.Profiles {
+		profile := &c.Profiles[i]
+		secrets = append(secrets,
+			secret{"profile_" + profile.Name + "_azure_subscription_key", &profile.AzureSubscriptionKey},
+			secret{"profile_" + profile.Name + "_claude_api_key", &profile.ClaudeAPIKey})
+	}
+	return secrets
+}
+
+// protected returns a copy of the config with credentials moved to the
+// keychain and/or encrypted, as the privacy policy asks, for saving
+func (c *Config) protected() (*Config, error) {
+	saved := *c
+	saved.Azure.SubscriptionKeys = append([]string(nil), c.Azure.SubscriptionKeys...)
+	saved.Claude.APIKeys = append([]string(nil), c.Claude.APIKeys...)
+	saved.Profiles = append([]ProfileConfig(nil), c.Profiles...)
+
+	for _, secret := range saved.secrets() {
+		if *secret.value == "" {
+			continue
+		}
+
+		if c.Privacy.CredentialStore == CredentialsKeychain {
+			err := secure.StoreCredential(secret.name, *secret.value)
+			if err != nil {
+				return nil, err
+			}
+			*secret.value = secure.Reference(secret.name)
+			continue
+		}
+
+		if c.Privacy.EncryptAtRest {
+			cipher, err := Cipher()
+			if err != nil {
+				return nil, err
+			}
+			*secret.value, err = cipher.Seal(*secret.value)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &saved, nil
+}
+
+// openSecrets resolves keychain references and decrypts credentials that
+// were saved encrypted, noting any that were saved in plain text
+func (c *Config) openSecrets() error {
+	for _, secret := range c.secrets() {
+		value := *secret.value
+		switch {
+		case secure.IsReference(value):
+			plain, err := secure.ReadCredential(secure.ReferenceName(value))
+			if err != nil {
+				return err
+			}
+			*secret.value = plain
+
+		case secure.IsSealed(value):
+			cipher, err := Cipher()
+			if err != nil {
+				return err
+			}
+			*secret.value, err = cipher.Open(value)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt credentials: %v", err)
+			}
+
+		case value != "":
+			c.plaintextSecrets = true
+		}
+	}
+	return nil
+}
+
+// HasPlaintextSecrets reports whether params.json was loaded with
+// credentials in plain text
+func (c *Config) HasPlaintextSecrets() bool {
+	return c.plaintextSecrets
+}
+
+// ProtectsSecrets reports whether credentials are kept out of plain text
+func (c *Config) ProtectsSecrets() bool {
+	return c.Privacy.EncryptAtRest || c.Privacy.CredentialStore == CredentialsKeychain
+}
+
+// Redacted returns a copy of the config that is safe to share, with every
+// credential replaced by a placeholder
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redacted.Azure.SubscriptionKeys = append([]string(nil), c.Azure.SubscriptionKeys...)
+	redacted.Claude.APIKeys = append([]string(nil), c.Claude.APIKeys...)
+	redacted.Profiles = append([]ProfileConfig(nil), c.Profiles...)
+
+	for _, secret := range redacted.secrets() {
+		if *secret.value != "" {
+			*secret.value = "REDACTED"
+		}
+	}
+	redacted.Remote.Tokens = make([]string, len(c.Remote.Tokens))
+	for i := range redacted.Remote.Tokens {
+		redacted.Remote.Tokens[i] = "REDACTED"
+	}
+	return &redacted
+}
@@ -0,0 +1,25 @@
+package config
+
+// ShellConfig controls the command execution tool. A command runs only if
+// it is listed exactly or matches a pattern, and always after the user
+// confirms it.
+type ShellConfig struct {
+	Enabled        bool              `json:"enabled"`
+	Allowed        []string          `json:"allowed"`          // Exact commands, e.g. "git status"
+	Patterns       []string          `json:"patterns"`         // Regular expressions matched against the whole command
+	Folders        map[string]string `json:"folders"`          // Spoken names to working folders, e.g. "my project": "C:/code/app"
+	TimeoutSeconds int               `json:"timeout_seconds"`  // Longest a command may run
+	MaxOutputBytes int               `json:"max_output_bytes"` // Output beyond this is cut before Claude sees it
+}
+
+// DefaultShellConfig returns default shell configuration
+func DefaultShellConfig() ShellConfig {
+	return ShellConfig{
+		Enabled:        false,
+		Allowed:        []string{"git status", "git log --oneline -10", "git diff --stat"},
+		Patterns:       []string{},
+		Folders:        map[string]string{},
+		TimeoutSeconds: 30,
+		MaxOutputBytes: 8000,
+	}
+}
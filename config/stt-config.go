@@ -0,0 +1,50 @@
+package config
+
+import "path/filepath"
+
+// STTConfig holds speech recognition backend settings
+type STTConfig struct {
+	Provider string          `json:"provider"` // "azure", "whisper" or "google"
+	Whisper  WhisperConfig   `json:"whisper"`
+	Google   GoogleSTTConfig `json:"google"`
+}
+
+// WhisperConfig holds settings for the local whisper.cpp backend
+type WhisperConfig struct {
+	ServerURL  string `json:"server_url"`           // Used when no server executable is configured
+	ServerPath string `json:"server_path"`          // whisper-server executable to launch; empty connects to ServerURL
+	ModelPath  string `json:"model_path,omitempty"` // ggml model file; empty uses ModelSize from the models folder
+	ModelSize  string `json:"model_size"`           // e.g. "tiny.en", "base.en", "small", "medium"
+	Port       int    `json:"port"`                 // Port a launched server listens on
+}
+
+// GoogleSTTConfig holds settings for Google Cloud Speech-to-Text
+type GoogleSTTConfig struct {
+	CredentialsFile string `json:"credentials_file"` // Service account key JSON
+	Model           string `json:"model,omitempty"`  // e.g. "latest_short"; empty uses Google's default
+}
+
+// IsConfigured checks if a service account key is set
+func (c *GoogleSTTConfig) IsConfigured() bool {
+	return c.CredentialsFile != ""
+}
+
+// DefaultSTTConfig returns default speech recognition configuration
+func DefaultSTTConfig() STTConfig {
+	return STTConfig{
+		Provider: "azure",
+		Whisper: WhisperConfig{
+			ServerURL: "http://127.0.0.1:8178",
+			ModelSize: "base.en",
+			Port:      8178,
+		},
+	}
+}
+
+// Model returns the path of the whisper model to load
+func (c *WhisperConfig) Model() string {
+	if c.ModelPath != "" {
+		return c.ModelPath
+	}
+	return filepath.Join(GetConfigDir(), "models", "ggml-"+c.ModelSize+".bin")
+}
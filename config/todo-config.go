@@ -0,0 +1,44 @@
+package config
+
+import "path/filepath"
+
+// Todo providers
+const (
+	TodoTodoist   = "todoist"
+	TodoMicrosoft = "microsoft"
+)
+
+// TodoConfig holds settings for "add to my todo list"
+type TodoConfig struct {
+	Provider string   `json:"provider"`            // todoist, microsoft, or empty to disable
+	APIToken string   `json:"api_token,omitempty"` // Todoist
+	Project  string   `json:"project"`             // Todoist project ID; empty uses the Inbox
+	Labels   []string `json:"labels"`              // Todoist labels added to every task
+	List     string   `json:"list"`                // Microsoft To Do list name; empty uses the default list
+	ClientID string   `json:"client_id"`           // Microsoft app registration for device-code sign-in
+	Tenant   string   `json:"tenant"`              // Microsoft only
+}
+
+// DefaultTodoConfig returns default todo configuration
+func DefaultTodoConfig() TodoConfig {
+	return TodoConfig{
+		Labels: []string{},
+		Tenant: "common",
+	}
+}
+
+// IsConfigured reports whether a todo provider is set up
+func (c TodoConfig) IsConfigured() bool {
+	switch c.Provider {
+	case TodoTodoist:
+		return c.APIToken != ""
+	case TodoMicrosoft:
+		return c.ClientID != ""
+	}
+	return false
+}
+
+// TodoQueuePath returns where tasks wait while the network is down
+func TodoQueuePath() string {
+	return filepath.Join(GetConfigDir(), "todo-queue.json")
+}
@@ -0,0 +1,22 @@
+package config
+
+// TTSConfig holds text-to-speech settings
+type TTSConfig struct {
+	Provider string            `json:"provider"`         // "local" uses the voices installed with Windows, "azure" uses neural voices
+	Voice    string            `json:"voice"`            // Empty uses the provider default; personas may override it
+	Voices   map[string]string `json:"voices,omitempty"` // Azure voice per language, e.g. "de-DE": "de-DE-ConradNeural"
+	Rate     float64           `json:"rate"`             // Relative speaking rate, 1.0 is normal
+	Pitch    string            `json:"pitch,omitempty"`  // Azure SSML pitch, e.g. "+5%" or "low"
+
+	ReplayCount int `json:"replay_count"` // Spoken answers kept for "repeat that" without synthesizing again, 0 to keep none
+}
+
+// DefaultTTSConfig returns default text-to-speech configuration
+func DefaultTTSConfig() TTSConfig {
+	return TTSConfig{
+		Provider: "local",
+		Rate:     1.0,
+
+		ReplayCount: 5,
+	}
+}
@@ -0,0 +1,20 @@
+package config
+
+// VisionConfig holds settings for asking Claude about images from the
+// clipboard or from files named in a question
+type VisionConfig struct {
+	Enabled   bool `json:"enabled"`    // Attach images the user asks about
+	MaxEdge   int  `json:"max_edge"`   // Longest image edge in pixels after downscaling
+	MaxKB     int  `json:"max_kb"`     // Largest encoded image; quality and then size are lowered to fit
+	MaxImages int  `json:"max_images"` // Most images attached to one question
+}
+
+// DefaultVisionConfig returns default image attachment configuration
+func DefaultVisionConfig() VisionConfig {
+	return VisionConfig{
+		Enabled:   true,
+		MaxEdge:   1568,
+		MaxKB:     3750, // Claude's 5 MB limit once base64 encoded
+		MaxImages: 5,
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Editors often write a file in several steps; wait for them to settle
+const watchDebounce = 500 * time.Millisecond
+
+// Watch calls onChange after params.json is modified. The folder is watched
+// rather than the file because many editors replace the file on save.
+// Calling the returned function stops watching.
+func Watch(onChange func()) (func(), error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch config: %v", err)
+	}
+
+	path, err := filepath.Abs(getConfigPath())
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	err = watcher.Add(filepath.Dir(path))
+	if err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("failed to watch config: %v", err)
+	}
+
+	go func() {
+		var debounce *time.Timer
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != path || event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				if debounce != nil {
+					debounce.Stop()
+				}
+				debounce = time.AfterFunc(watchDebounce, onChange)
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("⚠️  Config watcher: %v", err)
+			}
+		}
+	}()
+
+	log.Printf("👀 Watching %s for changes", path)
+	return func() { watcher.Close() }, nil
+}
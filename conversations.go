@@ -0,0 +1,385 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/commands"
+	"voice-assistant/internal/conversations"
+	"voice-assistant/internal/events"
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/notify"
+)
+
+// How many conversations the tray lists
+const conversationTrayItems = 10
+
+// Voice commands for named conversations, matched after commands.Normalize
+var (
+	switchConversationPhrase = regexp.MustCompile(`^(?:switch|change|go back|go|open)(?: to)? (?:the )?(?:conversation (?:called |named )?(.+)|(.+) conversation)$`)
+	newConversationPhrase    = regexp.MustCompile(`^(?:start )?(?:a )?new conversation (?:called|named) (.+)$`)
+	branchConversationPhrase = regexp.MustCompile(`^branch (?:off )?(?:this conversation |the conversation )?(?:as|into|to|called) (.+)$`)
+)
+
+var (
+	conversationStore  *conversations.Store                      // Nil unless conversations are saved
+	savedConversations = map[string]conversations.Conversation{} // By lower-case name
+	conversationItems  []*systray.MenuItem
+	conversationNames  []string  // What each tray item currently shows
+	lastExchange       time.Time // When Claude last answered in the active conversation
+	conversationMutex  sync.Mutex
+)
+
+// setupConversations loads the saved conversations, continues the active
+// one and saves it after every answer
+func setupConversations() {
+	if claudeClient == nil || kioskMode {
+		return
+	}
+
+	if appConfig.Conversation.Persist {
+		conversationStore = conversations.NewStore(conversationsDir())
+		if appConfig.Privacy.EncryptAtRest {
+			cipher, err := config.Cipher()
+			if err != nil {
+				// Never fall back to writing plain text when encryption was asked for
+				log.Printf("⚠️  Conversations won't be saved, encryption failed: %v", err)
+				conversationStore = nil
+			} else {
+				conversationStore.SetCipher(cipher)
+			}
+		}
+	}
+	if conversationStore != nil {
+		list, err := conversationStore.List()
+		if err != nil {
+			log.Printf("⚠️  %v", err)
+		}
+		for _, conversation := range list {
+			savedConversations[strings.ToLower(conversation.Name)] = conversation
+		}
+	}
+
+	active := savedConversations[strings.ToLower(appConfig.Conversation.ActiveName())]
+	if len(active.Messages) > 0 {
+		claudeClient.SetHistory(active.Messages)
+		lastExchange = active.Updated
+		log.Printf("💬 Continuing conversation %q (%d messages)", active.Name, len(active.Messages))
+	}
+
+	events.On(eventBus, func(e events.LLMResponse) {
+		saveConversation()
+	})
+}
+
+// setupIdleReset starts a fresh conversation when a turn begins after a
+// long quiet spell, so old context doesn't leak into new questions
+func setupIdleReset() {
+	if claudeClient == nil {
+		return
+	}
+
+	events.On(eventBus, func(e events.LLMResponse) {
+		conversationMutex.Lock()
+		lastExchange = time.Now()
+		conversationMutex.Unlock()
+	})
+	events.On(eventBus, func(e events.StateChanged) {
+		if (e.From == app.Idle || e.From == app.Error) && (e.To == app.Listening || e.To == app.Processing) {
+			expireIdleConversation()
+		}
+	})
+	// A conversation restored at startup may already be stale
+	expireIdleConversation()
+}
+
+// expireIdleConversation archives the active conversation and clears the
+// context if nothing has been asked for longer than the idle timeout
+func expireIdleConversation() {
+	timeout := appConfig.Conversation.IdleReset()
+	conversationMutex.Lock()
+	last := lastExchange
+	conversationMutex.Unlock()
+	if timeout == 0 || last.IsZero() || time.Since(last) < timeout || len(claudeClient.History()) == 0 {
+		return
+	}
+
+	idle := time.Since(last).Round(time.Minute)
+	message := fmt.Sprintf("🌅 Started a fresh conversation after %s without a question", idle)
+	if !kioskMode {
+		// Keep the old context under a dated name so it can be switched back to
+		archived := conversations.Conversation{
+			Name:     fmt.Sprintf("%s %s", appConfig.Conversation.ActiveName(), last.Format("2006-01-02 15:04")),
+			Persona:  appConfig.Persona,
+			Messages: claudeClient.History(),
+			Updated:  last,
+		}
+		conversationMutex.Lock()
+		savedConversations[strings.ToLower(archived.Name)] = archived
+		conversationMutex.Unlock()
+		if conversationStore != nil {
+			err := conversationStore.Save(archived)
+			if err != nil {
+				log.Printf("⚠️  %v", err)
+			}
+		}
+		message += fmt.Sprintf("\nThe previous one is kept as %q", archived.Name)
+	}
+
+	claudeClient.ResetConversation()
+	conversationMutex.Lock()
+	lastExchange = time.Time{}
+	conversationMutex.Unlock()
+	saveConversation()
+	log.Printf("%s", message)
+	notifications.Notify(notify.Info, message)
+}
+
+// conversationsDir keeps each profile's conversations apart
+func conversationsDir() string {
+	name := "conversations"
+	if appConfig.Profile != "" {
+		name += "-" + fileSafe(appConfig.Profile)
+	}
+	return filepath.Join(config.GetConfigDir(), name)
+}
+
+// saveConversation records where the active conversation has got to
+func saveConversation() {
+	if claudeClient == nil || kioskMode {
+		return
+	}
+
+	conversation := conversations.Conversation{
+		Name:     appConfig.Conversation.ActiveName(),
+		Persona:  appConfig.Persona,
+		Messages: claudeClient.History(),
+		Updated:  time.Now(),
+	}
+	conversationMutex.Lock()
+	savedConversations[strings.ToLower(conversation.Name)] = conversation
+	conversationMutex.Unlock()
+
+	if conversationStore != nil {
+		err := conversationStore.Save(conversation)
+		if err != nil {
+			log.Printf("⚠️  %v", err)
+		}
+	}
+	refreshConversationMenu()
+}
+
+// switchConversation continues the named conversation, starting it if it
+// is new. With branch the current conversation is copied under the new
+// name first. The one left keeps its history for switching back.
+func switchConversation(name string, branch bool) error {
+	if claudeClient == nil {
+		return fmt.Errorf("Claude is not configured")
+	}
+	if kioskMode {
+		return fmt.Errorf("conversations are fixed in kiosk mode")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("a conversation needs a name")
+	}
+
+	saveConversation()
+	conversationMutex.Lock()
+	target, exists := savedConversations[strings.ToLower(name)]
+	conversationMutex.Unlock()
+
+	switch {
+	case branch && exists:
+		return fmt.Errorf("there is already a conversation called %q", target.Name)
+	case branch:
+		target = conversations.Conversation{Name: name, Persona: appConfig.Persona, Messages: claudeClient.History()}
+	case !exists:
+		target = conversations.Conversation{Name: name}
+	}
+
+	claudeClient.SetHistory(target.Messages)
+	appConfig.Conversation.Active = target.Name
+	// Picked on purpose, so the idle timeout starts over
+	conversationMutex.Lock()
+	lastExchange = time.Now()
+	conversationMutex.Unlock()
+	if _, ok := appConfig.FindPersona(target.Persona); ok && !strings.EqualFold(target.Persona, appConfig.Persona) {
+		appConfig.Persona = target.Persona
+		applyPersona()
+		checkPersonaItem(target.Persona)
+	}
+	err := appConfig.Save()
+	if err != nil {
+		log.Printf("Failed to save conversation selection: %v", err)
+	}
+	saveConversation()
+
+	var message string
+	switch {
+	case branch:
+		message = fmt.Sprintf("🌿 Branched into %q", target.Name)
+	case exists:
+		message = fmt.Sprintf("💬 Back to %q (%d earlier messages)", target.Name, len(target.Messages))
+	default:
+		message = fmt.Sprintf("🆕 Started conversation %q", target.Name)
+	}
+	log.Printf("%s", message)
+	notifications.Notify(notify.Info, message)
+	return nil
+}
+
+// clearConversations forgets every named conversation, returning how many
+// were saved
+func clearConversations() (int, error) {
+	conversationMutex.Lock()
+	savedConversations = map[string]conversations.Conversation{}
+	conversationMutex.Unlock()
+
+	count := 0
+	var err error
+	if conversationStore != nil {
+		count, err = conversationStore.Clear()
+	}
+	appConfig.Conversation.Active = config.DefaultConversationName
+	refreshConversationMenu()
+	return count, err
+}
+
+// handleConversationCommand switches, starts or branches a conversation
+// when asked by voice; it reports whether the transcript was handled
+func handleConversationCommand(text string) bool {
+	if claudeClient == nil || kioskMode {
+		return false
+	}
+	normalized := commands.Normalize(text)
+
+	var err error
+	if match := branchConversationPhrase.FindStringSubmatch(normalized); match != nil {
+		err = switchConversation(match[1], true)
+	} else if match := newConversationPhrase.FindStringSubmatch(normalized); match != nil {
+		err = switchConversation(match[1], false)
+	} else if match := switchConversationPhrase.FindStringSubmatch(normalized); match != nil {
+		name := match[1] + match[2]
+		// "switch to the coder mode" is for personas; only known names switch here
+		conversationMutex.Lock()
+		_, known := savedConversations[name]
+		conversationMutex.Unlock()
+		if !known {
+			return false
+		}
+		err = switchConversation(name, false)
+	} else {
+		return false
+	}
+
+	if err != nil {
+		log.Printf("❌ %v", err)
+		notifications.Notify(notify.Error, "❌ "+err.Error())
+	}
+	return true
+}
+
+// addConversationMenu adds the "Conversations" submenu
+func addConversationMenu() *systray.MenuItem {
+	mConversations := systray.AddMenuItem("Conversations", "Switch between named conversations")
+	if claudeClient == nil || kioskMode {
+		mConversations.Disable()
+		return mConversations
+	}
+
+	mNew := mConversations.AddSubMenuItem("New conversation…", "Start a named conversation")
+	mBranch := mConversations.AddSubMenuItem("Branch this conversation…", "Copy this conversation under a new name and continue there")
+	conversationItems = make([]*systray.MenuItem, conversationTrayItems)
+	for i := range conversationItems {
+		conversationItems[i] = mConversations.AddSubMenuItemCheckbox("", "Switch to this conversation", false)
+		conversationItems[i].Hide()
+	}
+	refreshConversationMenu()
+
+	for i := range conversationItems {
+		go func(index int) {
+			for range conversationItems[index].ClickedCh {
+				conversationMutex.Lock()
+				var name string
+				if index < len(conversationNames) {
+					name = conversationNames[index]
+				}
+				conversationMutex.Unlock()
+				promptConversation(func() (string, bool) { return name, true }, false)
+			}
+		}(i)
+	}
+	go func() {
+		for {
+			select {
+			case <-mNew.ClickedCh:
+				promptConversation(func() (string, bool) {
+					return gui.Prompt("AI Assistant - New conversation", "Name of the new conversation:")
+				}, false)
+			case <-mBranch.ClickedCh:
+				promptConversation(func() (string, bool) {
+					return gui.Prompt("AI Assistant - Branch conversation", "Name for the copy of this conversation:")
+				}, true)
+			}
+		}
+	}()
+	return mConversations
+}
+
+// promptConversation switches to the conversation named by ask, reporting
+// failures
+func promptConversation(ask func() (string, bool), branch bool) {
+	name, ok := ask()
+	if !ok || strings.TrimSpace(name) == "" {
+		return
+	}
+	err := switchConversation(name, branch)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		notifications.Notify(notify.Error, "❌ "+err.Error())
+	}
+}
+
+// refreshConversationMenu lists the most recently used conversations,
+// checking the active one
+func refreshConversationMenu() {
+	conversationMutex.Lock()
+	defer conversationMutex.Unlock()
+	if len(conversationItems) == 0 {
+		return
+	}
+
+	list := make([]conversations.Conversation, 0, len(savedConversations))
+	for _, conversation := range savedConversations {
+		list = append(list, conversation)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Updated.After(list[j].Updated) })
+
+	active := appConfig.Conversation.ActiveName()
+	conversationNames = conversationNames[:0]
+	for i, item := range conversationItems {
+		if i >= len(list) {
+			item.Hide()
+			continue
+		}
+		conversationNames = append(conversationNames, list[i].Name)
+		item.SetTitle(clip(list[i].Name, 40))
+		if strings.EqualFold(list[i].Name, active) {
+			item.Check()
+		} else {
+			item.Uncheck()
+		}
+		item.Show()
+	}
+}
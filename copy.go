@@ -0,0 +1,117 @@
+package main
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/clipboard"
+	"voice-assistant/internal/hotkey"
+	"voice-assistant/internal/notify"
+)
+
+var (
+	lastTranscript string
+	lastResponse   string
+	lastMutex      sync.Mutex
+)
+
+// rememberTranscript keeps the latest recognized text for copying
+func rememberTranscript(text string) {
+	lastMutex.Lock()
+	defer lastMutex.Unlock()
+	lastTranscript = text
+}
+
+// rememberResponse keeps the latest Claude response for copying
+func rememberResponse(text string) {
+	lastMutex.Lock()
+	defer lastMutex.Unlock()
+	lastResponse = text
+}
+
+// bindCopyHotkeys adds Ctrl+Alt+C and Ctrl+Alt+R for copying the last
+// transcription and response
+func bindCopyHotkeys(listener *hotkey.Listener) {
+	listener.Bind("Ctrl+Alt+C", []int{hotkey.VK_CTRL, hotkey.VK_ALT, hotkey.VK_C}, copyLastTranscript)
+	listener.Bind("Ctrl+Alt+R", []int{hotkey.VK_CTRL, hotkey.VK_ALT, hotkey.VK_R}, copyLastResponse)
+}
+
+// addCopyMenu adds the copy items to the tray menu
+func addCopyMenu() (*systray.MenuItem, *systray.MenuItem) {
+	mCopyTranscript := systray.AddMenuItem("Copy last transcription", "Copy what you last said (Ctrl+Alt+C)")
+	mCopyResponse := systray.AddMenuItem("Copy last Claude response", "Copy Claude's last answer (Ctrl+Alt+R)")
+
+	go func() {
+		for {
+			select {
+			case <-mCopyTranscript.ClickedCh:
+				copyLastTranscript()
+			case <-mCopyResponse.ClickedCh:
+				copyLastResponse()
+			}
+		}
+	}()
+	return mCopyTranscript, mCopyResponse
+}
+
+// copyLastTranscript places the last transcription on the clipboard
+func copyLastTranscript() {
+	lastMutex.Lock()
+	text := lastTranscript
+	lastMutex.Unlock()
+	copyToClipboard("transcription", text)
+}
+
+// copyLastResponse places the last Claude response on the clipboard
+func copyLastResponse() {
+	lastMutex.Lock()
+	text := lastResponse
+	lastMutex.Unlock()
+	copyToClipboard("response", text)
+}
+
+// copyToClipboard copies text and tells the user how it went
+func copyToClipboard(what, text string) {
+	if text == "" {
+		notifications.Notify(notify.Info, "No "+what+" to copy yet")
+		return
+	}
+
+	err := clipboard.WriteText(text)
+	if err != nil {
+		log.Printf("❌ Failed to copy %s: %v", what, err)
+		notifications.Notify(notify.Error, "❌ Failed to copy "+what)
+		return
+	}
+	log.Printf("📋 Copied last %s (%d characters)", what, len(text))
+	notifications.Notify(notify.Info, "📋 Copied last "+what+" to clipboard")
+}
+
+// speakableResponse prepares a response for reading aloud. Code blocks
+// can't be spoken sensibly, so they are copied to the clipboard instead.
+func speakableResponse(response string) string {
+	spoken := claude.Speakable(response)
+
+	blocks := claude.ExtractCodeBlocks(response)
+	if len(blocks) == 0 {
+		return spoken
+	}
+
+	codes := make([]string, len(blocks))
+	for i, block := range blocks {
+		codes[i] = block.Code
+	}
+	err := clipboard.WriteText(strings.Join(codes, "\n\n"))
+	if err != nil {
+		log.Printf("❌ Failed to copy code: %v", err)
+		return spoken
+	}
+
+	log.Printf("📋 Copied %d code block(s) to clipboard", len(blocks))
+	notifications.Notify(notify.Info, "📋 Code copied to clipboard")
+	return strings.TrimSpace(spoken + " I've copied the code to your clipboard.")
+}
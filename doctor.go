@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/hotkey"
+	"voice-assistant/internal/tts"
+)
+
+// How long the doctor waits for the microphone to deliver audio
+const doctorMicTimeout = 2 * time.Second
+
+// doctorResult is the outcome of one self-test
+type doctorResult struct {
+	component string
+	status    string // PASS, FAIL or SKIP
+	detail    string
+}
+
+func doctorPass(component, detail string) doctorResult {
+	return doctorResult{component, "PASS", detail}
+}
+
+func doctorFail(component string, err error) doctorResult {
+	return doctorResult{component, "FAIL", err.Error()}
+}
+
+func doctorSkip(component, detail string) doctorResult {
+	return doctorResult{component, "SKIP", detail}
+}
+
+// runDoctor checks every component the assistant depends on and prints
+// pass/fail per component. It returns the process exit code.
+func runDoctor() int {
+	var results []doctorResult
+	results = append(results, doctorAudio()...)
+	results = append(results, doctorSpeech(), doctorClaude(), doctorTTS(), doctorHotkey())
+
+	failed := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, result := range results {
+		if result.status == "FAIL" {
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.status, result.component, result.detail)
+	}
+	w.Flush()
+
+	if failed > 0 {
+		fmt.Printf("\n%d check(s) failed\n", failed)
+		return 1
+	}
+	fmt.Println("\nAll checks passed")
+	return 0
+}
+
+// doctorAudio initializes PortAudio and records a moment from the microphone
+func doctorAudio() []doctorResult {
+	recorder := audio.NewRecorder()
+	err := recorder.Initialize()
+	if err != nil {
+		return []doctorResult{
+			doctorFail("PortAudio", err),
+			doctorSkip("Microphone", "PortAudio unavailable"),
+		}
+	}
+	defer recorder.Cleanup()
+
+	device, err := audio.CheckMicrophone(doctorMicTimeout)
+	if err != nil {
+		return []doctorResult{doctorPass("PortAudio", "initialized"), doctorFail("Microphone", err)}
+	}
+	return []doctorResult{doctorPass("PortAudio", "initialized"), doctorPass("Microphone", device)}
+}
+
+// doctorSpeech probes the configured speech-to-text service
+func doctorSpeech() doctorResult {
+	component := "Speech (" + appConfig.STT.Provider + ")"
+	service, err := newSpeechProvider()
+	if err != nil {
+		return doctorFail(component, err)
+	}
+	if service == nil {
+		return doctorFail(component, fmt.Errorf("not configured"))
+	}
+	defer service.Close()
+
+	err = service.TestConnection(context.Background())
+	if err != nil {
+		return doctorFail(component, err)
+	}
+	return doctorPass(component, "connected to "+service.Name())
+}
+
+// doctorClaude checks the Claude key is accepted
+func doctorClaude() doctorResult {
+	if !appConfig.Claude.IsConfigured() {
+		return doctorFail("Claude", fmt.Errorf("no API key"))
+	}
+
+	client := claude.NewClientFromConfig(appConfig)
+	client.SetHistoryEnabled(false)
+	err := client.TestConnection(context.Background())
+	if err != nil {
+		return doctorFail("Claude", err)
+	}
+	return doctorPass("Claude", "authenticated, model "+client.Model())
+}
+
+// doctorTTS synthesizes a short phrase without playing it
+func doctorTTS() doctorResult {
+	if !appConfig.Features.TTS {
+		return doctorSkip("Text-to-speech", "disabled in features")
+	}
+
+	provider, err := tts.NewProviderFromConfig(appConfig)
+	if err != nil {
+		return doctorFail("Text-to-speech", err)
+	}
+	_, err = provider.Synthesize("Test", tts.Options{Voice: appConfig.TTS.Voice, Rate: appConfig.TTS.Rate})
+	if err != nil {
+		return doctorFail("Text-to-speech", err)
+	}
+	return doctorPass("Text-to-speech", provider.Name())
+}
+
+// doctorHotkey checks global key state can be read for F12 and Ctrl+Q
+func doctorHotkey() doctorResult {
+	err := hotkey.Check()
+	if err != nil {
+		return doctorFail("Hotkeys", err)
+	}
+	return doctorPass("Hotkeys", "F12 and Ctrl+Q available")
+}
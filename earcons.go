@@ -0,0 +1,136 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/events"
+)
+
+// Built-in earcons: a rising chime, a falling chime and a low double buzz
+var defaultEarcons = map[string][]audio.Tone{
+	"start": {
+		{Frequency: 660, Duration: 70 * time.Millisecond},
+		{Frequency: 880, Duration: 110 * time.Millisecond},
+	},
+	"stop": {
+		{Frequency: 880, Duration: 70 * time.Millisecond},
+		{Frequency: 660, Duration: 110 * time.Millisecond},
+	},
+	"error": {
+		{Frequency: 196, Duration: 120 * time.Millisecond, Buzz: true},
+		{Duration: 60 * time.Millisecond},
+		{Frequency: 196, Duration: 160 * time.Millisecond, Buzz: true},
+	},
+}
+
+var (
+	earconPlayer *audio.Player
+	earconSounds map[string][]int16
+	earconQueue  = make(chan string, 4)
+	earconsOnce  sync.Once
+	earconsMutex sync.Mutex
+)
+
+// setupEarcons loads the configured sounds and, the first time, starts
+// playing them on state changes. Called again when settings are reloaded.
+func setupEarcons() {
+	earconsMutex.Lock()
+	defer earconsMutex.Unlock()
+
+	earconSounds = nil
+	if !appConfig.Earcons.Enabled {
+		return
+	}
+
+	if earconPlayer == nil {
+		player, err := audio.NewPlayer()
+		if err != nil {
+			log.Printf("⚠️  Earcons disabled: %v", err)
+			return
+		}
+		earconPlayer = player
+	}
+	earconPlayer.SetDevice(appConfig.Audio.OutputDevice)
+	earconPlayer.SetVolume(appConfig.Audio.Volume)
+
+	files := map[string]string{
+		"start": appConfig.Earcons.Start,
+		"stop":  appConfig.Earcons.Stop,
+		"error": appConfig.Earcons.Error,
+	}
+	earconSounds = make(map[string][]int16)
+	for name, tones := range defaultEarcons {
+		earconSounds[name] = loadEarcon(files[name], tones, appConfig.Earcons.Volume)
+	}
+
+	earconsOnce.Do(func() {
+		go playEarcons()
+		events.On(eventBus, func(e events.StateChanged) {
+			switch {
+			case e.To == app.Error:
+				queueEarcon("error")
+			case e.To == app.Listening && e.From != app.Listening:
+				queueEarcon("start")
+			case e.From == app.Listening:
+				queueEarcon("stop")
+			}
+		})
+	})
+}
+
+// loadEarcon reads a replacement WAV, falling back to the built-in tones
+func loadEarcon(path string, tones []audio.Tone, volume float64) []int16 {
+	if path != "" {
+		samples, rate, channels, err := audio.ReadWAVFile(path)
+		if err == nil {
+			samples = audio.Resample(audio.ToMono(samples, channels), rate, audio.SampleRate)
+			for i, sample := range samples {
+				samples[i] = int16(float64(sample) * volume)
+			}
+			return samples
+		}
+		log.Printf("⚠️  Using the built-in earcon instead of %s: %v", path, err)
+	}
+	return audio.RenderTones(tones, volume)
+}
+
+// queueEarcon plays a sound without holding up the state change; sounds
+// are dropped rather than piling up if several arrive at once
+func queueEarcon(name string) {
+	// Keep chimes out of transcribed meetings
+	if isMeetingActive() {
+		return
+	}
+	select {
+	case earconQueue <- name:
+	default:
+	}
+}
+
+// playEarcons plays queued sounds one at a time, with the microphone gated
+// so recognition doesn't hear them
+func playEarcons() {
+	for name := range earconQueue {
+		earconsMutex.Lock()
+		samples := earconSounds[name]
+		earconsMutex.Unlock()
+		if len(samples) == 0 {
+			continue
+		}
+
+		if echoGate != nil {
+			echoGate.PlaybackStarted()
+		}
+		err := earconPlayer.Play(samples, audio.SampleRate)
+		if echoGate != nil {
+			echoGate.PlaybackEnded()
+		}
+		if err != nil {
+			log.Printf("⚠️  Failed to play earcon: %v", err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/email"
+	"voice-assistant/internal/notify"
+)
+
+// How long a draft waits for "send it" before it is discarded
+const emailDraftLifetime = 10 * time.Minute
+
+// emailSender sends confirmed drafts; nil unless email is configured
+var emailSender *email.Sender
+
+// The draft waiting for voice confirmation
+var (
+	emailDraft      *email.Message
+	emailDraftTime  time.Time
+	emailDraftMutex sync.Mutex
+)
+
+// setupEmail offers Claude a tool that drafts email. Drafts are only sent
+// when the user says "send it", which is handled locally, so Claude can
+// never send mail on its own.
+func setupEmail() {
+	cfg := appConfig.Email
+	if !cfg.IsConfigured() {
+		return
+	}
+	emailSender = email.NewSender(cfg.SMTPHost, cfg.SMTPPort, cfg.Username, cfg.Password, cfg.Sender())
+
+	var contacts []string
+	for name := range cfg.Contacts {
+		contacts = append(contacts, name)
+	}
+	registerTool(claude.Tool{
+		Name: "draft_email",
+		Description: "Prepare an email from the user's dictation. It is NOT sent: read the draft back to the user " +
+			"and tell them to say \"send it\" to send or \"discard the email\" to cancel. " +
+			"Recipients are email addresses or these contact names: " + strings.Join(contacts, ", ") + ".",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"to":      map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+				"subject": map[string]interface{}{"type": "string"},
+				"body":    map[string]interface{}{"type": "string", "description": "Plain-text body, including greeting and sign-off"},
+			},
+			"required": []string{"to", "subject", "body"},
+		},
+	}, draftEmailTool)
+}
+
+// draftEmailTool holds a drafted email until the user confirms it
+func draftEmailTool(input json.RawMessage) (string, error) {
+	var args struct {
+		To      []string `json:"to"`
+		Subject string   `json:"subject"`
+		Body    string   `json:"body"`
+	}
+	err := json.Unmarshal(input, &args)
+	if err != nil {
+		return "", fmt.Errorf("invalid input: %v", err)
+	}
+
+	message := &email.Message{Subject: args.Subject, Body: args.Body}
+	for _, to := range args.To {
+		address, ok := appConfig.Email.Contacts[strings.ToLower(strings.TrimSpace(to))]
+		if !ok {
+			address = strings.TrimSpace(to)
+		}
+		if !strings.Contains(address, "@") {
+			return "", fmt.Errorf("no address for %q; ask the user for it", to)
+		}
+		message.To = append(message.To, address)
+	}
+
+	emailDraftMutex.Lock()
+	emailDraft = message
+	emailDraftTime = time.Now()
+	emailDraftMutex.Unlock()
+
+	log.Printf("✉️  Drafted email to %s", strings.Join(message.To, ", "))
+	return fmt.Sprintf("Draft ready, not sent. To: %s. Subject: %s. Read it back and ask the user to say \"send it\".",
+		strings.Join(message.To, ", "), message.Subject), nil
+}
+
+// hasEmailDraft reports whether a draft is waiting for confirmation
+func hasEmailDraft() bool {
+	emailDraftMutex.Lock()
+	defer emailDraftMutex.Unlock()
+	return emailDraft != nil && time.Since(emailDraftTime) < emailDraftLifetime
+}
+
+// takeEmailDraft removes and returns the waiting draft
+func takeEmailDraft() *email.Message {
+	emailDraftMutex.Lock()
+	defer emailDraftMutex.Unlock()
+
+	draft := emailDraft
+	emailDraft = nil
+	if draft == nil || time.Since(emailDraftTime) >= emailDraftLifetime {
+		return nil
+	}
+	return draft
+}
+
+// sendEmailDraft sends the draft the user just confirmed by voice
+func sendEmailDraft() {
+	draft := takeEmailDraft()
+	if draft == nil {
+		return
+	}
+
+	err := emailSender.Send(*draft)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		notifications.Notify(notify.Error, "❌ Failed to send the email")
+		speakResponse("Sorry, the email couldn't be sent.")
+		return
+	}
+	log.Printf("✉️  Sent email to %s", strings.Join(draft.To, ", "))
+	notifications.Notify(notify.Info, "✉️ Email sent to "+strings.Join(draft.To, ", "))
+	speakResponse("Email sent.")
+}
+
+// discardEmailDraft drops the waiting draft
+func discardEmailDraft() {
+	if takeEmailDraft() != nil {
+		log.Printf("✉️  Email draft discarded")
+		notifications.Notify(notify.Info, "✉️ Email discarded")
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/events"
+	"voice-assistant/internal/hooks"
+)
+
+// eventBus carries what happens in the pipeline to the features that react
+var eventBus = events.NewBus()
+
+// setupEvents publishes state changes on the bus and subscribes the
+// features that follow each turn
+func setupEvents() {
+	stateMachine.Subscribe(func(t app.Transition) {
+		eventBus.Publish(events.StateChanged{From: t.From, To: t.To, Reason: t.Reason, Aborted: t.Aborted})
+	})
+
+	events.On(eventBus, func(e events.TranscriptFinal) {
+		archivePhrase(e.Text)
+		fireHook(hooks.EventTranscript, e.Text)
+	})
+	events.On(eventBus, func(e events.LLMResponse) {
+		rememberResponse(e.Text)
+		fireHook(hooks.EventResponse, e.Text)
+	})
+	events.On(eventBus, func(e events.Error) {
+		fireHook(hooks.EventError, e.Err.Error())
+	})
+}
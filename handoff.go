@@ -0,0 +1,60 @@
+package main
+
+import (
+	"log"
+	"net/url"
+
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/clipboard"
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/notify"
+)
+
+// Browsers and chat sites reject very long URLs; longer prompts are only
+// placed on the clipboard and the site is opened empty
+const maxHandoffURLLength = 6000
+
+// Chat sites that accept a pre-filled prompt in the URL
+var handoffURLs = map[string]string{
+	"claude":  "https://claude.ai/new",
+	"chatgpt": "https://chatgpt.com/",
+}
+
+// handoffConversation exports the current conversation so it can be
+// continued in a browser chat
+func handoffConversation() {
+	if claudeClient == nil || len(claudeClient.History()) == 0 {
+		notifications.Notify(notify.Info, "Nothing to hand off yet")
+		return
+	}
+
+	prompt := claude.HandoffPrompt(claudeClient.History())
+
+	err := clipboard.WriteText(prompt)
+	if err != nil {
+		log.Printf("❌ Failed to copy conversation: %v", err)
+		notifications.Notify(notify.Error, "❌ Failed to copy conversation")
+		return
+	}
+	log.Printf("📋 Conversation copied to clipboard (%d characters)", len(prompt))
+
+	baseURL, ok := handoffURLs[appConfig.Handoff.Target]
+	if !ok {
+		notifications.Notify(notify.Info, "📋 Conversation copied to clipboard")
+		return
+	}
+
+	target := baseURL + "?q=" + url.QueryEscape(prompt)
+	message := "🌐 Continuing in your browser (also copied to clipboard)"
+	if len(target) > maxHandoffURLLength {
+		target = baseURL
+		message = "🌐 Conversation is long - paste it from the clipboard"
+	}
+
+	err = gui.Open(target)
+	if err != nil {
+		log.Printf("❌ Failed to open browser: %v", err)
+		message = "📋 Conversation copied to clipboard"
+	}
+	notifications.Notify(notify.Info, message)
+}
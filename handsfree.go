@@ -0,0 +1,126 @@
+package main
+
+import (
+	"log"
+
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/commands"
+	"voice-assistant/internal/metrics"
+	"voice-assistant/internal/notify"
+)
+
+// isStopWord reports whether a transcript ends the hands-free session
+func isStopWord(text string) bool {
+	normalized := commands.Normalize(text)
+	for _, stopWord := range appConfig.Conversation.StopWords {
+		if normalized == commands.Normalize(stopWord) {
+			return true
+		}
+	}
+	return false
+}
+
+// handsFreeOn reports whether the session keeps re-opening the microphone
+func (a *App) handsFreeOn() bool {
+	a.handsFreeMutex.Lock()
+	defer a.handsFreeMutex.Unlock()
+	return a.handsFree
+}
+
+// setHandsFree starts or quietly drops a hands-free session
+func (a *App) setHandsFree(active bool) {
+	a.handsFreeMutex.Lock()
+	defer a.handsFreeMutex.Unlock()
+	a.handsFree = active
+}
+
+// EndHandsFree stops re-opening the microphone after responses
+func (a *App) EndHandsFree(reason string) {
+	a.handsFreeMutex.Lock()
+	active := a.handsFree
+	a.handsFree = false
+	a.handsFreeMutex.Unlock()
+	if !active {
+		return
+	}
+	log.Printf("👋 Hands-free session ended (%s)", reason)
+	a.notifier.Notify(notify.Status, "👋 Hands-free conversation ended")
+}
+
+// ContinueHandsFree re-opens the microphone for the next turn once the
+// response has been delivered, or returns to idle outside hands-free mode
+func (a *App) ContinueHandsFree() {
+	if !a.handsFreeOn() {
+		a.setState(app.Idle, "turn complete")
+		return
+	}
+
+	log.Printf("🔁 Hands-free: listening for the next turn")
+	metrics.BeginInteraction()
+	err := a.speech.StartContinuousRecognition(appContext)
+	if err != nil {
+		log.Printf("❌ Failed to restart recognition: %v", err)
+		a.setHandsFree(false)
+		a.setState(app.Error, "hands-free restart failed")
+		a.notifier.Notify(notify.Error, "❌ Failed to restart listening")
+		return
+	}
+	metrics.Mark(metrics.MarkCaptureStart)
+	a.setState(app.Listening, "hands-free next turn")
+}
+
+// endHandsFree ends the running pipeline's hands-free session, for local
+// commands and the tray
+func endHandsFree(reason string) {
+	if assistant != nil {
+		assistant.EndHandsFree(reason)
+	}
+}
+
+// continueHandsFree finishes a local command's turn in the running pipeline
+func continueHandsFree() {
+	if assistant == nil {
+		setState(app.Idle, "turn complete")
+		return
+	}
+	assistant.ContinueHandsFree()
+}
+
+// onTurnEnd handles Azure closing a turn, e.g. after a silence timeout.
+// Turns that produced a phrase are already being processed.
+func onTurnEnd() {
+	if !stateMachine.Is(app.Listening) {
+		return
+	}
+	if isMeetingActive() {
+		continueMeeting(speechService)
+		return
+	}
+
+	err := speechService.StopContinuousRecognition()
+	if err != nil {
+		log.Printf("❌ Failed to stop recognition: %v", err)
+	}
+
+	if assistant != nil && assistant.handsFreeOn() {
+		// Nothing was said; keep the conversation open with a fresh turn
+		setState(app.Processing, "silent turn")
+		continueHandsFree()
+		return
+	}
+	setState(app.Idle, "turn ended")
+}
+
+// toggleHandsFree flips hands-free mode from the tray
+func toggleHandsFree(enabled bool) {
+	appConfig.Conversation.HandsFree = enabled
+	if !enabled {
+		endHandsFree("disabled from tray")
+	}
+
+	err := appConfig.Save()
+	if err != nil {
+		log.Printf("Failed to save hands-free setting: %v", err)
+	}
+	log.Printf("Hands-free mode enabled: %v", enabled)
+}
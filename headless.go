@@ -0,0 +1,36 @@
+package main
+
+import (
+	"log"
+	"sync"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/gui"
+)
+
+var (
+	noTray        bool // -no-tray: run as a console process without the tray icon
+	quitRequested = make(chan struct{})
+	quitHeadless  sync.Once
+)
+
+// runHeadless runs the assistant without a tray icon until quit is called.
+// It is controlled with the hotkeys; status changes go to the log.
+func runHeadless() {
+	gui.DisableTray()
+	log.Printf("🖥️  Running without a tray icon")
+	log.Printf("   F12: Start/Stop recording, Ctrl+Alt+X: Cancel, Ctrl+Alt+P: Replay answer, Ctrl+Alt+C/R: Copy transcript/response, Ctrl+Q or Ctrl+C: Exit")
+
+	<-quitRequested
+	onExit()
+}
+
+// quit ends the application, with or without the tray
+func quit() {
+	if noTray {
+		quitHeadless.Do(func() { close(quitRequested) })
+		return
+	}
+	systray.Quit()
+}
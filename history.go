@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/history"
+	"voice-assistant/internal/notify"
+)
+
+// How many matches a history search lists
+const historySearchLimit = 50
+
+// staleSearchPath is where earlier versions left search results in plain
+// text; it is removed on startup
+var staleSearchPath = filepath.Join(os.TempDir(), "voice-assistant-history.txt")
+
+var (
+	historyStore *history.Store // Nil when history is disabled or failed to open
+
+	historyItems []*systray.MenuItem
+	historyTurns []history.Turn // What each history menu item currently shows
+	historyMutex sync.Mutex
+)
+
+// setupHistory opens the history database
+func setupHistory() {
+	os.Remove(staleSearchPath)
+	if !appConfig.History.Enabled {
+		return
+	}
+
+	store, err := history.Open(historyPath())
+	if err != nil {
+		log.Printf("⚠️  History unavailable: %v", err)
+		return
+	}
+	if appConfig.Privacy.EncryptAtRest {
+		cipher, err := config.Cipher()
+		if err == nil {
+			err = store.SetCipher(cipher)
+		}
+		if err != nil {
+			// Never fall back to writing plain text when encryption was asked for
+			log.Printf("⚠️  History unavailable, encryption failed: %v", err)
+			store.Close()
+			return
+		}
+	}
+
+	historyStore = store
+	log.Printf("📚 Conversation history enabled")
+}
+
+// historyPath keeps each profile's history in its own database
+func historyPath() string {
+	name := "history.db"
+	if appConfig.Profile != "" {
+		name = "history-" + fileSafe(appConfig.Profile) + ".db"
+	}
+	return filepath.Join(config.GetConfigDir(), name)
+}
+
+// fileSafe replaces characters that can't appear in file names
+func fileSafe(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// recordTurn stores a finished turn and refreshes the tray
+func recordTurn(turn history.Turn) error {
+	if historyStore == nil {
+		return nil
+	}
+
+	err := historyStore.Add(turn)
+	if err != nil {
+		return err
+	}
+	refreshHistoryMenu()
+	return nil
+}
+
+// addHistoryMenu adds the "History" submenu listing recent turns
+func addHistoryMenu() *systray.MenuItem {
+	mHistory := systray.AddMenuItem("History", "Recent questions and answers")
+	if historyStore == nil {
+		mHistory.Hide()
+		return mHistory
+	}
+
+	mSearch := mHistory.AddSubMenuItem("Search history…", "Find past questions and answers")
+	historyItems = make([]*systray.MenuItem, appConfig.History.TrayItems)
+	for i := range historyItems {
+		historyItems[i] = mHistory.AddSubMenuItem("", "Copy this response")
+		historyItems[i].Hide()
+	}
+	refreshHistoryMenu()
+
+	for i := range historyItems {
+		go func(index int) {
+			for range historyItems[index].ClickedCh {
+				historyMutex.Lock()
+				var response string
+				if index < len(historyTurns) {
+					response = historyTurns[index].Response
+				}
+				historyMutex.Unlock()
+				copyToClipboard("response", response)
+			}
+		}(i)
+	}
+	go func() {
+		for range mSearch.ClickedCh {
+			searchHistory()
+		}
+	}()
+	return mHistory
+}
+
+// refreshHistoryMenu shows the latest turns in the history menu
+func refreshHistoryMenu() {
+	if historyStore == nil || len(historyItems) == 0 {
+		return
+	}
+
+	turns, err := historyStore.Recent(len(historyItems))
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return
+	}
+
+	historyMutex.Lock()
+	defer historyMutex.Unlock()
+	historyTurns = turns
+	for i, item := range historyItems {
+		if i >= len(turns) {
+			item.Hide()
+			continue
+		}
+		item.SetTitle(turns[i].Time.Format("Jan 2 15:04") + "  " + clip(turns[i].Transcript, 40))
+		item.Show()
+	}
+}
+
+// searchHistory asks for a search term and shows the matching turns in a
+// window, never on disk, since the history may be encrypted
+func searchHistory() {
+	query, ok := gui.Prompt("AI Assistant - Search history", "Find questions and answers containing:")
+	query = strings.TrimSpace(query)
+	if !ok || query == "" {
+		return
+	}
+
+	turns, err := historyStore.Search(query, historySearchLimit)
+	if err != nil {
+		log.Printf("❌ History search failed: %v", err)
+		return
+	}
+	if len(turns) == 0 {
+		notifications.Notify(notify.Info, fmt.Sprintf("🔍 Nothing in history matches %q", query))
+		return
+	}
+
+	var results strings.Builder
+	fmt.Fprintf(&results, "History matching %q (%d)\n\n", query, len(turns))
+	for _, turn := range turns {
+		fmt.Fprintf(&results, "── %s ──\nYou: %s\n\n", turn.Time.Format("Monday, 2 January 2006 15:04"), turn.Transcript)
+		if turn.Thinking != "" {
+			fmt.Fprintf(&results, "Claude's thinking:\n%s\n\n", turn.Thinking)
+		}
+		fmt.Fprintf(&results, "Claude: %s\n\n", turn.Response)
+	}
+
+	err = gui.ShowText(fmt.Sprintf("AI Assistant - History matching %q", query), results.String())
+	if err != nil {
+		log.Printf("❌ Failed to show history results: %v", err)
+	}
+}
+
+// clip shortens text to at most n characters for menu titles
+func clip(text string, n int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= n {
+		return text
+	}
+	return string([]rune(text)[:n-1]) + "…"
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"time"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/hooks"
+)
+
+// hookRunner fires the configured hooks; nil when none are configured
+var hookRunner *hooks.Runner
+
+// setupHooks builds the hook runner from config
+func setupHooks() {
+	cfg := appConfig.Hooks
+	if len(cfg.OnTranscript)+len(cfg.OnResponse)+len(cfg.OnError) == 0 {
+		hookRunner = nil
+		return
+	}
+
+	hookRunner = hooks.NewRunner(map[string][]hooks.Hook{
+		hooks.EventTranscript: toHooks(cfg.OnTranscript),
+		hooks.EventResponse:   toHooks(cfg.OnResponse),
+		hooks.EventError:      toHooks(cfg.OnError),
+	}, time.Duration(cfg.TimeoutSeconds)*time.Second)
+}
+
+// toHooks converts hook settings
+func toHooks(configs []config.HookConfig) []hooks.Hook {
+	result := make([]hooks.Hook, len(configs))
+	for i, c := range configs {
+		result[i] = hooks.Hook{URL: c.URL, Command: c.Command, Args: c.Args}
+	}
+	return result
+}
+
+// fireHook runs the hooks for an event, if any
+func fireHook(event, text string) {
+	if hookRunner != nil {
+		hookRunner.Fire(event, text)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/intent"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/system"
+)
+
+// Speaking rate used for "read that slower"
+const slowSpeechRate = 0.75
+
+var intentMatcher *intent.Matcher
+
+// setupIntents builds the matcher for local assistant control phrases
+func setupIntents() {
+	intentMatcher = intent.NewMatcher(pluginIntentPhrases(appConfig.Intents.Phrases))
+}
+
+// handleIntent runs a local control command without calling Claude; it
+// reports whether the transcript was handled
+func handleIntent(text string) bool {
+	match, ok := intentMatcher.Match(text)
+	if !ok {
+		return false
+	}
+
+	// Only allow-listed targets are opened locally; anything else goes to
+	// Claude, which can still open it through the tool after confirmation
+	if match.Intent == intent.Open && !actionRunner.IsAllowed(match.Target) {
+		return false
+	}
+	// Without a todo provider "remind me to..." is a question for Claude
+	if match.Intent == intent.AddTodo && todoProvider == nil {
+		return false
+	}
+
+	// Email confirmations only mean something while a draft is waiting
+	if (match.Intent == intent.SendEmail || match.Intent == intent.DiscardEmail) && !hasEmailDraft() {
+		return false
+	}
+	log.Printf("🧭 Intent: %s", match.Intent)
+
+	switch match.Intent {
+	case intent.NewConversation:
+		if claudeClient != nil {
+			claudeClient.ResetConversation()
+			saveConversation()
+		}
+		if llmClient != nil {
+			llmClient.ResetConversation()
+		}
+		notifications.Notify(notify.Info, "🆕 Started a new conversation")
+
+	case intent.Repeat:
+		replayAnswer(0, 1.0)
+
+	case intent.RepeatPrevious:
+		replayAnswer(1, 1.0)
+
+	case intent.Slower:
+		replayAnswer(0, slowSpeechRate)
+
+	case intent.StopListening:
+		endHandsFree("voice command")
+		setState(app.Idle, "stop listening")
+		return true
+
+	case intent.Copy:
+		copyLastResponse()
+
+	case intent.DeleteToday, intent.DeleteAll:
+		// Visitors must not wipe the kiosk's data; the tray item is hidden too
+		if kioskMode {
+			log.Printf("🔒 Ignoring %q in kiosk mode", match.Intent)
+			return true
+		}
+		if match.Intent == intent.DeleteToday {
+			deleteTodaysRecordings()
+		} else {
+			deleteAllData()
+		}
+
+	case intent.Open:
+		openAction(match.Target)
+
+	case intent.Note:
+		takeNote(match.Spoken)
+
+	case intent.PlayPause, intent.NextTrack, intent.PreviousTrack,
+		intent.VolumeUp, intent.VolumeDown, intent.Mute, intent.LockScreen:
+		controlSystem(match.Intent)
+
+	case intent.SendEmail:
+		sendEmailDraft()
+
+	case intent.DiscardEmail:
+		discardEmailDraft()
+
+	case intent.AddTodo:
+		addTodo(match.Spoken)
+
+	case intent.Screen, intent.ClipboardImage:
+		return false // Still a question for Claude; the image is attached when sending
+
+	default:
+		if !handlePluginIntent(string(match.Intent), match.Spoken, text) {
+			log.Printf("⚠️  No handler for intent %q", match.Intent)
+			return false
+		}
+	}
+
+	continueHandsFree()
+	return true
+}
+
+// systemControls maps media and system intents to the OS calls that do them
+var systemControls = map[intent.Intent]func() error{
+	intent.PlayPause:     system.PlayPause,
+	intent.NextTrack:     system.NextTrack,
+	intent.PreviousTrack: system.PreviousTrack,
+	intent.VolumeUp:      system.VolumeUp,
+	intent.VolumeDown:    system.VolumeDown,
+	intent.Mute:          system.ToggleMute,
+	intent.LockScreen:    system.LockScreen,
+}
+
+// controlSystem runs a media or system intent
+func controlSystem(i intent.Intent) {
+	err := systemControls[i]()
+	if err != nil {
+		log.Printf("❌ %s failed: %v", i, err)
+		notifications.Notify(notify.Error, "❌ Couldn't "+strings.ReplaceAll(string(i), "_", " "))
+	}
+}
@@ -0,0 +1,88 @@
+// Package actions opens applications, URLs and files on request. Targets on
+// the allow-list run immediately; anything else needs explicit confirmation.
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/commands"
+	"voice-assistant/internal/gui"
+)
+
+// ErrDeclined is returned when the user refuses to open an unlisted target
+var ErrDeclined = errors.New("opening was declined")
+
+// Runner starts allow-listed actions and guards everything else
+type Runner struct {
+	allowed         map[string]config.ActionConfig
+	confirmUnlisted bool
+	confirm         func(title, message string) bool
+}
+
+// NewRunner creates a runner for the configured allow-list
+func NewRunner(cfg config.ActionsConfig) *Runner {
+	r := &Runner{
+		allowed:         make(map[string]config.ActionConfig),
+		confirmUnlisted: cfg.ConfirmUnlisted,
+		confirm:         gui.Confirm,
+	}
+	for _, action := range cfg.Allowed {
+		r.allowed[commands.Normalize(action.Name)] = action
+	}
+	return r
+}
+
+// Names returns the spoken names of the allow-listed actions
+func (r *Runner) Names() []string {
+	names := make([]string, 0, len(r.allowed))
+	for _, action := range r.allowed {
+		names = append(names, action.Name)
+	}
+	return names
+}
+
+// IsAllowed reports whether name is an allow-listed action
+func (r *Runner) IsAllowed(name string) bool {
+	_, ok := r.allowed[commands.Normalize(name)]
+	return ok
+}
+
+// Open starts an allow-listed action by name, or opens any other program,
+// URL or file after the user confirms it
+func (r *Runner) Open(target string) error {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return fmt.Errorf("nothing to open")
+	}
+
+	if action, ok := r.allowed[commands.Normalize(target)]; ok {
+		return r.run(action)
+	}
+
+	if !r.confirmUnlisted {
+		return fmt.Errorf("%q is not on the allow-list", target)
+	}
+	if !r.confirm("AI Assistant", fmt.Sprintf("Open %q?\n\nIt is not on your allow-list of actions.", target)) {
+		log.Printf("🚫 Declined to open %s", target)
+		return ErrDeclined
+	}
+
+	log.Printf("📂 Opening %s", target)
+	return gui.Open(target)
+}
+
+// run starts an allow-listed action without waiting for it
+func (r *Runner) run(action config.ActionConfig) error {
+	log.Printf("▶️  Starting %s: %s %s", action.Name, action.Program, strings.Join(action.Args, " "))
+
+	// Without arguments let the shell pick the handler, which also covers URLs and documents
+	if len(action.Args) == 0 {
+		return gui.Open(action.Program)
+	}
+	return exec.Command(action.Program, action.Args...).Start()
+}
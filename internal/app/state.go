@@ -0,0 +1,131 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"sync"
+)
+
+// State is the assistant's top-level state
+type State string
+
+const (
+	Idle       State = "Idle"
+	Listening  State = "Listening"
+	Processing State = "Processing"
+	Speaking   State = "Speaking"
+	Error      State = "Error"
+)
+
+// allowedTransitions lists the states reachable from each state.
+// Error is reachable from everywhere and is not listed.
+var allowedTransitions = map[State][]State{
+	Idle:       {Listening, Processing},
+	Listening:  {Idle, Processing},
+	Processing: {Idle, Listening, Speaking},
+	Speaking:   {Idle, Listening},
+	Error:      {Idle, Listening},
+}
+
+// Transition describes a state change delivered to subscribers
+type Transition struct {
+	From    State
+	To      State
+	Reason  string
+	Aborted bool // The user cancelled the turn rather than it finishing
+}
+
+// Machine holds the single source of truth for the assistant's state and
+// notifies subscribers of every change
+type Machine struct {
+	state       State
+	subscribers []func(Transition)
+	mutex       sync.Mutex
+}
+
+// NewMachine creates a state machine in the Idle state
+func NewMachine() *Machine {
+	return &Machine{
+		state: Idle,
+	}
+}
+
+// State returns the current state
+func (m *Machine) State() State {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.state
+}
+
+// Is reports whether the machine is in any of the given states
+func (m *Machine) Is(states ...State) bool {
+	current := m.State()
+	for _, state := range states {
+		if current == state {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribe registers a callback invoked after every transition
+func (m *Machine) Subscribe(callback func(Transition)) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.subscribers = append(m.subscribers, callback)
+}
+
+// Transition moves to a new state, rejecting transitions that aren't allowed
+func (m *Machine) Transition(to State, reason string) error {
+	return m.transition(to, reason, false)
+}
+
+// Abort returns to Idle from a turn in progress, marking the transition
+// as cancelled. There is nothing to abort when idle or after an error.
+func (m *Machine) Abort(reason string) error {
+	return m.transition(Idle, reason, true)
+}
+
+// transition changes state and notifies subscribers
+func (m *Machine) transition(to State, reason string, aborted bool) error {
+	m.mutex.Lock()
+	from := m.state
+
+	if aborted && from != Listening && from != Processing && from != Speaking {
+		m.mutex.Unlock()
+		return fmt.Errorf("nothing to abort in state %s (%s)", from, reason)
+	}
+	if from == to {
+		m.mutex.Unlock()
+		return nil
+	}
+	if !canTransition(from, to) {
+		m.mutex.Unlock()
+		return fmt.Errorf("invalid state transition %s → %s (%s)", from, to, reason)
+	}
+
+	m.state = to
+	subscribers := make([]func(Transition), len(m.subscribers))
+	copy(subscribers, m.subscribers)
+	m.mutex.Unlock()
+
+	transition := Transition{From: from, To: to, Reason: reason, Aborted: aborted}
+	log.Printf("🔀 State: %s → %s (%s)", from, to, reason)
+	for _, subscriber := range subscribers {
+		subscriber(transition)
+	}
+	return nil
+}
+
+// canTransition reports whether from → to is allowed
+func canTransition(from, to State) bool {
+	if to == Error {
+		return true
+	}
+	for _, allowed := range allowedTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hajimehoshi/go-mp3"
+)
+
+// ReadAudioFile decodes a WAV or MP3 file, returning interleaved samples
+// with the sample rate and channel count they were recorded with
+func ReadAudioFile(path string) ([]int16, int, int, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".wav":
+		return ReadWAVFile(path)
+	case ".mp3":
+		return readMP3File(path)
+	}
+	return nil, 0, 0, fmt.Errorf("unsupported audio format %q (use WAV or MP3)", filepath.Ext(path))
+}
+
+// ReadSpeechFile decodes an audio file and converts it to the 16kHz mono
+// format speech recognition expects
+func ReadSpeechFile(path string) ([]int16, error) {
+	samples, sampleRate, channels, err := ReadAudioFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Resample(ToMono(samples, channels), sampleRate, SampleRate), nil
+}
+
+// readMP3File decodes an MP3 file; the decoder always produces 16-bit stereo
+func readMP3File(path string) ([]int16, int, int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer file.Close()
+
+	decoder, err := mp3.NewDecoder(file)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to decode %s: %v", path, err)
+	}
+
+	data, err := io.ReadAll(decoder)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to decode %s: %v", path, err)
+	}
+
+	samples := make([]int16, len(data)/2)
+	for i := range samples {
+		samples[i] = int16(binary.LittleEndian.Uint16(data[i*2:]))
+	}
+	return samples, decoder.SampleRate(), 2, nil
+}
@@ -0,0 +1,63 @@
+package audio
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gordonklaus/portaudio"
+)
+
+// DescribeDevices lists every audio device with its channels, marking the
+// defaults; PortAudio must be initialized
+func DescribeDevices() ([]string, error) {
+	devices, err := portaudio.Devices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audio devices: %v", err)
+	}
+
+	defaultInput, _ := portaudio.DefaultInputDevice()
+	defaultOutput, _ := portaudio.DefaultOutputDevice()
+
+	var lines []string
+	for _, device := range devices {
+		line := fmt.Sprintf("%s [%s] in: %d, out: %d, %.0f Hz", device.Name, device.HostApi.Name,
+			device.MaxInputChannels, device.MaxOutputChannels, device.DefaultSampleRate)
+		if defaultInput != nil && device.Name == defaultInput.Name && device.MaxInputChannels > 0 {
+			line += " (default input)"
+		}
+		if defaultOutput != nil && device.Name == defaultOutput.Name && device.MaxOutputChannels > 0 {
+			line += " (default output)"
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// CheckMicrophone opens the default input device and waits for the first
+// frame of audio; PortAudio must be initialized
+func CheckMicrophone(timeout time.Duration) (string, error) {
+	device, err := portaudio.DefaultInputDevice()
+	if err != nil {
+		return "", fmt.Errorf("no default input device: %v", err)
+	}
+
+	frames := make(chan struct{}, 1)
+	source := NewMicrophoneSource()
+	err = source.Start(func(frame []int16) {
+		select {
+		case frames <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		return device.Name, err
+	}
+	defer source.Stop()
+
+	select {
+	case <-frames:
+		return device.Name, nil
+	case <-time.After(timeout):
+		return device.Name, fmt.Errorf("no audio from %s within %v", device.Name, timeout)
+	}
+}
@@ -0,0 +1,56 @@
+package audio
+
+import (
+	"sync"
+	"time"
+)
+
+// EchoGate mutes microphone input while the assistant is speaking so its own
+// voice isn't picked up and recognized. Input stays muted for a short tail
+// after playback to let room echo die down.
+type EchoGate struct {
+	tail      time.Duration
+	playing   int // Number of overlapping playbacks
+	mutedTill time.Time
+	mutex     sync.Mutex
+}
+
+// NewEchoGate creates a gate that stays closed for tail after playback ends
+func NewEchoGate(tail time.Duration) *EchoGate {
+	return &EchoGate{tail: tail}
+}
+
+// PlaybackStarted closes the gate until the matching PlaybackEnded
+func (g *EchoGate) PlaybackStarted() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.playing++
+}
+
+// PlaybackEnded reopens the gate once the echo tail has passed
+func (g *EchoGate) PlaybackEnded() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.playing > 0 {
+		g.playing--
+	}
+	if g.playing == 0 {
+		g.mutedTill = time.Now().Add(g.tail)
+	}
+}
+
+// Open reports whether microphone input should currently be passed through
+func (g *EchoGate) Open() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.playing == 0 && time.Now().After(g.mutedTill)
+}
+
+// Filter returns in unchanged when the gate is open, and silence of the
+// same length otherwise so the recognizer's timeline stays continuous
+func (g *EchoGate) Filter(in []int16) []int16 {
+	if g.Open() {
+		return in
+	}
+	return make([]int16, len(in))
+}
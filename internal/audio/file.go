@@ -0,0 +1,143 @@
+package audio
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SourceFile replays a WAV recording instead of a live device
+const SourceFile = "file"
+
+// fileTrailingSilence is fed after the recording so endpointing and VAD
+// see the speaker stop, just as they would with a live microphone
+const fileTrailingSilence = 1500 * time.Millisecond
+
+// FileSource feeds a WAV recording through the capture pipeline in
+// FramesPerBuffer frames, so recognition and VAD can be exercised with the
+// same audio every run. Recordings are converted to 16kHz mono on load.
+type FileSource struct {
+	path    string
+	speed   float64
+	loop    bool
+	samples []int16
+
+	stop  chan struct{}
+	done  chan struct{}
+	mutex sync.Mutex
+}
+
+// NewFileSource loads a WAV file to replay. Speed 1 delivers frames in real
+// time, 2 twice as fast, and 0 as fast as the pipeline accepts them. With
+// loop set the recording starts over after the trailing silence.
+func NewFileSource(path string, speed float64, loop bool) (*FileSource, error) {
+	if speed < 0 {
+		return nil, fmt.Errorf("replay speed can't be negative")
+	}
+
+	samples, rate, channels, err := ReadWAVFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	samples = Resample(ToMono(samples, channels), rate, SampleRate)
+
+	return &FileSource{
+		path:    path,
+		speed:   speed,
+		loop:    loop,
+		samples: samples,
+	}, nil
+}
+
+// Name identifies the source in logs
+func (f *FileSource) Name() string {
+	return SourceFile + " " + filepath.Base(f.path)
+}
+
+// Duration is how long the recording lasts at real-time speed
+func (f *FileSource) Duration() time.Duration {
+	return time.Duration(len(f.samples)) * time.Second / SampleRate
+}
+
+// Start begins replaying from the start of the recording
+func (f *FileSource) Start(onFrame func(frame []int16)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.stop != nil {
+		return nil
+	}
+	f.stop = make(chan struct{})
+	f.done = make(chan struct{})
+	go f.run(onFrame, f.stop, f.done)
+
+	log.Printf("📼 Replaying %s (%.1fs at %gx)", f.path, f.Duration().Seconds(), f.speed)
+	return nil
+}
+
+// Stop ends the replay and waits for the last frame to be delivered
+func (f *FileSource) Stop() {
+	f.mutex.Lock()
+	stop, done := f.stop, f.done
+	f.stop, f.done = nil, nil
+	f.mutex.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// run delivers frames on a fixed schedule so timing doesn't drift with
+// however long onFrame takes
+func (f *FileSource) run(onFrame func(frame []int16), stop, done chan struct{}) {
+	defer close(done)
+
+	silence := int(fileTrailingSilence.Seconds() * SampleRate)
+	total := len(f.samples) + silence
+	frame := make([]int16, FramesPerBuffer)
+
+	var interval time.Duration
+	if f.speed > 0 {
+		interval = time.Duration(float64(FramesPerBuffer) / SampleRate / f.speed * float64(time.Second))
+	}
+
+	for {
+		started := time.Now()
+		for index, position := 0, 0; position < total; index, position = index+1, position+FramesPerBuffer {
+			if interval > 0 {
+				wait := time.Until(started.Add(time.Duration(index) * interval))
+				if wait > 0 {
+					select {
+					case <-stop:
+						return
+					case <-time.After(wait):
+					}
+				}
+			}
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			// Past the end of the recording the frame is zero-filled
+			copied := 0
+			if position < len(f.samples) {
+				copied = copy(frame, f.samples[position:])
+			}
+			for i := copied; i < len(frame); i++ {
+				frame[i] = 0
+			}
+			onFrame(frame)
+		}
+
+		if !f.loop {
+			log.Printf("📼 Finished replaying %s", filepath.Base(f.path))
+			<-stop
+			return
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package audio
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// FrameQueue hands audio frames from the PortAudio callback to a consumer
+// goroutine over a buffered channel, so the two never share a slice.
+// Push never blocks: when the consumer falls behind, frames are dropped and counted.
+type FrameQueue struct {
+	frames  chan []int16
+	pushed  uint64
+	dropped uint64
+}
+
+// NewFrameQueue creates a queue that can hold backlog worth of audio
+// before frames start being dropped
+func NewFrameQueue(backlog time.Duration) *FrameQueue {
+	samples := int(backlog.Seconds() * SampleRate * Channels)
+	capacity := (samples + FramesPerBuffer - 1) / FramesPerBuffer
+	if capacity < 2 {
+		capacity = 2
+	}
+	return &FrameQueue{
+		frames: make(chan []int16, capacity),
+	}
+}
+
+// Push copies a frame into the queue. PortAudio reuses its buffer between
+// callbacks, so the caller's slice must not be retained.
+func (q *FrameQueue) Push(in []int16) bool {
+	frame := make([]int16, len(in))
+	copy(frame, in)
+
+	select {
+	case q.frames <- frame:
+		atomic.AddUint64(&q.pushed, 1)
+		return true
+	default:
+		atomic.AddUint64(&q.dropped, 1)
+		return false
+	}
+}
+
+// Drain returns every queued sample concatenated in arrival order
+func (q *FrameQueue) Drain() []int16 {
+	var out []int16
+	for {
+		select {
+		case frame := <-q.frames:
+			out = append(out, frame...)
+		default:
+			return out
+		}
+	}
+}
+
+// Reset discards queued frames and clears the counters
+func (q *FrameQueue) Reset() {
+	q.Drain()
+	atomic.StoreUint64(&q.pushed, 0)
+	atomic.StoreUint64(&q.dropped, 0)
+}
+
+// Stats returns how many frames were queued and dropped since the last reset
+func (q *FrameQueue) Stats() (pushed, dropped uint64) {
+	return atomic.LoadUint64(&q.pushed), atomic.LoadUint64(&q.dropped)
+}
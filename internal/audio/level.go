@@ -0,0 +1,88 @@
+package audio
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level meter tuning
+const (
+	levelFloorDB = -60.0                  // Quietest level shown; anything below reads as silence
+	levelRelease = 0.85                   // Fraction of the previous level kept per frame when it falls
+	levelStale   = 300 * time.Millisecond // A meter with no frames for this long reads zero
+)
+
+// LevelMeter tracks how loud captured audio is, for showing the user that
+// the microphone hears them. It rises at once and falls back gradually so
+// the display doesn't flicker.
+type LevelMeter struct {
+	level   float64
+	updated time.Time
+	mutex   sync.Mutex
+}
+
+// NewLevelMeter creates a meter reading zero
+func NewLevelMeter() *LevelMeter {
+	return &LevelMeter{}
+}
+
+// Measure updates the meter with a captured frame
+func (m *LevelMeter) Measure(frame []int16) {
+	if len(frame) == 0 {
+		return
+	}
+
+	rms := frameRMS(frame)
+
+	// Map -60..0 dBFS onto 0..1, which follows perceived loudness
+	level := 0.0
+	if rms > 0 {
+		level = (20*math.Log10(rms) - levelFloorDB) / -levelFloorDB
+	}
+	level = math.Max(0, math.Min(1, level))
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if level < m.level {
+		level = math.Max(level, m.level*levelRelease)
+	}
+	m.level = level
+	m.updated = time.Now()
+}
+
+// Level returns the current level from 0 (silence) to 1 (full scale)
+func (m *LevelMeter) Level() float64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if time.Since(m.updated) > levelStale {
+		return 0
+	}
+	return m.level
+}
+
+// LevelBar draws a level as a text bar width characters wide
+func LevelBar(level float64, width int) string {
+	filled := int(math.Round(math.Max(0, math.Min(1, level)) * float64(width)))
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// MeteredSource passes frames from another source through a level meter
+type MeteredSource struct {
+	Source
+	meter *LevelMeter
+}
+
+// NewMeteredSource measures everything source captures with meter
+func NewMeteredSource(source Source, meter *LevelMeter) *MeteredSource {
+	return &MeteredSource{Source: source, meter: meter}
+}
+
+// Start starts the wrapped source, measuring each frame before handing it on
+func (m *MeteredSource) Start(onFrame func(frame []int16)) error {
+	return m.Source.Start(func(frame []int16) {
+		m.meter.Measure(frame)
+		onFrame(frame)
+	})
+}
@@ -0,0 +1,263 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+
+	"voice-assistant/internal/wincom"
+)
+
+var (
+	iidIAudioClient        = wincom.NewGUID(0x1CB9AD4C, 0xDBFA, 0x4C32, [8]byte{0xB1, 0x78, 0xC2, 0xF5, 0x68, 0xA7, 0x03, 0xB2})
+	iidIAudioCaptureClient = wincom.NewGUID(0xC8ADBD64, 0xE71E, 0x48A0, [8]byte{0xA4, 0xDE, 0x18, 0x5C, 0x39, 0x5C, 0xD3, 0x17})
+)
+
+// WASAPI constants
+const (
+	AUDCLNT_SHAREMODE_SHARED     = 0
+	AUDCLNT_STREAMFLAGS_LOOPBACK = 0x00020000
+	AUDCLNT_BUFFERFLAGS_SILENT   = 0x2
+	WAVE_FORMAT_IEEE_FLOAT       = 0x0003
+	WAVE_FORMAT_EXTENSIBLE       = 0xFFFE
+
+	loopbackBufferDuration = 10000000 // 1 second in 100ns units
+	loopbackPollInterval   = 10 * time.Millisecond
+)
+
+// Vtable slots (IUnknown takes slots 0-2)
+const (
+	methodActivate          = 3  // IMMDevice
+	methodInitialize        = 3  // IAudioClient
+	methodGetMixFormat      = 8  // IAudioClient
+	methodStart             = 10 // IAudioClient
+	methodStop              = 11 // IAudioClient
+	methodGetService        = 14 // IAudioClient
+	methodGetBuffer         = 3  // IAudioCaptureClient
+	methodReleaseBuffer     = 4  // IAudioCaptureClient
+	methodGetNextPacketSize = 5  // IAudioCaptureClient
+)
+
+// waveFormatEx mirrors WAVEFORMATEX, followed by the extensible fields
+type waveFormatEx struct {
+	formatTag      uint16
+	channels       uint16
+	samplesPerSec  uint32
+	avgBytesPerSec uint32
+	blockAlign     uint16
+	bitsPerSample  uint16
+	size           uint16
+	validBits      uint16
+	channelMask    uint32
+	subFormat      wincom.GUID
+}
+
+// isFloat reports whether samples are 32-bit IEEE floats, which is what
+// the shared-mode mix format almost always is
+func (f *waveFormatEx) isFloat() bool {
+	switch f.formatTag {
+	case WAVE_FORMAT_IEEE_FLOAT:
+		return true
+	case WAVE_FORMAT_EXTENSIBLE:
+		return f.subFormat.Data1 == WAVE_FORMAT_IEEE_FLOAT
+	}
+	return false
+}
+
+// LoopbackSource captures what the default output device is playing,
+// so calls and videos can be transcribed
+type LoopbackSource struct {
+	stop  chan struct{}
+	done  chan struct{}
+	mutex sync.Mutex
+}
+
+// NewLoopbackSource creates a loopback source for the default output device
+func NewLoopbackSource() *LoopbackSource {
+	return &LoopbackSource{}
+}
+
+// Name identifies the source in logs
+func (l *LoopbackSource) Name() string {
+	return SourceLoopback
+}
+
+// Start begins capturing on a dedicated COM thread
+func (l *LoopbackSource) Start(onFrame func(frame []int16)) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.stop != nil {
+		return nil
+	}
+
+	started := make(chan error, 1)
+	l.stop = make(chan struct{})
+	l.done = make(chan struct{})
+	go l.run(onFrame, started, l.stop, l.done)
+
+	err := <-started
+	if err != nil {
+		l.stop, l.done = nil, nil
+		return err
+	}
+	log.Printf("🔊 System audio capture started")
+	return nil
+}
+
+// Stop ends the capture and waits for the capture thread to exit
+func (l *LoopbackSource) Stop() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.stop == nil {
+		return
+	}
+	close(l.stop)
+	<-l.done
+	l.stop, l.done = nil, nil
+}
+
+// run owns every COM object of the capture; COM state is per thread
+func (l *LoopbackSource) run(onFrame func([]int16), started chan<- error, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	uninitialize, err := wincom.Initialize()
+	if err != nil {
+		started <- err
+		return
+	}
+	defer uninitialize()
+
+	device, err := wincom.DefaultAudioEndpoint(wincom.ERender)
+	if err != nil {
+		started <- err
+		return
+	}
+	defer device.Release()
+
+	var client *wincom.Object
+	hr := device.Call(methodActivate, uintptr(unsafe.Pointer(&iidIAudioClient)), wincom.CLSCTX_ALL, 0, uintptr(unsafe.Pointer(&client)))
+	if wincom.Failed(hr) {
+		started <- fmt.Errorf("failed to activate audio client: 0x%08X", uint32(hr))
+		return
+	}
+	defer client.Release()
+
+	var format *waveFormatEx
+	hr = client.Call(methodGetMixFormat, uintptr(unsafe.Pointer(&format)))
+	if wincom.Failed(hr) {
+		started <- fmt.Errorf("failed to read mix format: 0x%08X", uint32(hr))
+		return
+	}
+	defer wincom.TaskMemFree(uintptr(unsafe.Pointer(format)))
+
+	hr = client.Call(methodInitialize, AUDCLNT_SHAREMODE_SHARED, AUDCLNT_STREAMFLAGS_LOOPBACK,
+		loopbackBufferDuration, 0, uintptr(unsafe.Pointer(format)), 0)
+	if wincom.Failed(hr) {
+		started <- fmt.Errorf("failed to initialize loopback capture: 0x%08X", uint32(hr))
+		return
+	}
+
+	var capture *wincom.Object
+	hr = client.Call(methodGetService, uintptr(unsafe.Pointer(&iidIAudioCaptureClient)), uintptr(unsafe.Pointer(&capture)))
+	if wincom.Failed(hr) {
+		started <- fmt.Errorf("failed to get capture client: 0x%08X", uint32(hr))
+		return
+	}
+	defer capture.Release()
+
+	hr = client.Call(methodStart)
+	if wincom.Failed(hr) {
+		started <- fmt.Errorf("failed to start loopback capture: 0x%08X", uint32(hr))
+		return
+	}
+	defer client.Call(methodStop)
+
+	log.Printf("   🔧 Mix format: %d Hz, %d channels, %d-bit", format.samplesPerSec, format.channels, format.bitsPerSample)
+	started <- nil
+
+	ticker := time.NewTicker(loopbackPollInterval)
+	defer ticker.Stop()
+	silence := make([]int16, SampleRate*int(loopbackPollInterval)/int(time.Second))
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		// Nothing is delivered while nothing plays; keep time moving with silence
+		if !l.drain(capture, format, onFrame) {
+			onFrame(silence)
+		}
+	}
+}
+
+// drain passes every pending packet to onFrame, reporting whether there were any
+func (l *LoopbackSource) drain(capture *wincom.Object, format *waveFormatEx, onFrame func([]int16)) bool {
+	delivered := false
+	for {
+		var packet uint32
+		if wincom.Failed(capture.Call(methodGetNextPacketSize, uintptr(unsafe.Pointer(&packet)))) || packet == 0 {
+			return delivered
+		}
+
+		var data *byte
+		var frames, flags uint32
+		hr := capture.Call(methodGetBuffer, uintptr(unsafe.Pointer(&data)), uintptr(unsafe.Pointer(&frames)), uintptr(unsafe.Pointer(&flags)), 0, 0)
+		if wincom.Failed(hr) {
+			return delivered
+		}
+
+		var samples []int16
+		if flags&AUDCLNT_BUFFERFLAGS_SILENT != 0 || data == nil {
+			samples = make([]int16, int(frames)*int(format.channels))
+		} else {
+			samples = convertSamples(unsafe.Slice(data, int(frames)*int(format.blockAlign)), format)
+		}
+		capture.Call(methodReleaseBuffer, uintptr(frames))
+
+		onFrame(Resample(ToMono(samples, int(format.channels)), int(format.samplesPerSec), SampleRate))
+		delivered = true
+	}
+}
+
+// convertSamples turns a packet in the mix format into 16-bit samples
+func convertSamples(data []byte, format *waveFormatEx) []int16 {
+	switch {
+	case format.isFloat() && format.bitsPerSample == 32:
+		samples := make([]int16, len(data)/4)
+		for i := range samples {
+			value := math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+			if value > 1 {
+				value = 1
+			} else if value < -1 {
+				value = -1
+			}
+			samples[i] = int16(value * math.MaxInt16)
+		}
+		return samples
+	case format.bitsPerSample == 16:
+		samples := make([]int16, len(data)/2)
+		for i := range samples {
+			samples[i] = int16(binary.LittleEndian.Uint16(data[i*2:]))
+		}
+		return samples
+	case format.bitsPerSample == 32:
+		samples := make([]int16, len(data)/4)
+		for i := range samples {
+			samples[i] = int16(int32(binary.LittleEndian.Uint32(data[i*4:])) >> 16)
+		}
+		return samples
+	}
+	return make([]int16, len(data)/int(format.blockAlign)*int(format.channels))
+}
@@ -0,0 +1,68 @@
+package audio
+
+import "encoding/binary"
+
+// oggCRC is the lookup table for the Ogg page checksum (polynomial
+// 0x04c11db7, unreflected, no final xor)
+var oggCRC = func() [256]uint32 {
+	var table [256]uint32
+	for i := range table {
+		crc := uint32(i) << 24
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04c11db7
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}()
+
+// oggBeginStream flags the first page of a stream
+const oggBeginStream = 0x02
+
+// oggStream writes the packets of one logical Ogg stream as pages
+type oggStream struct {
+	serial   uint32
+	sequence uint32
+}
+
+// page wraps whole packets in one page. granule is the stream position
+// after the last packet.
+func (s *oggStream) page(packets [][]byte, granule uint64, flags byte) []byte {
+	var lacing []byte
+	size := 0
+	for _, packet := range packets {
+		// A packet is a run of 255 byte segments ended by a shorter one
+		for n := len(packet); ; n -= 255 {
+			if n < 255 {
+				lacing = append(lacing, byte(n))
+				break
+			}
+			lacing = append(lacing, 255)
+		}
+		size += len(packet)
+	}
+
+	page := make([]byte, 27, 27+len(lacing)+size)
+	copy(page, "OggS")
+	page[5] = flags
+	binary.LittleEndian.PutUint64(page[6:], granule)
+	binary.LittleEndian.PutUint32(page[14:], s.serial)
+	binary.LittleEndian.PutUint32(page[18:], s.sequence)
+	page[26] = byte(len(lacing))
+	page = append(page, lacing...)
+	for _, packet := range packets {
+		page = append(page, packet...)
+	}
+	s.sequence++
+
+	var crc uint32
+	for _, b := range page {
+		crc = crc<<8 ^ oggCRC[byte(crc>>24)^b]
+	}
+	binary.LittleEndian.PutUint32(page[22:], crc)
+	return page
+}
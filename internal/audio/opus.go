@@ -0,0 +1,139 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+
+	"gopkg.in/hraban/opus.v2"
+)
+
+// OggOpusContentType is the MIME type of what OpusEncoder produces
+const OggOpusContentType = "audio/ogg; codecs=opus"
+
+// Opus encoding settings
+const (
+	opusBitrate   = 24000           // Bits per second; speech stays clean for recognition well below this
+	opusFrame     = SampleRate / 50 // Samples per 20ms packet
+	opusGranule   = 960             // The same 20ms at the 48kHz Ogg Opus always counts in
+	opusPreSkip   = 312             // Encoder lookahead at 48kHz, trimmed by decoders
+	opusPageLimit = 50              // Packets per Ogg page, well inside its 255 segments
+	opusMaxPacket = 4000            // Largest packet libopus recommends allowing for
+	opusMaxFrame  = 5760            // Samples per channel in the longest packet, 120ms at 48kHz
+)
+
+// OpusEncoder compresses SampleRate mono audio into an Ogg Opus stream,
+// around a tenth of the size of 16-bit PCM. Each encoder makes one stream.
+type OpusEncoder struct {
+	encoder *opus.Encoder
+	stream  oggStream
+	granule uint64
+	pending []int16 // Samples short of a whole packet, held for the next call
+	packet  []byte
+}
+
+// NewOpusEncoder creates an encoder tuned for speech
+func NewOpusEncoder() (*OpusEncoder, error) {
+	encoder, err := opus.NewEncoder(SampleRate, Channels, opus.AppVoIP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Opus encoder: %v", err)
+	}
+	err = encoder.SetBitrate(opusBitrate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set Opus bitrate: %v", err)
+	}
+
+	return &OpusEncoder{
+		encoder: encoder,
+		stream:  oggStream{serial: rand.Uint32()},
+		packet:  make([]byte, opusMaxPacket),
+	}, nil
+}
+
+// Header returns the pages that open the stream, which must be sent
+// before any audio
+func (e *OpusEncoder) Header() []byte {
+	head := make([]byte, 19)
+	copy(head, "OpusHead")
+	head[8] = 1 // Version
+	head[9] = Channels
+	binary.LittleEndian.PutUint16(head[10:], opusPreSkip)
+	binary.LittleEndian.PutUint32(head[12:], SampleRate)
+
+	vendor := "voice-assistant"
+	tags := make([]byte, 8+4+len(vendor)+4)
+	copy(tags, "OpusTags")
+	binary.LittleEndian.PutUint32(tags[8:], uint32(len(vendor)))
+	copy(tags[12:], vendor)
+
+	pages := e.stream.page([][]byte{head}, 0, oggBeginStream)
+	return append(pages, e.stream.page([][]byte{tags}, 0, 0)...)
+}
+
+// Encode compresses samples into Ogg pages. Only whole 20ms packets are
+// encoded, so the result is empty when less than that has built up.
+func (e *OpusEncoder) Encode(samples []int16) ([]byte, error) {
+	e.pending = append(e.pending, samples...)
+
+	var pages []byte
+	var packets [][]byte
+	for len(e.pending) >= opusFrame {
+		n, err := e.encoder.Encode(e.pending[:opusFrame], e.packet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode Opus: %v", err)
+		}
+		packets = append(packets, append([]byte(nil), e.packet[:n]...))
+		e.granule += opusGranule
+		e.pending = e.pending[opusFrame:]
+
+		if len(packets) == opusPageLimit {
+			pages = append(pages, e.stream.page(packets, e.granule, 0)...)
+			packets = nil
+		}
+	}
+	if len(packets) > 0 {
+		pages = append(pages, e.stream.page(packets, e.granule, 0)...)
+	}
+
+	// Move the remainder to the front so pending doesn't grow without bound
+	e.pending = append(e.pending[:0:0], e.pending...)
+	return pages, nil
+}
+
+// Restart begins a new stream, which must again open with Header
+func (e *OpusEncoder) Restart() {
+	e.encoder.Reset()
+	e.stream = oggStream{serial: rand.Uint32()}
+	e.granule = 0
+	e.pending = nil
+}
+
+// OpusDecoder expands raw Opus packets, one per call, into 16-bit PCM
+type OpusDecoder struct {
+	decoder  *opus.Decoder
+	channels int
+	pcm      []int16
+}
+
+// NewOpusDecoder creates a decoder for packets at rate, which Opus limits
+// to 8, 12, 16, 24 or 48kHz, with this many channels
+func NewOpusDecoder(rate, channels int) (*OpusDecoder, error) {
+	decoder, err := opus.NewDecoder(rate, channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Opus decoder: %v", err)
+	}
+	return &OpusDecoder{
+		decoder:  decoder,
+		channels: channels,
+		pcm:      make([]int16, opusMaxFrame*channels),
+	}, nil
+}
+
+// Decode expands one packet into interleaved samples
+func (d *OpusDecoder) Decode(packet []byte) ([]int16, error) {
+	n, err := d.decoder.Decode(packet, d.pcm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Opus: %v", err)
+	}
+	return append([]int16(nil), d.pcm[:n*d.channels]...), nil
+}
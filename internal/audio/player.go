@@ -1 +1,224 @@
 package audio
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/gordonklaus/portaudio"
+)
+
+// Player plays mono 16-bit audio on the chosen output device
+type Player struct {
+	device  string  // Output device name; empty uses the system default
+	volume  float64 // Gain applied to every sample, 0 to 1
+	stream  *portaudio.Stream
+	samples []int16
+	pos     int
+	ended   bool // No more chunks will arrive
+	done    chan struct{}
+	mutex   sync.Mutex
+}
+
+// NewPlayer initializes PortAudio for playback
+func NewPlayer() (*Player, error) {
+	err := portaudio.Initialize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize PortAudio: %v", err)
+	}
+	return &Player{volume: 1}, nil
+}
+
+// OutputDevices lists the output devices of the default host API
+func (p *Player) OutputDevices() ([]string, error) {
+	hostAPI, err := portaudio.DefaultHostApi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audio devices: %v", err)
+	}
+
+	var names []string
+	for _, device := range hostAPI.Devices {
+		if device.MaxOutputChannels > 0 {
+			names = append(names, device.Name)
+		}
+	}
+	return names, nil
+}
+
+// SetDevice selects the output device by name; empty uses the default.
+// It takes effect from the next playback.
+func (p *Player) SetDevice(name string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.device = name
+}
+
+// SetVolume sets the playback gain from 0 (silent) to 1 (unchanged)
+func (p *Player) SetVolume(volume float64) {
+	if volume < 0 {
+		volume = 0
+	}
+	if volume > 1 {
+		volume = 1
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.volume = volume
+}
+
+// Play plays samples and blocks until they finish or Stop is called
+func (p *Player) Play(samples []int16, sampleRate int) error {
+	chunks := make(chan []int16, 1)
+	chunks <- samples
+	close(chunks)
+	return p.PlayStream(chunks, sampleRate)
+}
+
+// PlayStream starts playing as soon as audio arrives on chunks and blocks
+// until chunks is closed and everything has been played, or Stop is called
+func (p *Player) PlayStream(chunks <-chan []int16, sampleRate int) error {
+	p.mutex.Lock()
+	if p.stream != nil {
+		p.mutex.Unlock()
+		return fmt.Errorf("already playing")
+	}
+
+	p.samples = nil
+	p.pos = 0
+	p.ended = false
+	p.done = make(chan struct{})
+	stream, err := p.openStream(sampleRate)
+	if err != nil {
+		p.mutex.Unlock()
+		return fmt.Errorf("failed to open output stream: %v", err)
+	}
+	p.stream = stream
+	done := p.done
+
+	err = stream.Start()
+	if err != nil {
+		stream.Close()
+		p.stream = nil
+		p.mutex.Unlock()
+		return fmt.Errorf("failed to start output stream: %v", err)
+	}
+	p.mutex.Unlock()
+
+	// Feed chunks to the callback until the producer is done. After Stop
+	// the rest is drained and dropped so a later playback isn't polluted.
+	go func() {
+		for chunk := range chunks {
+			p.mutex.Lock()
+			if p.done == done {
+				p.samples = append(p.samples, chunk...)
+			}
+			p.mutex.Unlock()
+		}
+		p.mutex.Lock()
+		if p.done == done {
+			p.ended = true
+		}
+		p.mutex.Unlock()
+	}()
+
+	<-done
+
+	// Stop outside the lock: it waits for the callback, which takes the lock
+	stream.Stop()
+	stream.Close()
+
+	p.mutex.Lock()
+	p.stream = nil
+	p.mutex.Unlock()
+	return nil
+}
+
+// openStream opens the selected output device, falling back to the
+// default device when it has been unplugged
+func (p *Player) openStream(sampleRate int) (*portaudio.Stream, error) {
+	if p.device != "" {
+		device, err := findOutputDevice(p.device)
+		if err == nil {
+			params := portaudio.StreamParameters{
+				Output: portaudio.StreamDeviceParameters{
+					Device:   device,
+					Channels: Channels,
+					Latency:  device.DefaultLowOutputLatency,
+				},
+				SampleRate:      float64(sampleRate),
+				FramesPerBuffer: FramesPerBuffer,
+			}
+			return portaudio.OpenStream(params, p.fill)
+		}
+		log.Printf("⚠️  %v - using the default output device", err)
+	}
+	return portaudio.OpenDefaultStream(0, Channels, float64(sampleRate), FramesPerBuffer, p.fill)
+}
+
+// findOutputDevice looks up an output device of the default host API
+func findOutputDevice(name string) (*portaudio.DeviceInfo, error) {
+	hostAPI, err := portaudio.DefaultHostApi()
+	if err != nil {
+		return nil, err
+	}
+	for _, device := range hostAPI.Devices {
+		if device.Name == name && device.MaxOutputChannels > 0 {
+			return device, nil
+		}
+	}
+	return nil, fmt.Errorf("output device %q not found", name)
+}
+
+// Stop interrupts the current playback
+func (p *Player) Stop() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.stream != nil {
+		log.Printf("⏹️  Playback stopped")
+		p.finish()
+	}
+}
+
+// IsPlaying reports whether audio is currently playing
+func (p *Player) IsPlaying() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.stream != nil
+}
+
+// Close releases PortAudio
+func (p *Player) Close() {
+	p.Stop()
+	portaudio.Terminate()
+}
+
+// fill is the PortAudio callback that copies the next samples to the
+// device, padding with silence while a stream is waiting for more audio
+func (p *Player) fill(out []int16) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	n := copy(out, p.samples[p.pos:])
+	p.pos += n
+	for i := n; i < len(out); i++ {
+		out[i] = 0
+	}
+	if p.volume < 1 {
+		for i := 0; i < n; i++ {
+			out[i] = int16(float64(out[i]) * p.volume)
+		}
+	}
+	if p.ended && p.pos >= len(p.samples) {
+		p.finish()
+	}
+}
+
+// finish wakes PlayStream; safe to call more than once
+func (p *Player) finish() {
+	select {
+	case <-p.done:
+	default:
+		close(p.done)
+	}
+}
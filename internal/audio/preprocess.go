@@ -0,0 +1,163 @@
+package audio
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Preprocessing tuning
+const (
+	agcAttack      = 0.5                    // Share of the way to a lower gain covered per frame, so loud speech is caught quickly
+	agcRelease     = 0.05                   // Share of the way to a higher gain covered per frame, so pauses don't pump up noise
+	floorRise      = 0.003                  // How fast the noise floor estimate creeps up in steady sound; slow enough that a long sentence doesn't close the gate
+	gateClosedGain = 0.1                    // What a closed gate lets through (-20 dB), softer than muting outright
+	gateHold       = 250 * time.Millisecond // How long the gate stays open after speech drops below the threshold
+)
+
+// PreprocessOptions turns the cleanup stages on and tunes them
+type PreprocessOptions struct {
+	AutoGain     bool    // Bring speech to TargetDB
+	TargetDB     float64 // Level speech is brought to, in dBFS
+	MaxGainDB    float64 // Most the gain control may boost a quiet microphone
+	NoiseGate    bool    // Turn down audio that is close to the background noise
+	GateMarginDB float64 // How far above the noise floor sound must be to pass
+}
+
+// Preprocessor cleans up captured audio before recognition: a noise gate
+// quietens the background between words and automatic gain control evens
+// out quiet and loud microphones. Frames are processed in order on the
+// capture thread.
+type Preprocessor struct {
+	options   PreprocessOptions
+	gain      float64 // Current automatic gain
+	floor     float64 // Estimated background noise RMS
+	gate      float64 // Current gate gain
+	openUntil time.Time
+	applied   float64 // Total gain at the end of the last frame, ramped from
+	buffer    []int16
+	mutex     sync.Mutex
+}
+
+// NewPreprocessor creates a preprocessor with the given options
+func NewPreprocessor(options PreprocessOptions) *Preprocessor {
+	return &Preprocessor{options: options, gain: 1, gate: 1, applied: 1}
+}
+
+// SetOptions changes the stages from the next frame
+func (p *Preprocessor) SetOptions(options PreprocessOptions) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.options = options
+	if !options.AutoGain {
+		p.gain = 1
+	}
+	if !options.NoiseGate {
+		p.gate = 1
+	}
+}
+
+// Process returns the cleaned-up frame. The result is only valid until
+// the next call.
+func (p *Preprocessor) Process(frame []int16) []int16 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.options.AutoGain && !p.options.NoiseGate || len(frame) == 0 {
+		return frame
+	}
+
+	rms := frameRMS(frame)
+	speech := true
+
+	if p.options.NoiseGate {
+		// The floor follows quiet stretches down at once and rises slowly
+		// through sound, so it settles on the background between words
+		if p.floor == 0 || rms < p.floor {
+			p.floor = rms
+		} else {
+			p.floor += (rms - p.floor) * floorRise
+		}
+
+		threshold := p.floor * dbToGain(p.options.GateMarginDB)
+		now := time.Now()
+		if rms > threshold {
+			p.openUntil = now.Add(gateHold)
+		}
+		speech = now.Before(p.openUntil)
+		if speech {
+			p.gate = 1
+		} else {
+			p.gate = gateClosedGain
+		}
+	}
+
+	// Only speech steers the gain, or silence would be boosted to full volume
+	if p.options.AutoGain && speech && rms > 0 {
+		desired := dbToGain(p.options.TargetDB) / rms
+		desired = math.Min(desired, dbToGain(p.options.MaxGainDB))
+		if desired < p.gain {
+			p.gain += (desired - p.gain) * agcAttack
+		} else {
+			p.gain += (desired - p.gain) * agcRelease
+		}
+	}
+
+	// Ramp across the frame from the last gain to the new one to avoid clicks
+	target := p.gain * p.gate
+	if cap(p.buffer) < len(frame) {
+		p.buffer = make([]int16, len(frame))
+	}
+	out := p.buffer[:len(frame)]
+	for i, sample := range frame {
+		gain := p.applied + (target-p.applied)*float64(i+1)/float64(len(frame))
+		out[i] = clip16(float64(sample) * gain)
+	}
+	p.applied = target
+	return out
+}
+
+// frameRMS returns the root mean square of a frame as a fraction of full scale
+func frameRMS(frame []int16) float64 {
+	var sum float64
+	for _, sample := range frame {
+		value := float64(sample) / math.MaxInt16
+		sum += value * value
+	}
+	return math.Sqrt(sum / float64(len(frame)))
+}
+
+// dbToGain converts decibels to a linear factor
+func dbToGain(db float64) float64 {
+	return math.Pow(10, db/20)
+}
+
+// clip16 converts to a sample, saturating instead of wrapping around
+func clip16(value float64) int16 {
+	if value > math.MaxInt16 {
+		return math.MaxInt16
+	}
+	if value < math.MinInt16 {
+		return math.MinInt16
+	}
+	return int16(value)
+}
+
+// PreprocessedSource passes frames from another source through a
+// preprocessor
+type PreprocessedSource struct {
+	Source
+	preprocessor *Preprocessor
+}
+
+// NewPreprocessedSource cleans up everything source captures
+func NewPreprocessedSource(source Source, preprocessor *Preprocessor) *PreprocessedSource {
+	return &PreprocessedSource{Source: source, preprocessor: preprocessor}
+}
+
+// Start starts the wrapped source, processing each frame before handing it on
+func (p *PreprocessedSource) Start(onFrame func(frame []int16)) error {
+	return p.Source.Start(func(frame []int16) {
+		onFrame(p.preprocessor.Process(frame))
+	})
+}
@@ -18,7 +18,7 @@ const (
 	FramesPerBuffer = 1024
 
 	// Memory management
-	MaxRecordingDuration = 60 * time.Second // 60 second max recording
+	MaxRecordingDuration = 60 * time.Second // Default recording limit
 	TempFilePrefix       = "voice_assistant_"
 	TempFileExt          = ".wav"
 )
@@ -32,6 +32,7 @@ type Recorder struct {
 	audioBuffer  []int16
 	file         *os.File
 	startTime    time.Time
+	maxDuration  time.Duration // Recordings stop after this, 0 for no limit
 	onComplete   func(filePath string, duration time.Duration)
 	onError      func(error)
 }
@@ -41,9 +42,17 @@ func NewRecorder() *Recorder {
 	return &Recorder{
 		isRecording: false,
 		audioBuffer: make([]int16, FramesPerBuffer),
+		maxDuration: MaxRecordingDuration,
 	}
 }
 
+// SetMaxDuration limits how long a recording runs, 0 for no limit
+func (r *Recorder) SetMaxDuration(duration time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.maxDuration = duration
+}
+
 // Initialize sets up PortAudio
 func (r *Recorder) Initialize() error {
 	log.Println("Initializing audio system...")
@@ -92,13 +101,7 @@ func (r *Recorder) StartRecording() error {
 	}
 
 	// Set up PortAudio stream
-	stream, err := portaudio.OpenDefaultStream(
-		Channels, // input channels
-		0,        // output channels
-		float64(SampleRate),
-		FramesPerBuffer,
-		r.processAudio,
-	)
+	stream, err := openInputStream(r.processAudio)
 	if err != nil {
 		r.file.Close()
 		os.Remove(r.tempFilePath)
@@ -119,7 +122,9 @@ func (r *Recorder) StartRecording() error {
 	log.Printf("Started recording to: %s", r.tempFilePath)
 
 	// Start monitoring for max duration
-	go r.monitorDuration()
+	if r.maxDuration > 0 {
+		go r.monitorDuration(r.startTime, r.maxDuration)
+	}
 
 	return nil
 }
@@ -178,12 +183,16 @@ func (r *Recorder) processAudio(in []int16) {
 	}
 }
 
-// monitorDuration stops recording if it exceeds max duration
-func (r *Recorder) monitorDuration() {
-	time.Sleep(MaxRecordingDuration)
+// monitorDuration stops the recording begun at started if it runs longer
+// than limit
+func (r *Recorder) monitorDuration(started time.Time, limit time.Duration) {
+	time.Sleep(limit)
 
-	if r.IsRecording() {
-		log.Println("Recording exceeded max duration, stopping...")
+	r.mutex.Lock()
+	current := r.isRecording && r.startTime.Equal(started)
+	r.mutex.Unlock()
+	if current {
+		log.Printf("Recording exceeded %s, stopping...", limit)
 		err := r.StopRecording()
 		if err != nil && r.onError != nil {
 			r.onError(fmt.Errorf("failed to stop recording after timeout: %v", err))
@@ -232,49 +241,7 @@ func (r *Recorder) finalizeWAVFile() error {
 
 // writeWAVHeader writes a WAV file header
 func (r *Recorder) writeWAVHeader(file *os.File, dataSize int) error {
-	fileSize := dataSize + 36
-
-	// Helper function to convert int32 to little-endian bytes
-	int32ToBytes := func(val int32) []byte {
-		return []byte{
-			byte(val),
-			byte(val >> 8),
-			byte(val >> 16),
-			byte(val >> 24),
-		}
-	}
-
-	// Helper function to convert int16 to little-endian bytes
-	int16ToBytes := func(val int16) []byte {
-		return []byte{
-			byte(val),
-			byte(val >> 8),
-		}
-	}
-
-	// Build header piece by piece
-	var header []byte
-
-	// RIFF header
-	header = append(header, 'R', 'I', 'F', 'F')
-	header = append(header, int32ToBytes(int32(fileSize))...)
-	header = append(header, 'W', 'A', 'V', 'E')
-
-	// fmt chunk
-	header = append(header, 'f', 'm', 't', ' ')
-	header = append(header, int32ToBytes(16)...)                  // chunk size
-	header = append(header, int16ToBytes(1)...)                   // PCM format
-	header = append(header, int16ToBytes(int16(Channels))...)     // channels
-	header = append(header, int32ToBytes(int32(SampleRate))...)   // sample rate
-	header = append(header, int32ToBytes(int32(SampleRate*2))...) // byte rate
-	header = append(header, int16ToBytes(2)...)                   // block align
-	header = append(header, int16ToBytes(16)...)                  // bits per sample
-
-	// data chunk
-	header = append(header, 'd', 'a', 't', 'a')
-	header = append(header, int32ToBytes(int32(dataSize))...)
-
-	_, err := file.Write(header)
+	_, err := file.Write(WAVHeader(dataSize))
 	return err
 }
 
@@ -0,0 +1,90 @@
+package audio
+
+// ToMono averages interleaved channels into a single channel
+func ToMono(samples []int16, channels int) []int16 {
+	if channels <= 1 {
+		return samples
+	}
+
+	mono := make([]int16, len(samples)/channels)
+	for i := range mono {
+		var sum int
+		for c := 0; c < channels; c++ {
+			sum += int(samples[i*channels+c])
+		}
+		mono[i] = int16(sum / channels)
+	}
+	return mono
+}
+
+// Resample converts mono audio between sample rates by linear
+// interpolation, which is plenty for speech recognition
+func Resample(samples []int16, from, to int) []int16 {
+	if from == to || from <= 0 || to <= 0 || len(samples) == 0 {
+		return samples
+	}
+
+	length := int(int64(len(samples)) * int64(to) / int64(from))
+	out := make([]int16, length)
+	step := float64(from) / float64(to)
+	for i := range out {
+		position := float64(i) * step
+		index := int(position)
+		if index >= len(samples)-1 {
+			out[i] = samples[len(samples)-1]
+			continue
+		}
+		fraction := position - float64(index)
+		out[i] = int16(float64(samples[index])*(1-fraction) + float64(samples[index+1])*fraction)
+	}
+	return out
+}
+
+// streamResampler converts a live stream to SampleRate mono in
+// FramesPerBuffer frames, carrying the interpolation position and the last
+// sample across buffers so the joins are seamless
+type streamResampler struct {
+	channels int
+	step     float64 // Input samples per output sample
+	position float64 // Of the next output sample, where 0 is the previous buffer's last sample
+	previous int16
+	frame    []int16
+}
+
+// newStreamResampler creates a resampler for audio captured at rate with
+// the given number of interleaved channels
+func newStreamResampler(rate, channels int) *streamResampler {
+	return &streamResampler{
+		channels: channels,
+		step:     float64(rate) / SampleRate,
+		frame:    make([]int16, 0, FramesPerBuffer),
+	}
+}
+
+// write converts a captured buffer, calling onFrame for each full frame
+func (r *streamResampler) write(samples []int16, onFrame func(frame []int16)) {
+	mono := ToMono(samples, r.channels)
+	if len(mono) == 0 {
+		return
+	}
+
+	at := func(index int) float64 {
+		if index == 0 {
+			return float64(r.previous)
+		}
+		return float64(mono[index-1])
+	}
+
+	for r.position < float64(len(mono)) {
+		index := int(r.position)
+		fraction := r.position - float64(index)
+		r.frame = append(r.frame, int16(at(index)*(1-fraction)+at(index+1)*fraction))
+		if len(r.frame) == FramesPerBuffer {
+			onFrame(r.frame)
+			r.frame = r.frame[:0]
+		}
+		r.position += r.step
+	}
+	r.position -= float64(len(mono))
+	r.previous = mono[len(mono)-1]
+}
@@ -0,0 +1,72 @@
+package audio
+
+import (
+	"sync"
+	"time"
+)
+
+// RingBuffer keeps the most recent samples in a fixed-size circular buffer.
+// It is safe to write from the PortAudio callback while another goroutine drains it.
+type RingBuffer struct {
+	samples []int16
+	start   int // Index of the oldest sample
+	length  int // Number of valid samples
+	mutex   sync.Mutex
+}
+
+// NewRingBuffer creates a ring buffer holding duration worth of mono audio
+func NewRingBuffer(duration time.Duration) *RingBuffer {
+	capacity := int(duration.Seconds() * SampleRate * Channels)
+	if capacity < FramesPerBuffer {
+		capacity = FramesPerBuffer
+	}
+	return &RingBuffer{
+		samples: make([]int16, capacity),
+	}
+}
+
+// Write appends samples, overwriting the oldest ones when full
+func (r *RingBuffer) Write(in []int16) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	capacity := len(r.samples)
+
+	// Only the newest capacity samples can survive anyway
+	if len(in) > capacity {
+		in = in[len(in)-capacity:]
+	}
+
+	for _, sample := range in {
+		end := (r.start + r.length) % capacity
+		r.samples[end] = sample
+		if r.length < capacity {
+			r.length++
+		} else {
+			r.start = (r.start + 1) % capacity
+		}
+	}
+}
+
+// Drain returns the buffered samples oldest-first and empties the buffer
+func (r *RingBuffer) Drain() []int16 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	out := make([]int16, r.length)
+	capacity := len(r.samples)
+	for i := 0; i < r.length; i++ {
+		out[i] = r.samples[(r.start+i)%capacity]
+	}
+
+	r.start = 0
+	r.length = 0
+	return out
+}
+
+// Len returns the number of buffered samples
+func (r *RingBuffer) Len() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.length
+}
@@ -0,0 +1,135 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/gordonklaus/portaudio"
+
+	"voice-assistant/internal/errs"
+)
+
+// Capture sources accepted in config
+const (
+	SourceMicrophone = "microphone" // The default input device, through PortAudio
+	SourceLoopback   = "loopback"   // Whatever is playing on the computer, through WASAPI
+)
+
+// Source captures 16kHz mono audio and hands it to a callback in frames.
+// The callback runs on the capture thread and must not keep the frame.
+type Source interface {
+	Name() string
+	Start(onFrame func(frame []int16)) error
+	Stop()
+}
+
+// NewSource creates the capture source with the given name
+func NewSource(name string) (Source, error) {
+	switch name {
+	case SourceMicrophone, "":
+		return NewMicrophoneSource(), nil
+	case SourceLoopback:
+		return NewLoopbackSource(), nil
+	}
+	return nil, fmt.Errorf("unknown capture source %q", name)
+}
+
+// MicrophoneSource captures the default input device with PortAudio
+type MicrophoneSource struct {
+	stream *portaudio.Stream
+	mutex  sync.Mutex
+}
+
+// NewMicrophoneSource creates a microphone source; PortAudio must be initialized
+func NewMicrophoneSource() *MicrophoneSource {
+	return &MicrophoneSource{}
+}
+
+// Name identifies the source in logs
+func (m *MicrophoneSource) Name() string {
+	return SourceMicrophone
+}
+
+// Start opens the input stream
+func (m *MicrophoneSource) Start(onFrame func(frame []int16)) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.stream != nil {
+		return nil
+	}
+
+	stream, err := openInputStream(onFrame)
+	if err != nil {
+		return microphoneError(fmt.Errorf("failed to open audio stream: %w", err))
+	}
+	err = stream.Start()
+	if err != nil {
+		stream.Close()
+		return fmt.Errorf("failed to start audio stream: %v", err)
+	}
+	m.stream = stream
+
+	log.Printf("🎤 Microphone capture started")
+	return nil
+}
+
+// Stop closes the input stream
+func (m *MicrophoneSource) Stop() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.stream != nil {
+		m.stream.Stop()
+		m.stream.Close()
+		m.stream = nil
+	}
+}
+
+// openInputStream opens the default input device at SampleRate mono. Some
+// USB interfaces only run at 44.1 or 48kHz, so if that fails the device is
+// opened at its own rate and converted to SampleRate mono frames instead.
+func openInputStream(onFrame func(frame []int16)) (*portaudio.Stream, error) {
+	stream, err := portaudio.OpenDefaultStream(Channels, 0, float64(SampleRate), FramesPerBuffer, onFrame)
+	if err == nil {
+		return stream, nil
+	}
+
+	device, deviceErr := portaudio.DefaultInputDevice()
+	if deviceErr != nil || device.DefaultSampleRate <= 0 || int(device.DefaultSampleRate) == SampleRate {
+		return nil, err
+	}
+
+	rate := int(device.DefaultSampleRate)
+	buffer := FramesPerBuffer * rate / SampleRate
+	channelOptions := []int{Channels}
+	if device.MaxInputChannels > Channels {
+		// Some drivers only open with every channel
+		channelOptions = append(channelOptions, device.MaxInputChannels)
+	}
+
+	for _, channels := range channelOptions {
+		resampler := newStreamResampler(rate, channels)
+		native, nativeErr := portaudio.OpenDefaultStream(channels, 0, float64(rate), buffer, func(in []int16) {
+			resampler.write(in, onFrame)
+		})
+		if nativeErr == nil {
+			log.Printf("🎚️  %s can't capture at %d Hz, resampling from %d Hz (%d channels)", device.Name, SampleRate, rate, channels)
+			return native, nil
+		}
+	}
+	return nil, err
+}
+
+// microphoneError tags PortAudio failures the user can fix themselves
+func microphoneError(err error) error {
+	switch {
+	case errors.Is(err, portaudio.NoDefaultInputDevice), errors.Is(err, portaudio.InvalidDevice):
+		return errs.New(errs.ErrNoMic, "Microphone", err)
+	case errors.Is(err, portaudio.DeviceUnavailable):
+		return errs.New(errs.ErrDeviceBusy, "Microphone", err)
+	}
+	return err
+}
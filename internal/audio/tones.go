@@ -0,0 +1,47 @@
+package audio
+
+import (
+	"math"
+	"time"
+)
+
+// toneFade ramps each note in and out so it starts and stops without a click
+const toneFade = 5 * time.Millisecond
+
+// Tone is one note of a synthesized sound; a zero frequency is a rest
+type Tone struct {
+	Frequency float64
+	Duration  time.Duration
+	Buzz      bool // Square wave instead of sine, harsher for errors
+}
+
+// RenderTones synthesizes notes back to back at SampleRate, scaled by gain
+// from 0 to 1
+func RenderTones(tones []Tone, gain float64) []int16 {
+	var samples []int16
+	fade := int(toneFade.Seconds() * SampleRate)
+
+	for _, tone := range tones {
+		count := int(tone.Duration.Seconds() * SampleRate)
+		for i := 0; i < count; i++ {
+			if tone.Frequency == 0 {
+				samples = append(samples, 0)
+				continue
+			}
+
+			value := math.Sin(2 * math.Pi * tone.Frequency * float64(i) / SampleRate)
+			if tone.Buzz {
+				value = math.Copysign(0.6, value)
+			}
+
+			envelope := 1.0
+			if i < fade {
+				envelope = float64(i) / float64(fade)
+			} else if count-i < fade {
+				envelope = float64(count-i) / float64(fade)
+			}
+			samples = append(samples, int16(value*envelope*gain*math.MaxInt16))
+		}
+	}
+	return samples
+}
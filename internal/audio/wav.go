@@ -0,0 +1,138 @@
+package audio
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WAVHeaderSize is the size of the canonical PCM WAV header
+const WAVHeaderSize = 44
+
+// WAVHeader builds a 16-bit mono PCM WAV header for dataSize bytes of audio
+func WAVHeader(dataSize int) []byte {
+	fileSize := dataSize + 36
+
+	// Helper function to convert int32 to little-endian bytes
+	int32ToBytes := func(val int32) []byte {
+		return []byte{
+			byte(val),
+			byte(val >> 8),
+			byte(val >> 16),
+			byte(val >> 24),
+		}
+	}
+
+	// Helper function to convert int16 to little-endian bytes
+	int16ToBytes := func(val int16) []byte {
+		return []byte{
+			byte(val),
+			byte(val >> 8),
+		}
+	}
+
+	// Build header piece by piece
+	var header []byte
+
+	// RIFF header
+	header = append(header, 'R', 'I', 'F', 'F')
+	header = append(header, int32ToBytes(int32(fileSize))...)
+	header = append(header, 'W', 'A', 'V', 'E')
+
+	// fmt chunk
+	header = append(header, 'f', 'm', 't', ' ')
+	header = append(header, int32ToBytes(16)...)                  // chunk size
+	header = append(header, int16ToBytes(1)...)                   // PCM format
+	header = append(header, int16ToBytes(int16(Channels))...)     // channels
+	header = append(header, int32ToBytes(int32(SampleRate))...)   // sample rate
+	header = append(header, int32ToBytes(int32(SampleRate*2))...) // byte rate
+	header = append(header, int16ToBytes(2)...)                   // block align
+	header = append(header, int16ToBytes(16)...)                  // bits per sample
+
+	// data chunk
+	header = append(header, 'd', 'a', 't', 'a')
+	header = append(header, int32ToBytes(int32(dataSize))...)
+
+	return header
+}
+
+// EncodeWAV returns samples as a complete in-memory WAV file
+func EncodeWAV(samples []int16) []byte {
+	data := make([]byte, WAVHeaderSize, WAVHeaderSize+len(samples)*2)
+	copy(data, WAVHeader(len(samples)*2))
+	for _, sample := range samples {
+		data = append(data, byte(sample), byte(sample>>8))
+	}
+	return data
+}
+
+// WriteWAVFile saves samples as a WAV file, creating parent directories
+func WriteWAVFile(path string, samples []int16) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create audio directory: %v", err)
+	}
+	return os.WriteFile(path, EncodeWAV(samples), 0644)
+}
+
+// ReadWAVFile loads a 16-bit PCM WAV file, returning its samples along with
+// the sample rate and channel count it was recorded with
+func ReadWAVFile(path string) ([]int16, int, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return DecodeWAV(data, path)
+}
+
+// DecodeWAV decodes 16-bit PCM WAV data already in memory; name is used in
+// errors
+func DecodeWAV(data []byte, name string) ([]int16, int, int, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, 0, 0, fmt.Errorf("%s is not a WAV file", name)
+	}
+
+	var sampleRate, channels int
+	formatSeen := false
+
+	// Walk the chunks; only "fmt " and "data" matter
+	for offset := 12; offset+8 <= len(data); {
+		chunkID := string(data[offset : offset+4])
+		chunkSize := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := data[offset+8:]
+		if chunkSize > len(body) {
+			chunkSize = len(body)
+		}
+		body = body[:chunkSize]
+
+		switch chunkID {
+		case "fmt ":
+			if len(body) < 16 {
+				return nil, 0, 0, fmt.Errorf("%s has a truncated format chunk", name)
+			}
+			format := binary.LittleEndian.Uint16(body[0:2])
+			bitsPerSample := binary.LittleEndian.Uint16(body[14:16])
+			if format != 1 || bitsPerSample != 16 {
+				return nil, 0, 0, fmt.Errorf("%s is not 16-bit PCM", name)
+			}
+			channels = int(binary.LittleEndian.Uint16(body[2:4]))
+			sampleRate = int(binary.LittleEndian.Uint32(body[4:8]))
+			formatSeen = true
+		case "data":
+			if !formatSeen {
+				return nil, 0, 0, fmt.Errorf("%s has no format chunk before its data", name)
+			}
+			samples := make([]int16, len(body)/2)
+			for i := range samples {
+				samples[i] = int16(binary.LittleEndian.Uint16(body[i*2:]))
+			}
+			return samples, sampleRate, channels, nil
+		}
+
+		// Chunks are padded to an even size
+		offset += 8 + chunkSize + chunkSize%2
+	}
+
+	return nil, 0, 0, fmt.Errorf("%s has no audio data", name)
+}
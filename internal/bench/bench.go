@@ -0,0 +1,232 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"voice-assistant/internal/audio"
+)
+
+// Suite layout inside the bench directory
+const (
+	UtterancesDir = "utterances" // *.wav with a matching *.txt reference
+	PromptsFile   = "prompts.txt"
+)
+
+// Utterance is a recorded clip and what was actually said in it
+type Utterance struct {
+	Name      string
+	Samples   []int16
+	Reference string
+}
+
+// Suite is the fixed set of inputs every provider is run against
+type Suite struct {
+	Utterances []Utterance
+	Prompts    []string
+}
+
+// Transcriber recognizes one utterance
+type Transcriber func(samples []int16) (string, error)
+
+// Completer answers one prompt and returns the estimated cost in USD
+type Completer func(prompt string) (float64, error)
+
+// Synthesizer renders one text to speech
+type Synthesizer func(text string) error
+
+// Result summarizes one provider's run over the suite
+type Result struct {
+	Kind      string // "STT", "LLM" or "TTS"
+	Provider  string
+	Runs      int
+	Errors    int
+	Latencies []time.Duration
+	WordErrs  int // STT only
+	RefWords  int // STT only
+	Cost      float64
+	Skipped   string // Reason the provider wasn't run
+}
+
+// LoadSuite reads utterances and prompts from a bench directory. Missing
+// parts are allowed so STT or LLM can be benchmarked on their own.
+func LoadSuite(dir string) (*Suite, error) {
+	suite := &Suite{}
+
+	wavs, err := filepath.Glob(filepath.Join(dir, UtterancesDir, "*.wav"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(wavs)
+	for _, path := range wavs {
+		samples, sampleRate, channels, err := audio.ReadWAVFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if sampleRate != audio.SampleRate || channels != audio.Channels {
+			return nil, fmt.Errorf("%s must be %d Hz mono (got %d Hz, %d channels)",
+				path, audio.SampleRate, sampleRate, channels)
+		}
+
+		reference, err := os.ReadFile(strings.TrimSuffix(path, ".wav") + ".txt")
+		if err != nil {
+			return nil, fmt.Errorf("missing reference transcript for %s: %v", path, err)
+		}
+
+		suite.Utterances = append(suite.Utterances, Utterance{
+			Name:      filepath.Base(path),
+			Samples:   samples,
+			Reference: strings.TrimSpace(string(reference)),
+		})
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, PromptsFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			suite.Prompts = append(suite.Prompts, line)
+		}
+	}
+
+	if len(suite.Utterances) == 0 && len(suite.Prompts) == 0 {
+		return nil, fmt.Errorf("no utterances or prompts found in %s", dir)
+	}
+	return suite, nil
+}
+
+// RunSTT transcribes every utterance with one provider
+func RunSTT(provider string, transcribe Transcriber, utterances []Utterance) Result {
+	result := Result{Kind: "STT", Provider: provider}
+	for _, utterance := range utterances {
+		start := time.Now()
+		text, err := transcribe(utterance.Samples)
+		elapsed := time.Since(start)
+
+		result.Runs++
+		if err != nil {
+			log.Printf("❌ %s failed on %s: %v", provider, utterance.Name, err)
+			result.Errors++
+			continue
+		}
+
+		errs, words := WordErrors(utterance.Reference, text)
+		result.WordErrs += errs
+		result.RefWords += words
+		result.Latencies = append(result.Latencies, elapsed)
+		log.Printf("🎙️  %s %s: %d/%d word errors in %v", provider, utterance.Name, errs, words, elapsed)
+	}
+	return result
+}
+
+// RunLLM sends every prompt to one provider
+func RunLLM(provider string, complete Completer, prompts []string) Result {
+	result := Result{Kind: "LLM", Provider: provider}
+	for i, prompt := range prompts {
+		start := time.Now()
+		cost, err := complete(prompt)
+		elapsed := time.Since(start)
+
+		result.Runs++
+		if err != nil {
+			log.Printf("❌ %s failed on prompt %d: %v", provider, i+1, err)
+			result.Errors++
+			continue
+		}
+
+		result.Cost += cost
+		result.Latencies = append(result.Latencies, elapsed)
+		log.Printf("🤖 %s prompt %d: %v ($%.5f)", provider, i+1, elapsed, cost)
+	}
+	return result
+}
+
+// RunTTS synthesizes every text with one provider
+func RunTTS(provider string, synthesize Synthesizer, texts []string) Result {
+	result := Result{Kind: "TTS", Provider: provider}
+	for i, text := range texts {
+		start := time.Now()
+		err := synthesize(text)
+		elapsed := time.Since(start)
+
+		result.Runs++
+		if err != nil {
+			log.Printf("❌ %s failed on text %d: %v", provider, i+1, err)
+			result.Errors++
+			continue
+		}
+
+		result.Latencies = append(result.Latencies, elapsed)
+		log.Printf("🔈 %s text %d: %v", provider, i+1, elapsed)
+	}
+	return result
+}
+
+// Skip records a provider that couldn't be benchmarked
+func Skip(kind, provider, reason string) Result {
+	return Result{Kind: kind, Provider: provider, Skipped: reason}
+}
+
+// Percentile returns the p-th percentile (0-100) using nearest rank
+func Percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// WriteReport prints a comparison table of all results
+func WriteReport(w io.Writer, results []Result) {
+	fmt.Fprintf(w, "%-4s  %-32s  %5s  %6s  %8s  %8s  %8s  %6s  %9s\n",
+		"KIND", "PROVIDER", "RUNS", "ERRORS", "P50", "P90", "P99", "WER", "COST")
+	for _, r := range results {
+		if r.Skipped != "" {
+			fmt.Fprintf(w, "%-4s  %-32s  skipped: %s\n", r.Kind, r.Provider, r.Skipped)
+			continue
+		}
+
+		wer := "-"
+		if r.RefWords > 0 {
+			wer = fmt.Sprintf("%.1f%%", 100*float64(r.WordErrs)/float64(r.RefWords))
+		}
+		cost := "-"
+		if r.Kind == "LLM" {
+			cost = fmt.Sprintf("$%.4f", r.Cost)
+		}
+
+		fmt.Fprintf(w, "%-4s  %-32s  %5d  %6d  %8s  %8s  %8s  %6s  %9s\n",
+			r.Kind, r.Provider, r.Runs, r.Errors,
+			formatLatency(Percentile(r.Latencies, 50)),
+			formatLatency(Percentile(r.Latencies, 90)),
+			formatLatency(Percentile(r.Latencies, 99)),
+			wer, cost)
+	}
+}
+
+// formatLatency rounds a latency for the report
+func formatLatency(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,50 @@
+package bench
+
+import (
+	"strings"
+
+	"voice-assistant/internal/commands"
+)
+
+// WordErrors returns the word-level edit distance between a reference
+// transcript and a hypothesis, along with the number of reference words.
+// Both are normalized first so casing and punctuation don't count as errors.
+func WordErrors(reference, hypothesis string) (int, int) {
+	ref := strings.Fields(commands.Normalize(reference))
+	hyp := strings.Fields(commands.Normalize(hypothesis))
+
+	prev := make([]int, len(hyp)+1)
+	curr := make([]int, len(hyp)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ref); i++ {
+		curr[0] = i
+		for j := 1; j <= len(hyp); j++ {
+			cost := 1
+			if ref[i-1] == hyp[j-1] {
+				cost = 0
+			}
+			best := prev[j-1] + cost // substitution
+			if prev[j]+1 < best {
+				best = prev[j] + 1 // deletion
+			}
+			if curr[j-1]+1 < best {
+				best = curr[j-1] + 1 // insertion
+			}
+			curr[j] = best
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(hyp)], len(ref)
+}
+
+// WER returns the word error rate of a hypothesis against a reference
+func WER(reference, hypothesis string) float64 {
+	errors, words := WordErrors(reference, hypothesis)
+	if words == 0 {
+		return 0
+	}
+	return float64(errors) / float64(words)
+}
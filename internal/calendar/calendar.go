@@ -0,0 +1,122 @@
+// Package calendar reads and creates events in Google Calendar or Outlook
+// (Microsoft Graph).
+package calendar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"voice-assistant/internal/oauth"
+)
+
+// RequestTimeout bounds each calendar API request
+const RequestTimeout = 15 * time.Second
+
+// tokenCredential is the keychain entry holding the signed-in token
+const tokenCredential = "calendar_token"
+
+var httpClient = &http.Client{Timeout: RequestTimeout}
+
+// Event is a calendar event
+type Event struct {
+	Title    string
+	Start    time.Time
+	End      time.Time
+	Location string
+	AllDay   bool
+}
+
+// Provider is a calendar API
+type Provider interface {
+	Name() string
+	Flow() *oauth.DeviceFlow
+	Upcoming(ctx context.Context, accessToken string, count int) ([]Event, error)
+	Create(ctx context.Context, accessToken string, event Event) error
+}
+
+// Client calls a provider as the signed-in user
+type Client struct {
+	provider Provider
+	session  *oauth.Session
+}
+
+// NewClient creates a client and loads any saved sign-in
+func NewClient(provider Provider) *Client {
+	return &Client{
+		provider: provider,
+		session:  oauth.NewSession(provider.Flow(), tokenCredential),
+	}
+}
+
+// Name returns the provider's name
+func (c *Client) Name() string {
+	return c.provider.Name()
+}
+
+// Session returns the user's sign-in
+func (c *Client) Session() *oauth.Session {
+	return c.session
+}
+
+// Upcoming returns the next count events from now
+func (c *Client) Upcoming(ctx context.Context, count int) ([]Event, error) {
+	accessToken, err := c.session.AccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.provider.Upcoming(ctx, accessToken, count)
+}
+
+// Create adds an event to the user's primary calendar
+func (c *Client) Create(ctx context.Context, event Event) error {
+	accessToken, err := c.session.AccessToken(ctx)
+	if err != nil {
+		return err
+	}
+	return c.provider.Create(ctx, accessToken, event)
+}
+
+// callAPI sends an authorized JSON request and decodes the reply into v,
+// which may be nil
+func callAPI(ctx context.Context, method, url, accessToken string, body, v interface{}, headers map[string]string) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("calendar API returned %s: %s", resp.Status, bytes.TrimSpace(detail))
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
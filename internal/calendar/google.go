@@ -0,0 +1,95 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"voice-assistant/internal/oauth"
+)
+
+const googleEventsURL = "https://www.googleapis.com/calendar/v3/calendars/primary/events"
+
+// Google is the Google Calendar API
+type Google struct {
+	flow *oauth.DeviceFlow
+}
+
+// NewGoogle creates a Google Calendar provider for an OAuth client
+func NewGoogle(clientID, clientSecret string) *Google {
+	return &Google{flow: &oauth.DeviceFlow{
+		DeviceCodeURL: "https://oauth2.googleapis.com/device/code",
+		TokenURL:      "https://oauth2.googleapis.com/token",
+		ClientID:      clientID,
+		ClientSecret:  clientSecret,
+		Scopes:        []string{"https://www.googleapis.com/auth/calendar.events"},
+	}}
+}
+
+// Name identifies the provider
+func (g *Google) Name() string {
+	return "Google Calendar"
+}
+
+// Flow returns the sign-in flow
+func (g *Google) Flow() *oauth.DeviceFlow {
+	return g.flow
+}
+
+// googleTime is a timed or all-day event boundary
+type googleTime struct {
+	DateTime string `json:"dateTime,omitempty"`
+	Date     string `json:"date,omitempty"`
+}
+
+// googleEvent is an event as the API represents it
+type googleEvent struct {
+	Summary  string     `json:"summary"`
+	Location string     `json:"location,omitempty"`
+	Start    googleTime `json:"start"`
+	End      googleTime `json:"end"`
+}
+
+// Upcoming lists the next events on the primary calendar
+func (g *Google) Upcoming(ctx context.Context, accessToken string, count int) ([]Event, error) {
+	query := url.Values{}
+	query.Set("timeMin", time.Now().Format(time.RFC3339))
+	query.Set("maxResults", fmt.Sprint(count))
+	query.Set("singleEvents", "true")
+	query.Set("orderBy", "startTime")
+
+	var response struct {
+		Items []googleEvent `json:"items"`
+	}
+	err := callAPI(ctx, "GET", googleEventsURL+"?"+query.Encode(), accessToken, nil, &response, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(response.Items))
+	for _, item := range response.Items {
+		event := Event{Title: item.Summary, Location: item.Location}
+		if item.Start.DateTime != "" {
+			event.Start, _ = time.Parse(time.RFC3339, item.Start.DateTime)
+			event.End, _ = time.Parse(time.RFC3339, item.End.DateTime)
+		} else {
+			event.AllDay = true
+			event.Start, _ = time.ParseInLocation("2006-01-02", item.Start.Date, time.Local)
+			event.End, _ = time.ParseInLocation("2006-01-02", item.End.Date, time.Local)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Create adds a timed event to the primary calendar
+func (g *Google) Create(ctx context.Context, accessToken string, event Event) error {
+	body := googleEvent{
+		Summary:  event.Title,
+		Location: event.Location,
+		Start:    googleTime{DateTime: event.Start.Format(time.RFC3339)},
+		End:      googleTime{DateTime: event.End.Format(time.RFC3339)},
+	}
+	return callAPI(ctx, "POST", googleEventsURL, accessToken, body, nil, nil)
+}
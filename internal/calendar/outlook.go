@@ -0,0 +1,106 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"voice-assistant/internal/oauth"
+)
+
+const graphURL = "https://graph.microsoft.com/v1.0/me"
+
+// graphTimeLayout is how Graph writes date-times, without a zone
+const graphTimeLayout = "2006-01-02T15:04:05.9999999"
+
+// How far ahead Outlook's calendar view looks for upcoming events
+const outlookLookahead = 30 * 24 * time.Hour
+
+// Outlook is the Microsoft Graph calendar API
+type Outlook struct {
+	flow *oauth.DeviceFlow
+}
+
+// NewOutlook creates an Outlook provider for an app registration; tenant
+// is "common" for any account or a directory ID
+func NewOutlook(clientID, tenant string) *Outlook {
+	authority := "https://login.microsoftonline.com/" + tenant + "/oauth2/v2.0"
+	return &Outlook{flow: &oauth.DeviceFlow{
+		DeviceCodeURL: authority + "/devicecode",
+		TokenURL:      authority + "/token",
+		ClientID:      clientID,
+		Scopes:        []string{"offline_access", "Calendars.ReadWrite"},
+	}}
+}
+
+// Name identifies the provider
+func (o *Outlook) Name() string {
+	return "Outlook"
+}
+
+// Flow returns the sign-in flow
+func (o *Outlook) Flow() *oauth.DeviceFlow {
+	return o.flow
+}
+
+// graphTime is an event boundary; times are requested in UTC
+type graphTime struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+// graphEvent is an event as Graph represents it
+type graphEvent struct {
+	Subject  string    `json:"subject"`
+	Start    graphTime `json:"start"`
+	End      graphTime `json:"end"`
+	IsAllDay bool      `json:"isAllDay,omitempty"`
+	Location struct {
+		DisplayName string `json:"displayName,omitempty"`
+	} `json:"location"`
+}
+
+// Upcoming lists the next events in the user's calendar
+func (o *Outlook) Upcoming(ctx context.Context, accessToken string, count int) ([]Event, error) {
+	now := time.Now().UTC()
+	query := url.Values{}
+	query.Set("startDateTime", now.Format(time.RFC3339))
+	query.Set("endDateTime", now.Add(outlookLookahead).Format(time.RFC3339))
+	query.Set("$orderby", "start/dateTime")
+	query.Set("$top", fmt.Sprint(count))
+
+	var response struct {
+		Value []graphEvent `json:"value"`
+	}
+	headers := map[string]string{"Prefer": `outlook.timezone="UTC"`}
+	err := callAPI(ctx, "GET", graphURL+"/calendarView?"+query.Encode(), accessToken, nil, &response, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(response.Value))
+	for _, item := range response.Value {
+		start, _ := time.ParseInLocation(graphTimeLayout, item.Start.DateTime, time.UTC)
+		end, _ := time.ParseInLocation(graphTimeLayout, item.End.DateTime, time.UTC)
+		events = append(events, Event{
+			Title:    item.Subject,
+			Start:    start.Local(),
+			End:      end.Local(),
+			Location: item.Location.DisplayName,
+			AllDay:   item.IsAllDay,
+		})
+	}
+	return events, nil
+}
+
+// Create adds a timed event to the user's calendar
+func (o *Outlook) Create(ctx context.Context, accessToken string, event Event) error {
+	body := graphEvent{
+		Subject: event.Title,
+		Start:   graphTime{DateTime: event.Start.UTC().Format(graphTimeLayout), TimeZone: "UTC"},
+		End:     graphTime{DateTime: event.End.UTC().Format(graphTimeLayout), TimeZone: "UTC"},
+	}
+	body.Location.DisplayName = event.Location
+	return callAPI(ctx, "POST", graphURL+"/events", accessToken, body, nil, nil)
+}
@@ -2,238 +2,643 @@ package claude
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"voice-assistant/config"
+	"voice-assistant/internal/errs"
+	"voice-assistant/internal/keys"
+	"voice-assistant/internal/proxy"
 )
 
 // Claude API configuration
 type Config struct {
-	APIKey       string
-	Model        string
-	SystemPrompt string
+	APIKey        string
+	APIKeys       []string // All keys to rotate through; APIKey is used when empty
+	KeyRotation   string
+	Model         string
+	SystemPrompt  string
+	PromptCaching bool // Mark the system prompt as cacheable
+
+	MaxTokens        int      // Zero uses DefaultMaxTokens
+	Temperature      *float64 // Nil uses the API default
+	VoiceInstruction string   // Appended to the system prompt in voice mode
+
+	ConnectTimeout  time.Duration // Zero uses DefaultConnectTimeout
+	ResponseTimeout time.Duration // Longest the API may go quiet; zero uses DefaultResponseTimeout
+
+	MaxConcurrent     int // Turns sent at once; zero uses DefaultMaxConcurrent
+	RequestsPerMinute int // Zero for no limit
+
+	ThinkingBudget int // Tokens Claude may think for when thinking is on; zero uses DefaultThinkingBudget
 }
 
+// DefaultMaxTokens caps responses when no limit is configured
+const DefaultMaxTokens = 1000
+
+// Network defaults
+const (
+	DefaultConnectTimeout  = 10 * time.Second
+	DefaultResponseTimeout = 60 * time.Second
+	maxIdleConnections     = 4 // Kept alive between turns so follow-ups skip the TLS handshake
+)
+
 // Message represents a single message in the conversation
 type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role    string         `json:"role"`
+	Content []ContentBlock `json:"content"`
+}
+
+// ContentBlock is one piece of message content: text, an image, Claude's
+// thinking, a tool call made by Claude, or the result of running that tool
+type ContentBlock struct {
+	Type   string       `json:"type"`
+	Text   string       `json:"text,omitempty"`
+	Source *ImageSource `json:"source,omitempty"` // image
+
+	// thinking and redacted_thinking, sent back unchanged within a turn
+	Thinking  string `json:"thinking,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Data      string `json:"data,omitempty"`
+
+	// tool_use
+	ID    string          `json:"id,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Input json.RawMessage `json:"input,omitempty"`
+
+	// tool_result
+	ToolUseID string `json:"tool_use_id,omitempty"`
+	Content   string `json:"content,omitempty"`
+	IsError   bool   `json:"is_error,omitempty"`
+}
+
+// ImageSource is the inline data of an image block
+type ImageSource struct {
+	Type      string `json:"type"` // Always "base64"
+	MediaType string `json:"media_type"`
+	Data      string `json:"data"`
+}
+
+// Image is an image attached to a user message
+type Image struct {
+	MediaType string // e.g. "image/jpeg"
+	Data      []byte
+}
+
+// TextMessage creates a message holding a single text block
+func TextMessage(role, text string) Message {
+	return Message{
+		Role:    role,
+		Content: []ContentBlock{{Type: "text", Text: text}},
+	}
+}
+
+// Text returns the message's text blocks joined together
+func (m Message) Text() string {
+	return textOf(m.Content)
+}
+
+// textOf joins the text blocks of some content
+func textOf(blocks []ContentBlock) string {
+	var parts []string
+	for _, block := range blocks {
+		if block.Type == "text" && block.Text != "" {
+			parts = append(parts, block.Text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// CacheControl marks a content block as a prompt caching breakpoint
+type CacheControl struct {
+	Type string `json:"type"`
+}
+
+// SystemBlock is one text block of the structured system prompt
+type SystemBlock struct {
+	Type         string        `json:"type"`
+	Text         string        `json:"text"`
+	CacheControl *CacheControl `json:"cache_control,omitempty"`
 }
 
 // Request represents the Claude API request structure
 type Request struct {
-	Model     string    `json:"model"`
-	MaxTokens int       `json:"max_tokens"`
-	Messages  []Message `json:"messages"`
-	System    string    `json:"system,omitempty"`
+	Model       string        `json:"model"`
+	MaxTokens   int           `json:"max_tokens"`
+	Messages    []Message     `json:"messages"`
+	System      []SystemBlock `json:"system,omitempty"`
+	Temperature *float64      `json:"temperature,omitempty"`
+	Tools       []Tool        `json:"tools,omitempty"`
+	Thinking    *Thinking     `json:"thinking,omitempty"`
 }
 
 // Response represents the Claude API response structure
 type Response struct {
-	ID      string `json:"id"`
-	Type    string `json:"type"`
-	Role    string `json:"role"`
-	Content []struct {
-		Type string `json:"type"`
-		Text string `json:"text"`
-	} `json:"content"`
-	Model        string `json:"model"`
-	StopReason   string `json:"stop_reason"`
-	StopSequence string `json:"stop_sequence"`
-	Usage        struct {
-		InputTokens  int `json:"input_tokens"`
-		OutputTokens int `json:"output_tokens"`
-	} `json:"usage"`
+	ID           string         `json:"id"`
+	Type         string         `json:"type"`
+	Role         string         `json:"role"`
+	Content      []ContentBlock `json:"content"`
+	Model        string         `json:"model"`
+	StopReason   string         `json:"stop_reason"`
+	StopSequence string         `json:"stop_sequence"`
+	Usage        Usage          `json:"usage"`
+}
+
+// Usage reports the tokens consumed by a request
+type Usage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// RequestOptions overrides client defaults for a single request
+type RequestOptions struct {
+	Model     string  // Empty uses the configured model
+	MaxTokens int     // Zero uses the default
+	Images    []Image // Sent ahead of the message text
+	Context   string  // Extra system prompt text for this request only, e.g. the active window
+
+	OnThinking func(text string) // Given Claude's reasoning when extended thinking is on
+	OnText     func(text string) // Given the answer so far as it streams in, by providers that stream
 }
 
 // Client handles communication with Claude API
 type Client struct {
-	config          Config
-	httpClient      *http.Client
-	baseURL         string
-	conversationLog []Message // Store conversation history
+	config         Config
+	keyRing        *keys.KeyRing
+	httpClient     *http.Client
+	limiter        *limiter
+	baseURL        string
+	conversation   *ConversationManager
+	historyEnabled bool // Whether earlier turns are sent as context
+	voiceMode      bool // Whether the voice instruction is added to the system prompt
+	tools          *ToolRegistry
+	toolsEnabled   bool
+	thinking       bool // Whether Claude thinks before answering
+	onUsage        func(model string, usage Usage)
 }
 
 // NewClientFromConfig creates a new Claude API client from app config
 func NewClientFromConfig(cfg *config.Config) *Client {
-	return NewClient(Config{
-		APIKey:       cfg.Claude.APIKey,
-		Model:        cfg.Claude.Model,
-		SystemPrompt: cfg.Claude.SystemPrompt,
-	})
+	clientConfig := Config{
+		APIKey:        cfg.Claude.APIKey,
+		APIKeys:       cfg.Claude.Keys(),
+		KeyRotation:   cfg.Claude.KeyRotation,
+		Model:         cfg.Claude.Model,
+		SystemPrompt:  cfg.Claude.SystemPrompt,
+		PromptCaching: cfg.Claude.PromptCaching,
+		MaxTokens:     cfg.Claude.MaxTokens,
+		Temperature:   cfg.Claude.Temperature,
+
+		ConnectTimeout:  time.Duration(cfg.Claude.ConnectTimeoutSeconds) * time.Second,
+		ResponseTimeout: time.Duration(cfg.Claude.ResponseTimeoutSeconds) * time.Second,
+
+		MaxConcurrent:     cfg.Claude.MaxConcurrent,
+		RequestsPerMinute: cfg.Claude.RequestsPerMinute,
+
+		ThinkingBudget: cfg.Claude.ThinkingBudget,
+	}
+	if cfg.Claude.VoiceBrevity {
+		clientConfig.VoiceInstruction = cfg.Claude.BrevityInstruction
+	}
+	return NewClient(clientConfig)
 }
 
 // NewClient creates a new Claude API client
 func NewClient(config Config) *Client {
+	apiKeys := config.APIKeys
+	if len(apiKeys) == 0 {
+		apiKeys = []string{config.APIKey}
+	}
+	if config.ConnectTimeout <= 0 {
+		config.ConnectTimeout = DefaultConnectTimeout
+	}
+	if config.ResponseTimeout <= 0 {
+		config.ResponseTimeout = DefaultResponseTimeout
+	}
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = DefaultMaxConcurrent
+	}
+
+	// No overall timeout: the response timeout only trips when nothing
+	// arrives, so a long answer can take as long as it needs
+	transport := proxy.NewTransport()
+	transport.DialContext = (&net.Dialer{Timeout: config.ConnectTimeout, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = config.ConnectTimeout
+	transport.ResponseHeaderTimeout = config.ResponseTimeout
+	transport.MaxIdleConnsPerHost = maxIdleConnections
+
 	return &Client{
-		config: config,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL:         "https://api.anthropic.com/v1",
-		conversationLog: make([]Message, 0), // Initialize empty conversation
+		config:         config,
+		keyRing:        keys.NewKeyRing("Claude", apiKeys, config.KeyRotation),
+		httpClient:     &http.Client{Transport: transport},
+		limiter:        newLimiter(config.MaxConcurrent, config.RequestsPerMinute),
+		baseURL:        "https://api.anthropic.com/v1",
+		conversation:   NewConversationManager(),
+		historyEnabled: true,
 	}
 }
 
 // SendMessage sends a message to Claude and returns the response
-func (c *Client) SendMessage(userMessage string) (string, error) {
+func (c *Client) SendMessage(ctx context.Context, userMessage string) (string, error) {
+	return c.SendMessageWithOptions(ctx, userMessage, RequestOptions{})
+}
+
+// SendMessageWithOptions sends a message using per-request overrides.
+// When Claude asks to use tools they are run and their results sent back
+// until Claude produces a final answer. Cancelling ctx abandons the turn.
+func (c *Client) SendMessageWithOptions(ctx context.Context, userMessage string, options RequestOptions) (string, error) {
 	log.Printf("Sending message to Claude: %s", userMessage)
 
-	// Add user message to conversation log
-	userMsg := Message{
-		Role:    "user",
-		Content: userMessage,
+	// Queue behind earlier turns, then read the history they left
+	order := c.limiter.nextTurn()
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		order.abandon()
+		return "", err
 	}
-	c.conversationLog = append(c.conversationLog, userMsg)
+	defer release()
+	committed := false
+	defer func() {
+		if !committed {
+			order.abandon()
+		}
+	}()
+
+	// Without memory every message starts a fresh conversation
+	turn := c.conversation.NewConversation()
+	if c.historyEnabled {
+		turn = c.conversation.Messages()
+	}
+	start := len(turn)
+
+	// Add user message to the turn, images first as recommended
+	userMsg := TextMessage("user", userMessage)
+	if len(options.Images) > 0 {
+		userMsg.Content = append(imageBlocks(options.Images), userMsg.Content...)
+	}
+	turn = append(turn, userMsg)
 
 	// Prepare the request payload with full conversation history
 	request := Request{
-		Model:     c.config.Model,
-		MaxTokens: 1000,
-		Messages:  c.conversationLog,
+		Model:       c.config.Model,
+		MaxTokens:   c.maxTokens(),
+		Temperature: c.config.Temperature,
+		Tools:       c.toolDefinitions(),
+	}
+	if options.Model != "" {
+		request.Model = options.Model
+	}
+	if options.MaxTokens > 0 {
+		request.MaxTokens = options.MaxTokens
+	}
+	c.applyThinking(&request)
+
+	// Always include the system prompt so later turns behave like the first.
+	// Per-request context goes after the cache breakpoint so it doesn't
+	// invalidate the cached prefix.
+	request.System = c.systemBlocks()
+	if options.Context != "" {
+		request.System = append(request.System, SystemBlock{Type: "text", Text: options.Context})
 	}
 
-	// Only include system prompt if this is the first message
-	if len(c.conversationLog) == 1 {
-		request.System = c.config.SystemPrompt
-		log.Printf("Including system prompt (first message)")
+	var thinking []string
+	for round := 0; ; round++ {
+		request.Messages = turn
+		claudeResponse, err := c.send(ctx, request)
+		if err != nil {
+			return "", err
+		}
+		if text := thinkingOf(claudeResponse.Content); text != "" {
+			thinking = append(thinking, text)
+		}
+
+		// Add Claude's response to the turn
+		turn = append(turn, Message{
+			Role:    "assistant",
+			Content: claudeResponse.Content,
+		})
+
+		if claudeResponse.StopReason != "tool_use" {
+			responseText := textOf(claudeResponse.Content)
+			if responseText == "" {
+				return "", fmt.Errorf("no content in Claude response")
+			}
+			order.commit(func() { c.commitTurn(turn[start:]) })
+			committed = true
+			if options.OnThinking != nil && len(thinking) > 0 {
+				options.OnThinking(strings.Join(thinking, "\n\n"))
+			}
+			return responseText, nil
+		}
+
+		if round >= MaxToolRounds {
+			return "", fmt.Errorf("Claude requested tools more than %d times in one turn", MaxToolRounds)
+		}
+		turn = append(turn, Message{
+			Role:    "user",
+			Content: c.runTools(claudeResponse.Content),
+		})
 	}
+}
 
-	// Marshal request to JSON
-	requestBody, err := json.Marshal(request)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %v", err)
+// commitTurn records a completed turn in the live history. Without memory
+// only the latest turn is kept, for handing the conversation off.
+func (c *Client) commitTurn(messages []Message) {
+	messages[0] = withoutImages(messages[0])
+	messages = withoutThinking(messages)
+	if !c.historyEnabled {
+		c.conversation.Reset()
 	}
+	c.conversation.Append(messages...)
+}
+
+// imageBlocks converts attached images to base64 content blocks
+func imageBlocks(images []Image) []ContentBlock {
+	blocks := make([]ContentBlock, len(images))
+	for i, image := range images {
+		blocks[i] = ContentBlock{
+			Type: "image",
+			Source: &ImageSource{
+				Type:      "base64",
+				MediaType: image.MediaType,
+				Data:      base64.StdEncoding.EncodeToString(image.Data),
+			},
+		}
+	}
+	return blocks
+}
 
-	// Create HTTP request
-	url := fmt.Sprintf("%s/messages", c.baseURL)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
+// SendConversation sends a multi-turn conversation to Claude
+func (c *Client) SendConversation(ctx context.Context, messages []Message) (string, error) {
+	log.Printf("Sending conversation with %d messages to Claude", len(messages))
+
+	release, err := c.limiter.acquire(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %v", err)
+		return "", err
 	}
+	defer release()
 
-	// Set required headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-api-key", c.config.APIKey)
-	req.Header.Set("anthropic-version", "2023-06-01")
+	// Prepare the request payload
+	request := Request{
+		Model:       c.config.Model,
+		MaxTokens:   c.maxTokens(),
+		System:      c.systemBlocks(),
+		Messages:    messages,
+		Temperature: c.config.Temperature,
+	}
 
-	// Execute request
-	log.Printf("Sending request to Claude API...")
-	resp, err := c.httpClient.Do(req)
+	claudeResponse, err := c.send(ctx, request)
 	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %v", err)
+		return "", err
 	}
-	defer resp.Body.Close()
 
-	// Read response body
-	responseBody, err := io.ReadAll(resp.Body)
+	// Extract text content from response
+	responseText := textOf(claudeResponse.Content)
+	if responseText == "" {
+		return "", fmt.Errorf("no content in Claude response")
+	}
+	log.Printf("Claude conversation response: %s", responseText)
+
+	return responseText, nil
+}
+
+// send executes one Messages API request and parses the response
+func (c *Client) send(ctx context.Context, request Request) (*Response, error) {
+	// Marshal request to JSON
+	requestBody, err := json.Marshal(request)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %v", err)
+		return nil, fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	// Check for HTTP errors
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Claude API error: %s - %s", resp.Status, string(responseBody))
+	// Execute request
+	log.Printf("Sending request to Claude API...")
+	responseBody, err := c.do(ctx, "POST", "/messages", requestBody)
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse response
 	var claudeResponse Response
 	err = json.Unmarshal(responseBody, &claudeResponse)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse response: %v", err)
+		return nil, fmt.Errorf("failed to parse response: %v", err)
 	}
+	c.reportUsage(claudeResponse)
 
-	// Extract text content from response
-	if len(claudeResponse.Content) == 0 {
-		return "", fmt.Errorf("no content in Claude response")
+	if claudeResponse.Usage.CacheReadInputTokens > 0 || claudeResponse.Usage.CacheCreationInputTokens > 0 {
+		log.Printf("Prompt cache: %d tokens read, %d tokens written",
+			claudeResponse.Usage.CacheReadInputTokens, claudeResponse.Usage.CacheCreationInputTokens)
 	}
+	return &claudeResponse, nil
+}
 
-	responseText := claudeResponse.Content[0].Text
-
-	// Add Claude's response to conversation log
-	assistantMsg := Message{
-		Role:    "assistant",
-		Content: responseText,
+// SetHistoryEnabled controls whether previous turns are kept as context
+func (c *Client) SetHistoryEnabled(enabled bool) {
+	c.historyEnabled = enabled
+	if !enabled {
+		c.conversation.Reset()
 	}
-	c.conversationLog = append(c.conversationLog, assistantMsg)
-
-	return responseText, nil
 }
 
-// SendConversation sends a multi-turn conversation to Claude
-func (c *Client) SendConversation(messages []Message) (string, error) {
-	log.Printf("Sending conversation with %d messages to Claude", len(messages))
-
-	// Prepare the request payload
-	request := Request{
-		Model:     c.config.Model,
-		MaxTokens: 1000,
-		System:    c.config.SystemPrompt,
-		Messages:  messages,
-	}
+// do executes an API request, rotating to the next key when one is rate
+// limited, and returns the body of a successful response
+func (c *Client) do(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	url := c.baseURL + path
 
-	// Marshal request to JSON
-	requestBody, err := json.Marshal(request)
+	err := c.limiter.wait(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %v", err)
+		return nil, err
 	}
 
-	// Create HTTP request
-	url := fmt.Sprintf("%s/messages", c.baseURL)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %v", err)
+	var lastErr error
+	for attempt := 0; attempt < c.keyRing.Len(); attempt++ {
+		apiKey := c.keyRing.Next()
+
+		// Create HTTP request
+		attemptCtx, cancel := context.WithCancel(ctx)
+		req, err := http.NewRequestWithContext(attemptCtx, method, url, bytes.NewReader(body))
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+		c.setHeaders(req, apiKey)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			cancel()
+			// A cancelled request says nothing about the key
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			c.keyRing.ReportFailure(apiKey)
+			return nil, errs.FromTransport("Claude", fmt.Errorf("failed to execute request: %w", err))
+		}
+
+		// Read response body, giving up only if it stops arriving
+		responseBody, err := readWithTimeout(resp.Body, c.config.ResponseTimeout, cancel)
+		resp.Body.Close()
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if attemptCtx.Err() != nil {
+				err = fmt.Errorf("Claude stopped responding for %v", c.config.ResponseTimeout)
+				return nil, errs.New(errs.ErrUnavailable, "Claude", err)
+			}
+			return nil, fmt.Errorf("failed to read response: %v", err)
+		}
+
+		// Check for HTTP errors
+		if resp.StatusCode == http.StatusOK {
+			return responseBody, nil
+		}
+
+		lastErr = fmt.Errorf("Claude API error: %s - %s", resp.Status, string(responseBody))
+		if resp.StatusCode == http.StatusBadRequest && strings.Contains(string(responseBody), "credit balance") {
+			lastErr = errs.New(errs.ErrQuota, "Claude", lastErr)
+		} else {
+			lastErr = errs.FromStatus("Claude", resp.StatusCode, lastErr)
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			c.keyRing.ReportFailure(apiKey)
+			return nil, lastErr
+		}
+		c.keyRing.ReportRateLimited(apiKey)
 	}
 
-	// Set required headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-api-key", c.config.APIKey)
-	req.Header.Set("anthropic-version", "2023-06-01")
+	return nil, lastErr
+}
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %v", err)
+// idleReader restarts a timer on every read, so it only fires when the
+// data stops
+type idleReader struct {
+	io.Reader
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+func (r *idleReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.timer.Reset(r.timeout)
+	return n, err
+}
+
+// readWithTimeout reads body to the end, calling cancel if nothing arrives
+// for timeout
+func readWithTimeout(body io.Reader, timeout time.Duration, cancel func()) ([]byte, error) {
+	timer := time.AfterFunc(timeout, cancel)
+	defer timer.Stop()
+	return io.ReadAll(&idleReader{Reader: body, timer: timer, timeout: timeout})
+}
+
+// systemBlocks builds the structured system prompt, marked for caching
+// when enabled so repeated turns reuse the cached prefix
+func (c *Client) systemBlocks() []SystemBlock {
+	var blocks []SystemBlock
+	if c.config.SystemPrompt != "" {
+		blocks = append(blocks, SystemBlock{
+			Type: "text",
+			Text: c.config.SystemPrompt,
+		})
+	}
+	if c.voiceMode && c.config.VoiceInstruction != "" {
+		blocks = append(blocks, SystemBlock{
+			Type: "text",
+			Text: c.config.VoiceInstruction,
+		})
 	}
-	defer resp.Body.Close()
 
-	// Read response body
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response: %v", err)
+	// The breakpoint goes on the last block so the whole prompt is cached
+	if c.config.PromptCaching && len(blocks) > 0 {
+		blocks[len(blocks)-1].CacheControl = &CacheControl{Type: "ephemeral"}
 	}
+	return blocks
+}
 
-	// Check for HTTP errors
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Claude API error: %s - %s", resp.Status, string(responseBody))
+// maxTokens returns the configured response limit
+func (c *Client) maxTokens() int {
+	if c.config.MaxTokens > 0 {
+		return c.config.MaxTokens
 	}
+	return DefaultMaxTokens
+}
 
-	// Parse response
-	var claudeResponse Response
-	err = json.Unmarshal(responseBody, &claudeResponse)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse response: %v", err)
+// SetVoiceMode controls whether answers are requested in a short spoken
+// style, used while responses are read aloud
+func (c *Client) SetVoiceMode(enabled bool) {
+	c.voiceMode = enabled
+}
+
+// SetUsageCallback sets a callback invoked with the token usage of every response
+func (c *Client) SetUsageCallback(onUsage func(model string, usage Usage)) {
+	c.onUsage = onUsage
+}
+
+// reportUsage passes a response's token usage to the usage callback
+func (c *Client) reportUsage(response Response) {
+	if c.onUsage != nil {
+		c.onUsage(response.Model, response.Usage)
 	}
+}
 
-	// Extract text content from response
-	if len(claudeResponse.Content) == 0 {
-		return "", fmt.Errorf("no content in Claude response")
+// SetModel switches the model used for subsequent requests
+func (c *Client) SetModel(model string) {
+	c.config.Model = model
+}
+
+// SetAPIKeys replaces the keys requests are signed with
+func (c *Client) SetAPIKeys(apiKeys []string, rotation string) {
+	if len(apiKeys) == 0 {
+		return
 	}
+	c.config.APIKey = apiKeys[0]
+	c.config.APIKeys = apiKeys
+	c.config.KeyRotation = rotation
+	c.keyRing = keys.NewKeyRing("Claude", apiKeys, rotation)
+}
 
-	responseText := claudeResponse.Content[0].Text
-	log.Printf("Claude conversation response: %s", responseText)
+// Model returns the model used for requests
+func (c *Client) Model() string {
+	return c.config.Model
+}
 
-	return responseText, nil
+// SetSystemPrompt replaces the system prompt used for new conversations
+func (c *Client) SetSystemPrompt(prompt string) {
+	c.config.SystemPrompt = prompt
+}
+
+// ResetConversation clears the conversation history
+func (c *Client) ResetConversation() {
+	log.Printf("Starting a new conversation")
+	c.conversation.Reset()
+}
+
+// setHeaders sets the headers required by every Claude API request
+func (c *Client) setHeaders(req *http.Request, apiKey string) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+}
+
+// KeyUsage returns a summary of per-key usage for display
+func (c *Client) KeyUsage() string {
+	return c.keyRing.Summary()
 }
 
 // ValidateConfig checks if the Claude configuration is valid
 func (c *Client) ValidateConfig() error {
-	if c.config.APIKey == "" {
+	if c.keyRing.Len() == 0 || c.config.APIKey == "" && len(c.config.APIKeys) == 0 {
 		return fmt.Errorf("Claude API key is required")
 	}
 	if c.config.Model == "" {
@@ -242,16 +647,17 @@ func (c *Client) ValidateConfig() error {
 	return nil
 }
 
-// TestConnection tests the connection to Claude API
-func (c *Client) TestConnection() error {
+// TestConnection checks the API key is accepted. It lists a single model
+// instead of sending a message, so it costs no tokens and leaves the
+// conversation untouched.
+func (c *Client) TestConnection(ctx context.Context) error {
 	log.Println("Testing Claude API connection...")
 
-	// Send a simple test message
-	response, err := c.SendMessage("Hello! Can you respond with just 'API connection successful'?")
+	_, err := c.do(ctx, "GET", "/models?limit=1", nil)
 	if err != nil {
 		return fmt.Errorf("Claude API test failed: %v", err)
 	}
 
-	log.Printf("Claude API test successful. Response: %s", response)
+	log.Printf("Claude API test successful")
 	return nil
 }
@@ -0,0 +1,84 @@
+package claude
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ConversationManager owns the live conversation history. Turns are built
+// on a copy and only appended once they complete, so failed requests,
+// connection tests and one-off conversations never leave messages behind.
+type ConversationManager struct {
+	messages []Message
+	mutex    sync.Mutex
+}
+
+// NewConversationManager creates a manager with an empty conversation
+func NewConversationManager() *ConversationManager {
+	return &ConversationManager{}
+}
+
+// NewConversation returns an empty conversation that is independent of the
+// live history, for one-off requests and branches
+func (m *ConversationManager) NewConversation() []Message {
+	return make([]Message, 0)
+}
+
+// Messages returns a copy of the live history
+func (m *ConversationManager) Messages() []Message {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	messages := make([]Message, len(m.messages))
+	copy(messages, m.messages)
+	return messages
+}
+
+// Len returns the number of messages in the live history
+func (m *ConversationManager) Len() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return len(m.messages)
+}
+
+// Append adds a completed turn to the live history
+func (m *ConversationManager) Append(messages ...Message) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.messages = append(m.messages, messages...)
+}
+
+// Replace swaps the live history for another conversation
+func (m *ConversationManager) Replace(messages []Message) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.messages = append([]Message(nil), messages...)
+}
+
+// Reset clears the live history
+func (m *ConversationManager) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.messages = nil
+}
+
+// withoutImages replaces the images of a message with a short note, so they
+// aren't uploaded again every turn once the turn is over
+func withoutImages(message Message) Message {
+	var content []ContentBlock
+	images := 0
+	for _, block := range message.Content {
+		if block.Type == "image" {
+			images++
+			continue
+		}
+		content = append(content, block)
+	}
+	if images == 0 {
+		return message
+	}
+
+	note := ContentBlock{Type: "text", Text: fmt.Sprintf("[%d image(s) shared earlier]", images)}
+	message.Content = append([]ContentBlock{note}, content...)
+	return message
+}
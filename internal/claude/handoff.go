@@ -0,0 +1,40 @@
+package claude
+
+import (
+	"fmt"
+	"strings"
+)
+
+// History returns a copy of the current conversation
+func (c *Client) History() []Message {
+	return c.conversation.Messages()
+}
+
+// SetHistory continues a saved conversation in place of the current one
+func (c *Client) SetHistory(messages []Message) {
+	c.conversation.Replace(messages)
+}
+
+// HandoffPrompt turns a conversation into a single prompt that another chat
+// interface can pick up from, so a voice session can continue at the keyboard
+func HandoffPrompt(messages []Message) string {
+	var b strings.Builder
+	b.WriteString("I've been talking with a voice assistant and want to continue the conversation here. ")
+	b.WriteString("Here is the transcript so far:\n\n")
+
+	for _, message := range messages {
+		text := message.Text()
+		if text == "" {
+			continue // Tool calls and results only
+		}
+
+		speaker := "Me"
+		if message.Role == "assistant" {
+			speaker = "Assistant"
+		}
+		fmt.Fprintf(&b, "%s: %s\n\n", speaker, text)
+	}
+
+	b.WriteString("Please pick up where the assistant left off.")
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package claude
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultMaxConcurrent runs turns one after another so each sees the last
+// answer
+const DefaultMaxConcurrent = 1
+
+// limiter keeps turns from piling onto the API: a fixed number of turns
+// run at once, requests are spread to stay under a per-minute limit, and
+// turns reach the history in the order they started
+type limiter struct {
+	slots     chan struct{}
+	perMinute int         // 0 for no limit
+	sent      []time.Time // Requests made in the last minute
+	lastTurn  chan struct{}
+	mutex     sync.Mutex
+}
+
+// newLimiter creates a limiter; concurrency below 1 is treated as 1
+func newLimiter(concurrency, perMinute int) *limiter {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	lastTurn := make(chan struct{})
+	close(lastTurn)
+	return &limiter{
+		slots:     make(chan struct{}, concurrency),
+		perMinute: perMinute,
+		lastTurn:  lastTurn,
+	}
+}
+
+// acquire waits for a free slot. Call release once the turn is over.
+func (l *limiter) acquire(ctx context.Context) (release func(), err error) {
+	select {
+	case l.slots <- struct{}{}:
+	default:
+		log.Printf("⏳ Waiting for an earlier Claude request to finish")
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return func() { <-l.slots }, nil
+}
+
+// wait delays a request until it fits under the per-minute limit
+func (l *limiter) wait(ctx context.Context) error {
+	for {
+		l.mutex.Lock()
+		now := time.Now()
+		for len(l.sent) > 0 && now.Sub(l.sent[0]) >= time.Minute {
+			l.sent = l.sent[1:]
+		}
+		if l.perMinute <= 0 || len(l.sent) < l.perMinute {
+			l.sent = append(l.sent, now)
+			l.mutex.Unlock()
+			return nil
+		}
+		delay := l.sent[0].Add(time.Minute).Sub(now)
+		l.mutex.Unlock()
+
+		log.Printf("⏳ Waiting %v to stay under %d Claude requests a minute", delay.Round(time.Second), l.perMinute)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// turnOrder is a turn's place in line for updating the history
+type turnOrder struct {
+	previous <-chan struct{} // Closed once the turn before has finished
+	finished chan struct{}
+}
+
+// nextTurn takes the next place in line
+func (l *limiter) nextTurn() *turnOrder {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	order := &turnOrder{previous: l.lastTurn, finished: make(chan struct{})}
+	l.lastTurn = order.finished
+	return order
+}
+
+// commit runs update once every earlier turn has finished, then lets the
+// next one go
+func (o *turnOrder) commit(update func()) {
+	<-o.previous
+	update()
+	close(o.finished)
+}
+
+// abandon gives up the turn's place without updating the history
+func (o *turnOrder) abandon() {
+	go o.commit(func() {})
+}
@@ -0,0 +1,163 @@
+package claude
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// ModelInfo describes a model available through the Claude API
+type ModelInfo struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// modelsResponse represents the Claude models list response
+type modelsResponse struct {
+	Data    []ModelInfo `json:"data"`
+	HasMore bool        `json:"has_more"`
+	LastID  string      `json:"last_id"`
+}
+
+// ModelNotFoundError is returned when the configured model no longer exists
+type ModelNotFoundError struct {
+	Model      string
+	Suggestion string
+}
+
+func (e *ModelNotFoundError) Error() string {
+	if e.Suggestion == "" {
+		return fmt.Sprintf("Claude model %q is not available", e.Model)
+	}
+	return fmt.Sprintf("Claude model %q is not available - did you mean %q?", e.Model, e.Suggestion)
+}
+
+// ListModels returns all models available to the configured API key
+func (c *Client) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	var models []ModelInfo
+	afterID := ""
+
+	for {
+		path := "/models?limit=1000"
+		if afterID != "" {
+			path += "&after_id=" + afterID
+		}
+
+		responseBody, err := c.do(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page modelsResponse
+		err = json.Unmarshal(responseBody, &page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse models response: %v", err)
+		}
+
+		models = append(models, page.Data...)
+		if !page.HasMore || page.LastID == "" {
+			return models, nil
+		}
+		afterID = page.LastID
+	}
+}
+
+// ValidateModel checks the configured model against the models endpoint and
+// returns a *ModelNotFoundError with the closest current model if it is gone
+func (c *Client) ValidateModel(ctx context.Context) error {
+	log.Printf("Checking Claude model '%s'...", c.config.Model)
+
+	models, err := c.ListModels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list models: %v", err)
+	}
+
+	ids := make([]string, 0, len(models))
+	for _, model := range models {
+		if model.ID == c.config.Model {
+			return nil
+		}
+		ids = append(ids, model.ID)
+	}
+
+	return &ModelNotFoundError{
+		Model:      c.config.Model,
+		Suggestion: nearestModel(c.config.Model, ids),
+	}
+}
+
+// nearestModel picks the candidate with the smallest edit distance to the
+// (date-stripped) model name, preferring the same family (opus/sonnet/haiku)
+func nearestModel(model string, candidates []string) string {
+	family := modelFamily(model)
+	base := stripDateSuffix(model)
+
+	best := ""
+	bestScore := -1
+	for _, candidate := range candidates {
+		score := editDistance(base, stripDateSuffix(candidate))
+		if family != "" && modelFamily(candidate) != family {
+			score += 100 // Only cross families when nothing else is close
+		}
+		if bestScore < 0 || score < bestScore || (score == bestScore && candidate > best) {
+			best = candidate
+			bestScore = score
+		}
+	}
+	return best
+}
+
+// modelFamily returns the tier name contained in a model ID
+func modelFamily(model string) string {
+	for _, family := range []string{"opus", "sonnet", "haiku"} {
+		if strings.Contains(model, family) {
+			return family
+		}
+	}
+	return ""
+}
+
+// stripDateSuffix removes a trailing -YYYYMMDD snapshot date
+func stripDateSuffix(model string) string {
+	i := strings.LastIndex(model, "-")
+	if i < 0 || len(model)-i-1 != 8 {
+		return model
+	}
+	for _, r := range model[i+1:] {
+		if r < '0' || r > '9' {
+			return model
+		}
+	}
+	return model[:i]
+}
+
+// editDistance computes the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j] + 1
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+			if previous[j-1]+cost < current[j] {
+				current[j] = previous[j-1] + cost
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
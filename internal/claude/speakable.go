@@ -0,0 +1,87 @@
+package claude
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CodeBlock is a fenced code block lifted out of a response
+type CodeBlock struct {
+	Language string
+	Code     string
+}
+
+var (
+	fencePattern      = regexp.MustCompile("(?s)```([\\w+#.-]*)[^\\n]*\\n(.*?)```")
+	inlineCodePattern = regexp.MustCompile("`([^`]+)`")
+	imagePattern      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkPattern       = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	boldPattern       = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	italicPattern     = regexp.MustCompile(`\*(\S[^*]*?)\*|\b_(\S[^_]*?)_\b`)
+	headingPattern    = regexp.MustCompile(`^#{1,6}\s+`)
+	bulletPattern     = regexp.MustCompile(`^\s*[-*+]\s+`)
+	quotePattern      = regexp.MustCompile(`^\s*>\s?`)
+	rulePattern       = regexp.MustCompile(`^\s*([-*_]\s*){3,}$`)
+)
+
+// ExtractCodeBlocks returns the fenced code blocks in a response
+func ExtractCodeBlocks(text string) []CodeBlock {
+	var blocks []CodeBlock
+	for _, match := range fencePattern.FindAllStringSubmatch(text, -1) {
+		blocks = append(blocks, CodeBlock{
+			Language: match[1],
+			Code:     strings.TrimRight(match[2], "\n"),
+		})
+	}
+	return blocks
+}
+
+// Speakable converts a markdown response into plain text that reads well
+// aloud: code blocks are dropped, formatting markers removed and list items
+// and headings turned into separate sentences.
+func Speakable(text string) string {
+	text = fencePattern.ReplaceAllString(text, "\n")
+	text = imagePattern.ReplaceAllString(text, "$1")
+	text = linkPattern.ReplaceAllString(text, "$1")
+	text = inlineCodePattern.ReplaceAllString(text, "$1")
+	text = boldPattern.ReplaceAllString(text, "$1$2")
+	text = italicPattern.ReplaceAllString(text, "$1$2")
+
+	var sentences []string
+	for _, line := range strings.Split(text, "\n") {
+		if rulePattern.MatchString(line) {
+			continue
+		}
+
+		structural := headingPattern.MatchString(line) || bulletPattern.MatchString(line)
+		line = headingPattern.ReplaceAllString(line, "")
+		line = bulletPattern.ReplaceAllString(line, "")
+		line = quotePattern.ReplaceAllString(line, "")
+
+		// Table rows read as comma separated cells
+		if strings.HasPrefix(strings.TrimSpace(line), "|") {
+			if strings.Trim(line, "|-: ") == "" {
+				continue // Header separator row
+			}
+			cells := strings.Split(strings.Trim(strings.TrimSpace(line), "|"), "|")
+			for i := range cells {
+				cells[i] = strings.TrimSpace(cells[i])
+			}
+			line = strings.Join(cells, ", ")
+			structural = true
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Give headings and list items a pause when read aloud
+		if structural && !strings.ContainsAny(line[len(line)-1:], ".!?:;") {
+			line += "."
+		}
+		sentences = append(sentences, line)
+	}
+
+	return strings.Join(sentences, " ")
+}
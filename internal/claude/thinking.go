@@ -0,0 +1,78 @@
+package claude
+
+import "strings"
+
+// Extended thinking limits
+const (
+	DefaultThinkingBudget = 4000
+	MinThinkingBudget     = 1024 // The smallest budget the API accepts
+)
+
+// Thinking asks Claude to reason before answering, spending up to
+// BudgetTokens on it
+type Thinking struct {
+	Type         string `json:"type"` // Always "enabled"
+	BudgetTokens int    `json:"budget_tokens"`
+}
+
+// SetThinking controls whether Claude thinks before answering. Answers
+// take longer but hard questions come out better.
+func (c *Client) SetThinking(enabled bool) {
+	c.thinking = enabled
+}
+
+// applyThinking turns on extended thinking for a request when enabled and
+// its model supports it; a turn degraded to a faster model answers without
+func (c *Client) applyThinking(request *Request) {
+	if !c.thinking || !supportsThinking(request.Model) {
+		return
+	}
+
+	budget := c.config.ThinkingBudget
+	if budget <= 0 {
+		budget = DefaultThinkingBudget
+	}
+	if budget < MinThinkingBudget {
+		budget = MinThinkingBudget
+	}
+	request.Thinking = &Thinking{Type: "enabled", BudgetTokens: budget}
+
+	// Thinking counts towards max_tokens, so leave the usual room for the
+	// answer on top; a custom temperature isn't allowed alongside it
+	if request.MaxTokens <= budget {
+		request.MaxTokens += budget
+	}
+	request.Temperature = nil
+}
+
+// supportsThinking reports whether a model accepts extended thinking; it
+// arrived with Claude 3.7, and the API rejects it for older models
+func supportsThinking(model string) bool {
+	return !strings.HasPrefix(model, "claude-3-") || strings.HasPrefix(model, "claude-3-7-")
+}
+
+// thinkingOf joins the reasoning in a response, which is never spoken
+func thinkingOf(blocks []ContentBlock) string {
+	var parts []string
+	for _, block := range blocks {
+		if block.Type == "thinking" && block.Thinking != "" {
+			parts = append(parts, block.Thinking)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// withoutThinking drops thinking blocks from a finished turn. The API
+// ignores them in earlier turns, so they would only bloat saved history.
+func withoutThinking(messages []Message) []Message {
+	for i, message := range messages {
+		var content []ContentBlock
+		for _, block := range message.Content {
+			if block.Type != "thinking" && block.Type != "redacted_thinking" {
+				content = append(content, block)
+			}
+		}
+		messages[i].Content = content
+	}
+	return messages
+}
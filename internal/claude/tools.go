@@ -0,0 +1,130 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// MaxToolRounds limits how many tool calls Claude can chain in one turn
+const MaxToolRounds = 5
+
+// Tool is a tool definition as sent to the Claude API
+type Tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+// ToolHandler runs a tool with Claude's JSON input and returns its result
+type ToolHandler func(input json.RawMessage) (string, error)
+
+// ToolRegistry holds the tools the app offers to Claude
+type ToolRegistry struct {
+	tools    []Tool
+	handlers map[string]ToolHandler
+	mutex    sync.Mutex
+}
+
+// NewToolRegistry creates an empty tool registry
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{
+		handlers: make(map[string]ToolHandler),
+	}
+}
+
+// Register adds a tool, replacing any tool with the same name
+func (r *ToolRegistry) Register(tool Tool, handler ToolHandler) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if tool.InputSchema == nil {
+		tool.InputSchema = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	}
+	if _, exists := r.handlers[tool.Name]; exists {
+		for i := range r.tools {
+			if r.tools[i].Name == tool.Name {
+				r.tools[i] = tool
+			}
+		}
+	} else {
+		r.tools = append(r.tools, tool)
+	}
+	r.handlers[tool.Name] = handler
+}
+
+// Definitions returns every registered tool definition
+func (r *ToolRegistry) Definitions() []Tool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tools := make([]Tool, len(r.tools))
+	copy(tools, r.tools)
+	return tools
+}
+
+// Call runs a registered tool
+func (r *ToolRegistry) Call(name string, input json.RawMessage) (string, error) {
+	r.mutex.Lock()
+	handler, ok := r.handlers[name]
+	r.mutex.Unlock()
+
+	if !ok {
+		return "", fmt.Errorf("unknown tool %q", name)
+	}
+	return handler(input)
+}
+
+// SetToolRegistry sets the tools offered to Claude
+func (c *Client) SetToolRegistry(registry *ToolRegistry) {
+	c.tools = registry
+	c.toolsEnabled = true
+}
+
+// SetToolsEnabled controls whether tools are offered to Claude
+func (c *Client) SetToolsEnabled(enabled bool) {
+	c.toolsEnabled = enabled
+}
+
+// toolDefinitions returns the tools to include in a request
+func (c *Client) toolDefinitions() []Tool {
+	if !c.toolsEnabled || c.tools == nil {
+		return nil
+	}
+	return c.tools.Definitions()
+}
+
+// runTools executes the tool calls in a response and returns the
+// tool_result blocks to send back
+func (c *Client) runTools(content []ContentBlock) []ContentBlock {
+	var results []ContentBlock
+	for _, block := range content {
+		if block.Type != "tool_use" {
+			continue
+		}
+
+		log.Printf("🔧 Claude called tool %s with %s", block.Name, string(block.Input))
+		result := ContentBlock{
+			Type:      "tool_result",
+			ToolUseID: block.ID,
+		}
+
+		var output string
+		var err error
+		if c.tools == nil {
+			err = fmt.Errorf("tools are not available")
+		} else {
+			output, err = c.tools.Call(block.Name, block.Input)
+		}
+		if err != nil {
+			log.Printf("❌ Tool %s failed: %v", block.Name, err)
+			result.Content = err.Error()
+			result.IsError = true
+		} else {
+			result.Content = output
+		}
+		results = append(results, result)
+	}
+	return results
+}
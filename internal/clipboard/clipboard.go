@@ -0,0 +1,9 @@
+// Package clipboard reads and writes plain text on the system clipboard,
+// and reads images from it. Windows uses the Win32 clipboard API directly;
+// macOS and Linux shell out to the platform's clipboard tools.
+package clipboard
+
+import "errors"
+
+// ErrNoImage is returned by ReadImage when the clipboard holds no image
+var ErrNoImage = errors.New("clipboard has no image")
@@ -0,0 +1,58 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/png"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// WriteText places text on the clipboard
+func WriteText(text string) error {
+	cmd := exec.Command("pbcopy")
+	cmd.Stdin = strings.NewReader(text)
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("pbcopy failed: %v", err)
+	}
+	return nil
+}
+
+// ReadText returns the text currently on the clipboard
+func ReadText() (string, error) {
+	out, err := exec.Command("pbpaste").Output()
+	if err != nil {
+		return "", fmt.Errorf("pbpaste failed: %v", err)
+	}
+	return string(out), nil
+}
+
+// pngData matches AppleScript's rendering of PNG data: «data PNGf89504E47…»
+var pngData = regexp.MustCompile(`«data PNGf([0-9A-Fa-f]+)»`)
+
+// ReadImage returns the image currently on the clipboard
+func ReadImage() (image.Image, error) {
+	// pbpaste only handles text; AppleScript can coerce the clipboard to PNG
+	out, err := exec.Command("osascript", "-e", "the clipboard as «class PNGf»").Output()
+	if err != nil {
+		return nil, ErrNoImage
+	}
+	match := pngData.FindSubmatch(out)
+	if match == nil {
+		return nil, ErrNoImage
+	}
+
+	data, err := hex.DecodeString(string(match[1]))
+	if err == nil {
+		var img image.Image
+		img, err = png.Decode(bytes.NewReader(data))
+		if err == nil {
+			return img, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to decode clipboard image: %v", err)
+}
@@ -0,0 +1,109 @@
+package clipboard
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// tool is a clipboard command line utility and its copy/paste arguments
+type tool struct {
+	name  string
+	write []string
+	read  []string
+}
+
+// Wayland's wl-clipboard is preferred when a Wayland session is running,
+// then the common X11 utilities
+var tools = []tool{
+	{name: "wl-copy"},
+	{name: "xclip", write: []string{"-selection", "clipboard"}, read: []string{"-selection", "clipboard", "-o"}},
+	{name: "xsel", write: []string{"--clipboard", "--input"}, read: []string{"--clipboard", "--output"}},
+}
+
+// WriteText places text on the clipboard
+func WriteText(text string) error {
+	name, args, err := find(true)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(text)
+	err = cmd.Run()
+	if err != nil {
+		return fmt.Errorf("%s failed: %v", name, err)
+	}
+	return nil
+}
+
+// ReadText returns the text currently on the clipboard
+func ReadText() (string, error) {
+	name, args, err := find(false)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("%s failed: %v", name, err)
+	}
+	return string(out), nil
+}
+
+// find picks the first installed clipboard tool usable in this session
+func find(write bool) (string, []string, error) {
+	for _, t := range tools {
+		name, args := t.name, t.read
+		if write {
+			args = t.write
+		}
+		if name == "wl-copy" {
+			if os.Getenv("WAYLAND_DISPLAY") == "" {
+				continue
+			}
+			if !write {
+				name, args = "wl-paste", []string{"--no-newline"}
+			}
+		}
+
+		if _, err := exec.LookPath(name); err == nil {
+			return name, args, nil
+		}
+	}
+	return "", nil, fmt.Errorf("no clipboard tool found (install wl-clipboard, xclip or xsel)")
+}
+
+// ReadImage returns the image currently on the clipboard
+func ReadImage() (image.Image, error) {
+	var cmd *exec.Cmd
+	switch {
+	case os.Getenv("WAYLAND_DISPLAY") != "" && installed("wl-paste"):
+		cmd = exec.Command("wl-paste", "--no-newline", "--type", "image/png")
+	case installed("xclip"):
+		cmd = exec.Command("xclip", "-selection", "clipboard", "-t", "image/png", "-o")
+	default:
+		return nil, fmt.Errorf("no clipboard tool for images found (install wl-clipboard or xclip)")
+	}
+
+	// Both tools fail when the clipboard has nothing of the asked-for type
+	out, err := cmd.Output()
+	if err != nil || len(out) == 0 {
+		return nil, ErrNoImage
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode clipboard image: %v", err)
+	}
+	return img, nil
+}
+
+// installed reports whether a command is on the PATH
+func installed(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
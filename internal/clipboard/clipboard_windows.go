@@ -0,0 +1,262 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	_ "image/gif" // Registers the GIF decoder for copied files
+	_ "image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const (
+	CF_DIB         = 8
+	CF_UNICODETEXT = 13
+	CF_HDROP       = 15
+	GMEM_MOVEABLE  = 0x0002
+	BI_RGB         = 0
+	BI_BITFIELDS   = 3
+)
+
+var (
+	user32           = syscall.NewLazyDLL("user32.dll")
+	kernel32         = syscall.NewLazyDLL("kernel32.dll")
+	openClipboard    = user32.NewProc("OpenClipboard")
+	closeClipboard   = user32.NewProc("CloseClipboard")
+	emptyClipboard   = user32.NewProc("EmptyClipboard")
+	getClipboardData = user32.NewProc("GetClipboardData")
+	setClipboardData = user32.NewProc("SetClipboardData")
+	globalAlloc      = kernel32.NewProc("GlobalAlloc")
+	globalFree       = kernel32.NewProc("GlobalFree")
+	globalLock       = kernel32.NewProc("GlobalLock")
+	globalUnlock     = kernel32.NewProc("GlobalUnlock")
+	globalSize       = kernel32.NewProc("GlobalSize")
+	moveMemory       = kernel32.NewProc("RtlMoveMemory")
+
+	registerClipboardFormatW = user32.NewProc("RegisterClipboardFormatW")
+	dragQueryFileW           = syscall.NewLazyDLL("shell32.dll").NewProc("DragQueryFileW")
+)
+
+// WriteText places text on the clipboard
+func WriteText(text string) error {
+	data, err := syscall.UTF16FromString(text)
+	if err != nil {
+		return fmt.Errorf("failed to encode clipboard text: %v", err)
+	}
+
+	err = open()
+	if err != nil {
+		return err
+	}
+	defer closeClipboard.Call()
+
+	emptyClipboard.Call()
+
+	size := uintptr(len(data) * 2)
+	handle, _, err := globalAlloc.Call(GMEM_MOVEABLE, size)
+	if handle == 0 {
+		return fmt.Errorf("GlobalAlloc failed: %v", err)
+	}
+
+	ptr, _, err := globalLock.Call(handle)
+	if ptr == 0 {
+		globalFree.Call(handle)
+		return fmt.Errorf("GlobalLock failed: %v", err)
+	}
+	moveMemory.Call(ptr, uintptr(unsafe.Pointer(&data[0])), size)
+	globalUnlock.Call(handle)
+
+	ret, _, err := setClipboardData.Call(CF_UNICODETEXT, handle)
+	if ret == 0 {
+		globalFree.Call(handle)
+		return fmt.Errorf("SetClipboardData failed: %v", err)
+	}
+	// The clipboard owns the memory once SetClipboardData succeeds
+	return nil
+}
+
+// ReadText returns the text currently on the clipboard
+func ReadText() (string, error) {
+	err := open()
+	if err != nil {
+		return "", err
+	}
+	defer closeClipboard.Call()
+
+	handle, _, _ := getClipboardData.Call(CF_UNICODETEXT)
+	if handle == 0 {
+		return "", fmt.Errorf("clipboard has no text")
+	}
+
+	ptr, _, err := globalLock.Call(handle)
+	if ptr == 0 {
+		return "", fmt.Errorf("GlobalLock failed: %v", err)
+	}
+	defer globalUnlock.Call(handle)
+
+	size, _, _ := globalSize.Call(handle)
+	data := make([]uint16, size/2)
+	if len(data) == 0 {
+		return "", nil
+	}
+	moveMemory.Call(uintptr(unsafe.Pointer(&data[0])), ptr, uintptr(len(data)*2))
+	return syscall.UTF16ToString(data), nil
+}
+
+// open opens the clipboard, retrying briefly while another app holds it
+func open() error {
+	var err error
+	for attempt := 0; attempt < 10; attempt++ {
+		var ret uintptr
+		ret, _, err = openClipboard.Call(0)
+		if ret != 0 {
+			return nil
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return fmt.Errorf("failed to open clipboard: %v", err)
+}
+
+// ReadImage returns the image currently on the clipboard: a copied image,
+// or the first image file among copied files
+func ReadImage() (image.Image, error) {
+	err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer closeClipboard.Call()
+
+	// Browsers and Office put a lossless PNG alongside the bitmap
+	name, _ := syscall.UTF16PtrFromString("PNG")
+	if format, _, _ := registerClipboardFormatW.Call(uintptr(unsafe.Pointer(name))); format != 0 {
+		if data := readGlobal(format); len(data) > 0 {
+			img, err := png.Decode(bytes.NewReader(data))
+			if err == nil {
+				return img, nil
+			}
+		}
+	}
+	if data := readGlobal(CF_DIB); len(data) > 0 {
+		return decodeDIB(data)
+	}
+	if path := firstDroppedImage(); path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		img, _, err := image.Decode(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %v", filepath.Base(path), err)
+		}
+		return img, nil
+	}
+	return nil, ErrNoImage
+}
+
+// readGlobal copies clipboard data of one format; the clipboard must be open
+func readGlobal(format uintptr) []byte {
+	handle, _, _ := getClipboardData.Call(format)
+	if handle == 0 {
+		return nil
+	}
+	ptr, _, _ := globalLock.Call(handle)
+	if ptr == 0 {
+		return nil
+	}
+	defer globalUnlock.Call(handle)
+
+	size, _, _ := globalSize.Call(handle)
+	data := make([]byte, size)
+	if len(data) > 0 {
+		moveMemory.Call(uintptr(unsafe.Pointer(&data[0])), ptr, size)
+	}
+	return data
+}
+
+// firstDroppedImage returns the first copied file that decodes as an
+// image, e.g. after Ctrl+C in Explorer; the clipboard must be open
+func firstDroppedImage() string {
+	drop, _, _ := getClipboardData.Call(CF_HDROP)
+	if drop == 0 {
+		return ""
+	}
+
+	count, _, _ := dragQueryFileW.Call(drop, 0xFFFFFFFF, 0, 0)
+	for i := uintptr(0); i < count; i++ {
+		length, _, _ := dragQueryFileW.Call(drop, i, 0, 0)
+		buf := make([]uint16, length+1)
+		dragQueryFileW.Call(drop, i, uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+		path := syscall.UTF16ToString(buf)
+
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		_, _, err = image.DecodeConfig(file)
+		file.Close()
+		if err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// decodeDIB decodes a device-independent bitmap as Windows puts it on the
+// clipboard: a BITMAPINFOHEADER (or a later version) followed by the
+// pixels, bottom row first unless the height is negative
+func decodeDIB(data []byte) (image.Image, error) {
+	if len(data) < 40 {
+		return nil, fmt.Errorf("clipboard bitmap is truncated")
+	}
+	headerSize := int(binary.LittleEndian.Uint32(data[0:4]))
+	width := int(int32(binary.LittleEndian.Uint32(data[4:8])))
+	height := int(int32(binary.LittleEndian.Uint32(data[8:12])))
+	bitCount := int(binary.LittleEndian.Uint16(data[14:16]))
+	compression := binary.LittleEndian.Uint32(data[16:20])
+	colorsUsed := int(binary.LittleEndian.Uint32(data[32:36]))
+
+	if (bitCount != 24 && bitCount != 32) || (compression != BI_RGB && compression != BI_BITFIELDS) {
+		return nil, fmt.Errorf("unsupported clipboard bitmap (%d bits, compression %d)", bitCount, compression)
+	}
+	topDown := height < 0
+	if topDown {
+		height = -height
+	}
+
+	// The masks follow a plain BITMAPINFOHEADER; later headers include them
+	offset := headerSize + colorsUsed*4
+	if compression == BI_BITFIELDS && headerSize == 40 {
+		offset += 12
+	}
+	stride := (width*bitCount + 31) / 32 * 4
+	if width <= 0 || height <= 0 || offset+stride*height > len(data) {
+		return nil, fmt.Errorf("clipboard bitmap is truncated")
+	}
+
+	// Alpha in clipboard bitmaps is unreliable, so pixels are taken as opaque
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	step := bitCount / 8
+	for y := 0; y < height; y++ {
+		row := y
+		if !topDown {
+			row = height - 1 - y
+		}
+		line := data[offset+row*stride:]
+		for x := 0; x < width; x++ {
+			pixel := line[x*step:]
+			o := img.PixOffset(x, y)
+			img.Pix[o] = pixel[2]
+			img.Pix[o+1] = pixel[1]
+			img.Pix[o+2] = pixel[0]
+			img.Pix[o+3] = 0xFF
+		}
+	}
+	return img, nil
+}
@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Action types a command can trigger
+const (
+	ActionShell   = "shell"   // Run a program with arguments
+	ActionURL     = "url"     // Open a URL in the default browser
+	ActionTool    = "tool"    // Invoke a registered assistant tool
+	ActionPersona = "persona" // Switch the active persona
+)
+
+var knownActions = map[string]bool{
+	ActionShell:   true,
+	ActionURL:     true,
+	ActionTool:    true,
+	ActionPersona: true,
+}
+
+// Action describes what a command does when matched
+type Action struct {
+	Type   string   `yaml:"type"`
+	Target string   `yaml:"target"` // Program, URL, tool, or persona name; may contain {slot} placeholders
+	Args   []string `yaml:"args"`   // Extra arguments; may contain {slot} placeholders
+}
+
+// Command is one user-defined voice command
+type Command struct {
+	Name    string            `yaml:"name"`
+	Phrases []string          `yaml:"phrases"` // e.g. "search the web for {query}"
+	Slots   map[string]string `yaml:"slots"`   // Optional regex per slot; defaults to any text
+	Action  Action            `yaml:"action"`
+}
+
+// Grammar is the contents of the command grammar file
+type Grammar struct {
+	Commands []Command `yaml:"commands"`
+}
+
+// compiledCommand pairs a command with its phrase patterns
+type compiledCommand struct {
+	command  Command
+	patterns []*regexp.Regexp
+}
+
+var slotPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// compile validates the grammar and builds matchers, collecting every
+// problem rather than stopping at the first so they can all be shown at once
+func compile(grammar Grammar) ([]compiledCommand, []error) {
+	var compiled []compiledCommand
+	var errs []error
+	names := make(map[string]bool)
+
+	for i, command := range grammar.Commands {
+		label := command.Name
+		if label == "" {
+			label = fmt.Sprintf("command #%d", i+1)
+			errs = append(errs, fmt.Errorf("%s: name is required", label))
+		} else if names[label] {
+			errs = append(errs, fmt.Errorf("%s: duplicate command name", label))
+		}
+		names[label] = true
+
+		if len(command.Phrases) == 0 {
+			errs = append(errs, fmt.Errorf("%s: at least one phrase is required", label))
+		}
+		if !knownActions[command.Action.Type] {
+			errs = append(errs, fmt.Errorf("%s: unknown action type %q", label, command.Action.Type))
+		}
+		if command.Action.Target == "" {
+			errs = append(errs, fmt.Errorf("%s: action target is required", label))
+		}
+
+		entry := compiledCommand{command: command}
+		valid := true
+		for _, phrase := range command.Phrases {
+			pattern, err := phraseToRegexp(phrase, command.Slots)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: phrase %q: %v", label, phrase, err))
+				valid = false
+				continue
+			}
+			entry.patterns = append(entry.patterns, pattern)
+
+			// Every placeholder used by the action must be filled by the phrase
+			for _, used := range slotNames(command.Action.Target, command.Action.Args) {
+				if !strings.Contains(phrase, "{"+used+"}") {
+					errs = append(errs, fmt.Errorf("%s: phrase %q does not provide slot {%s}", label, phrase, used))
+					valid = false
+				}
+			}
+		}
+
+		if valid && len(entry.patterns) > 0 {
+			compiled = append(compiled, entry)
+		}
+	}
+
+	return compiled, errs
+}
+
+// phraseToRegexp converts "play {song} by {artist}" into an anchored regexp
+// with named groups, using per-slot patterns when provided
+func phraseToRegexp(phrase string, slots map[string]string) (*regexp.Regexp, error) {
+	phrase = Normalize(phrase)
+	var b strings.Builder
+	b.WriteString("^")
+
+	last := 0
+	for _, loc := range slotPattern.FindAllStringSubmatchIndex(phrase, -1) {
+		b.WriteString(regexp.QuoteMeta(phrase[last:loc[0]]))
+		name := phrase[loc[2]:loc[3]]
+		pattern := ".+?"
+		if custom, ok := slots[name]; ok && custom != "" {
+			pattern = custom
+		}
+		fmt.Fprintf(&b, "(?P<%s>%s)", name, pattern)
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(phrase[last:]))
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+// slotNames returns the placeholders referenced by an action
+func slotNames(target string, args []string) []string {
+	var names []string
+	for _, text := range append([]string{target}, args...) {
+		for _, match := range slotPattern.FindAllStringSubmatch(text, -1) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// Fill replaces {slot} placeholders with captured values
+func Fill(text string, slots map[string]string) string {
+	return slotPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		return slots[placeholder[1:len(placeholder)-1]]
+	})
+}
+
+// Normalize lowercases text and strips punctuation so transcripts like
+// "Open GitHub." match the phrase "open github"
+func Normalize(text string) string {
+	text = strings.ToLower(strings.TrimSpace(text))
+	text = strings.Map(func(r rune) rune {
+		switch r {
+		case '.', ',', '!', '?', ';', ':', '"':
+			return -1
+		}
+		return r
+	}, text)
+	return strings.Join(strings.Fields(text), " ")
+}
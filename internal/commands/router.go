@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Handler executes an action with the slot values captured from the transcript
+type Handler func(action Action, slots map[string]string) error
+
+// Router matches transcripts against the user's command grammar and
+// dispatches the matched action to a handler for its type
+type Router struct {
+	path     string
+	commands []compiledCommand
+	errors   []error
+	modTime  time.Time
+	handlers map[string]Handler
+	onReload func(loaded int, errs []error)
+	mutex    sync.Mutex
+
+	stop     chan struct{} // Closed to stop watching
+	done     chan struct{} // Closed once the watcher has returned
+	stopOnce sync.Once
+}
+
+// NewRouter creates a router for the grammar file at path
+func NewRouter(path string) *Router {
+	return &Router{
+		path:     path,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Handle registers the handler for an action type
+func (r *Router) Handle(actionType string, handler Handler) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.handlers[actionType] = handler
+}
+
+// SetReloadCallback sets a callback invoked after every (re)load
+func (r *Router) SetReloadCallback(onReload func(loaded int, errs []error)) {
+	r.onReload = onReload
+}
+
+// Load reads and validates the grammar file. Valid commands are kept even
+// when others fail, and a missing file simply means no custom commands.
+func (r *Router) Load() []error {
+	info, err := os.Stat(r.path)
+	if os.IsNotExist(err) {
+		r.apply(nil, nil, time.Time{})
+		return nil
+	}
+	if err != nil {
+		return r.apply(nil, []error{fmt.Errorf("failed to read command grammar: %v", err)}, time.Time{})
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return r.apply(nil, []error{fmt.Errorf("failed to read command grammar: %v", err)}, info.ModTime())
+	}
+
+	var grammar Grammar
+	err = yaml.Unmarshal(data, &grammar)
+	if err != nil {
+		return r.apply(nil, []error{fmt.Errorf("failed to parse command grammar: %v", err)}, info.ModTime())
+	}
+
+	compiled, errs := compile(grammar)
+	return r.apply(compiled, errs, info.ModTime())
+}
+
+// apply swaps in a freshly loaded grammar and reports the result
+func (r *Router) apply(compiled []compiledCommand, errs []error, modTime time.Time) []error {
+	r.mutex.Lock()
+	r.commands = compiled
+	r.errors = errs
+	r.modTime = modTime
+	r.mutex.Unlock()
+
+	log.Printf("📜 Loaded %d custom commands from %s", len(compiled), r.path)
+	for _, err := range errs {
+		log.Printf("   ⚠️  %v", err)
+	}
+
+	if r.onReload != nil {
+		r.onReload(len(compiled), errs)
+	}
+	return errs
+}
+
+// Watch reloads the grammar whenever the file changes
+func (r *Router) Watch(interval time.Duration) {
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+
+	go func(stop <-chan struct{}, done chan<- struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+
+			var modTime time.Time
+			if info, err := os.Stat(r.path); err == nil {
+				modTime = info.ModTime()
+			}
+
+			r.mutex.Lock()
+			changed := !modTime.Equal(r.modTime)
+			r.mutex.Unlock()
+
+			if changed {
+				log.Printf("📜 Command grammar changed, reloading...")
+				r.Load()
+			}
+		}
+	}(r.stop, r.done)
+}
+
+// Stop stops watching the grammar file, waiting for a reload in progress
+func (r *Router) Stop() {
+	if r.stop == nil {
+		return
+	}
+	r.stopOnce.Do(func() { close(r.stop) })
+	<-r.done
+}
+
+// Errors returns the validation errors from the last load
+func (r *Router) Errors() []error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.errors
+}
+
+// Route runs the first command matching the transcript. It reports whether
+// a command matched; the error is the action's result.
+func (r *Router) Route(transcript string) (bool, error) {
+	normalized := Normalize(transcript)
+
+	r.mutex.Lock()
+	commands := r.commands
+	r.mutex.Unlock()
+
+	for _, entry := range commands {
+		for _, pattern := range entry.patterns {
+			match := pattern.FindStringSubmatch(normalized)
+			if match == nil {
+				continue
+			}
+
+			slots := make(map[string]string)
+			for i, name := range pattern.SubexpNames() {
+				if name != "" {
+					slots[name] = match[i]
+				}
+			}
+
+			log.Printf("📜 Command '%s' matched (slots: %v)", entry.command.Name, slots)
+			return true, r.execute(entry.command.Action, slots)
+		}
+	}
+	return false, nil
+}
+
+// execute dispatches an action to the handler registered for its type
+func (r *Router) execute(action Action, slots map[string]string) error {
+	r.mutex.Lock()
+	handler, ok := r.handlers[action.Type]
+	r.mutex.Unlock()
+
+	if !ok {
+		return fmt.Errorf("no handler for %s actions", action.Type)
+	}
+	return handler(action, slots)
+}
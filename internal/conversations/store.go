@@ -0,0 +1,179 @@
+// Package conversations keeps named conversations, each with its own
+// history and persona, so the user can switch between them and pick up
+// where they left off, even after a restart.
+package conversations
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"voice-assistant/internal/claude"
+)
+
+// Conversation is one named thread
+type Conversation struct {
+	Name     string           `json:"name"`
+	Persona  string           `json:"persona,omitempty"` // Empty keeps whichever persona is active
+	Messages []claude.Message `json:"messages"`
+	Updated  time.Time        `json:"updated"`
+}
+
+// Cipher encrypts conversations before they are written
+type Cipher interface {
+	Seal(plain string) (string, error)
+	Open(value string) (string, error)
+}
+
+// Store saves each conversation to its own file in a directory
+type Store struct {
+	dir    string
+	cipher Cipher // Nil stores conversations as plain JSON
+	mutex  sync.Mutex
+}
+
+// NewStore creates a store in dir, which is created on the first save
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// SetCipher encrypts conversations saved from now on; plain ones are still
+// read
+func (s *Store) SetCipher(cipher Cipher) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cipher = cipher
+}
+
+// List returns every saved conversation, most recently used first
+func (s *Store) List() ([]Conversation, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var list []Conversation
+	for _, file := range files {
+		conversation, err := s.read(file)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, conversation)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Updated.After(list[j].Updated) })
+	return list, nil
+}
+
+// Load returns the conversation saved under name, reporting false if
+// there is none
+func (s *Store) Load(name string) (Conversation, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	conversation, err := s.read(s.path(name))
+	if os.IsNotExist(err) {
+		return Conversation{Name: name}, false, nil
+	}
+	return conversation, err == nil, err
+}
+
+// Save writes a conversation, replacing any saved under the same name
+func (s *Store) Save(conversation Conversation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	conversation.Updated = time.Now()
+	data, err := json.Marshal(conversation)
+	if err != nil {
+		return err
+	}
+	if s.cipher != nil {
+		sealed, err := s.cipher.Seal(string(data))
+		if err != nil {
+			return fmt.Errorf("failed to encrypt conversation: %v", err)
+		}
+		data = []byte(sealed)
+	}
+
+	err = os.MkdirAll(s.dir, 0700)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(s.path(conversation.Name), data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to save conversation %q: %v", conversation.Name, err)
+	}
+	return nil
+}
+
+// Delete removes a saved conversation
+func (s *Store) Delete(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := os.Remove(s.path(name))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Clear removes every saved conversation and returns how many there were
+func (s *Store) Clear() (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+	for i, file := range files {
+		err = os.Remove(file)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(files), nil
+}
+
+// read loads a conversation file, decrypting it if needed
+func (s *Store) read(path string) (Conversation, error) {
+	var conversation Conversation
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return conversation, err
+	}
+
+	text := string(data)
+	if s.cipher != nil {
+		text, err = s.cipher.Open(text)
+		if err != nil {
+			return conversation, fmt.Errorf("failed to decrypt %s: %v", filepath.Base(path), err)
+		}
+	}
+	err = json.Unmarshal([]byte(text), &conversation)
+	if err != nil {
+		return conversation, fmt.Errorf("failed to read %s: %v", filepath.Base(path), err)
+	}
+	return conversation, nil
+}
+
+// path names a conversation's file; names differing only in case or
+// punctuation share one
+func (s *Store) path(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '_'
+	}, strings.TrimSpace(name))
+	return filepath.Join(s.dir, safe+".json")
+}
@@ -0,0 +1,21 @@
+package ducking
+
+import "voice-assistant/internal/wincom"
+
+var (
+	iidIAudioSessionManager2 = wincom.NewGUID(0x77AA99A0, 0x1BD6, 0x484F, [8]byte{0x8B, 0xC7, 0x2C, 0x65, 0x4C, 0x9A, 0x9B, 0x6F})
+	iidIAudioSessionControl2 = wincom.NewGUID(0xBFB7FF88, 0x7239, 0x4FC9, [8]byte{0x8F, 0xA2, 0x07, 0xC9, 0x50, 0xBE, 0x9C, 0x6D})
+	iidISimpleAudioVolume    = wincom.NewGUID(0x87CE5498, 0x68D6, 0x44E5, [8]byte{0x92, 0x15, 0x6D, 0xA4, 0x7E, 0xF8, 0x83, 0xD8})
+)
+
+// Vtable slots of the interfaces used here (IUnknown takes slots 0-2)
+const (
+	methodActivate              = 3  // IMMDevice
+	methodGetSessionEnumerator  = 5  // IAudioSessionManager2
+	methodGetCount              = 3  // IAudioSessionEnumerator
+	methodGetSession            = 4  // IAudioSessionEnumerator
+	methodGetProcessId          = 14 // IAudioSessionControl2
+	methodIsSystemSoundsSession = 15 // IAudioSessionControl2
+	methodSetMasterVolume       = 3  // ISimpleAudioVolume
+	methodGetMasterVolume       = 4  // ISimpleAudioVolume
+)
@@ -0,0 +1,162 @@
+// Package ducking lowers other applications' volume while the assistant
+// is listening or speaking, using the Windows audio session API.
+package ducking
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"voice-assistant/internal/wincom"
+)
+
+// Ducker lowers and restores the volume of other applications' audio sessions
+type Ducker struct {
+	level    float32 // Volume multiplier applied while ducked
+	ducked   bool
+	original map[uint32]float32 // Volume of each ducked process before ducking
+	mutex    sync.Mutex
+}
+
+// NewDucker creates a ducker that lowers other audio by percent (0-100)
+func NewDucker(percent int) *Ducker {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	return &Ducker{
+		level:    float32(100-percent) / 100,
+		original: make(map[uint32]float32),
+	}
+}
+
+// Duck lowers the volume of every other application; calling it again
+// while ducked does nothing
+func (d *Ducker) Duck() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.ducked {
+		return
+	}
+	d.ducked = true
+
+	err := forEachSession(func(pid uint32, volume *wincom.Object) {
+		var current float32
+		if wincom.Failed(volume.Call(methodGetMasterVolume, uintptr(unsafe.Pointer(&current)))) {
+			return
+		}
+		d.original[pid] = current
+		setVolume(volume, current*d.level)
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to duck other audio: %v", err)
+		return
+	}
+	log.Printf("🔉 Ducked %d audio session(s)", len(d.original))
+}
+
+// Restore puts every ducked application back to its previous volume
+func (d *Ducker) Restore() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if !d.ducked {
+		return
+	}
+	d.ducked = false
+
+	err := forEachSession(func(pid uint32, volume *wincom.Object) {
+		if original, ok := d.original[pid]; ok {
+			setVolume(volume, original)
+		}
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to restore other audio: %v", err)
+	}
+	d.original = make(map[uint32]float32)
+}
+
+// setVolume sets a session's master volume. The float travels in the low
+// bits of the argument; the Windows syscall path mirrors arguments into
+// the floating point registers the callee reads it from.
+func setVolume(volume *wincom.Object, level float32) {
+	volume.Call(methodSetMasterVolume, uintptr(math.Float32bits(level)), 0)
+}
+
+// forEachSession calls fn with the volume control of every audio session on
+// the default output device, except this process and system sounds
+func forEachSession(fn func(pid uint32, volume *wincom.Object)) error {
+	// COM state is per thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	uninitialize, err := wincom.Initialize()
+	if err != nil {
+		return err
+	}
+	defer uninitialize()
+
+	device, err := wincom.DefaultAudioEndpoint(wincom.ERender)
+	if err != nil {
+		return err
+	}
+	defer device.Release()
+
+	var manager *wincom.Object
+	hr := device.Call(methodActivate, uintptr(unsafe.Pointer(&iidIAudioSessionManager2)), wincom.CLSCTX_ALL, 0, uintptr(unsafe.Pointer(&manager)))
+	if wincom.Failed(hr) {
+		return fmt.Errorf("failed to activate session manager: 0x%08X", uint32(hr))
+	}
+	defer manager.Release()
+
+	var sessions *wincom.Object
+	hr = manager.Call(methodGetSessionEnumerator, uintptr(unsafe.Pointer(&sessions)))
+	if wincom.Failed(hr) {
+		return fmt.Errorf("failed to enumerate sessions: 0x%08X", uint32(hr))
+	}
+	defer sessions.Release()
+
+	var count int32
+	sessions.Call(methodGetCount, uintptr(unsafe.Pointer(&count)))
+
+	self := uint32(os.Getpid())
+	for i := int32(0); i < count; i++ {
+		var control *wincom.Object
+		if wincom.Failed(sessions.Call(methodGetSession, uintptr(i), uintptr(unsafe.Pointer(&control)))) {
+			continue
+		}
+		visitSession(control, self, fn)
+		control.Release()
+	}
+	return nil
+}
+
+// visitSession passes one session to fn unless it should be left alone
+func visitSession(control *wincom.Object, self uint32, fn func(pid uint32, volume *wincom.Object)) {
+	control2, err := control.Query(&iidIAudioSessionControl2)
+	if err != nil {
+		return
+	}
+	defer control2.Release()
+
+	// S_OK means it is the system sounds session
+	if control2.Call(methodIsSystemSoundsSession) == 0 {
+		return
+	}
+	var pid uint32
+	if wincom.Failed(control2.Call(methodGetProcessId, uintptr(unsafe.Pointer(&pid)))) || pid == self {
+		return
+	}
+
+	volume, err := control.Query(&iidISimpleAudioVolume)
+	if err != nil {
+		return
+	}
+	defer volume.Release()
+	fn(pid, volume)
+}
@@ -0,0 +1,75 @@
+// Package email sends plain-text mail over SMTP
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message is a plain-text email
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Sender sends mail through an SMTP server, upgrading to TLS when the
+// server offers STARTTLS
+type Sender struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewSender creates a sender for an SMTP account
+func NewSender(host string, port int, username, password, from string) *Sender {
+	return &Sender{host: host, port: port, username: username, password: password, from: from}
+}
+
+// Send delivers a message
+func (s *Sender) Send(message Message) error {
+	if len(message.To) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+	for _, to := range message.To {
+		_, err := mail.ParseAddress(to)
+		if err != nil {
+			return fmt.Errorf("invalid recipient %q: %v", to, err)
+		}
+	}
+
+	var auth smtp.Auth
+	if s.password != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	err := smtp.SendMail(addr, auth, s.from, message.To, s.compose(message))
+	if err != nil {
+		return fmt.Errorf("failed to send email: %v", err)
+	}
+	return nil
+}
+
+// compose renders the message with UTF-8 headers and body
+func (s *Sender) compose(message Message) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(message.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
@@ -0,0 +1,123 @@
+// Package errs classifies failures from speech, Claude, audio and TTS so
+// they can be reported with a hint the user can act on instead of a
+// generic error. Services wrap what they return with New, FromStatus or
+// FromTransport; callers test the kind with errors.Is and ask Hint for the
+// message to show.
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// Kinds of failure, matched with errors.Is
+var (
+	ErrAuth        = errors.New("credentials rejected")
+	ErrQuota       = errors.New("quota or rate limit exceeded")
+	ErrNetwork     = errors.New("network unreachable")
+	ErrUnavailable = errors.New("service unavailable")
+	ErrNoMic       = errors.New("no microphone found")
+	ErrDeviceBusy  = errors.New("audio device busy")
+)
+
+// Error is a failure of one kind from a named service
+type Error struct {
+	Kind    error  // One of the Err* kinds
+	Service string // Who failed, as shown to the user, e.g. "Azure Speech"
+	Err     error  // The underlying error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is match the kind as well as the underlying error
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// New tags err with a kind and the service it came from
+func New(kind error, service string, err error) error {
+	if err == nil {
+		err = kind
+	}
+	return &Error{Kind: kind, Service: service, Err: err}
+}
+
+// FromStatus classifies an HTTP error response, leaving statuses that
+// don't point at anything the user can fix untagged
+func FromStatus(service string, status int, err error) error {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return New(ErrAuth, service, err)
+	case status == http.StatusTooManyRequests || status == http.StatusPaymentRequired:
+		return New(ErrQuota, service, err)
+	case status >= 500:
+		return New(ErrUnavailable, service, err)
+	}
+	return err
+}
+
+// FromTransport classifies an error from sending a request. Cancellation
+// is left alone since it is never a fault.
+func FromTransport(service string, err error) error {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return err
+	}
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	if errors.As(err, &netErr) || errors.As(err, &dnsErr) || errors.As(err, &opErr) {
+		return New(ErrNetwork, service, err)
+	}
+	return err
+}
+
+// ServiceOf returns the service an error was tagged with, if any
+func ServiceOf(err error) string {
+	var tagged *Error
+	if errors.As(err, &tagged) {
+		return tagged.Service
+	}
+	return ""
+}
+
+// Hint returns a notification explaining err and what to do about it, or
+// an empty string when the error is of no known kind
+func Hint(err error) string {
+	service := ServiceOf(err)
+	if service == "" {
+		service = "The service"
+	}
+
+	switch {
+	case errors.Is(err, ErrAuth):
+		return fmt.Sprintf("🔑 Your %s key was rejected — check it in params.json", service)
+	case errors.Is(err, ErrQuota):
+		return fmt.Sprintf("⏳ %s quota or rate limit reached — try again later", service)
+	case errors.Is(err, ErrNetwork):
+		return fmt.Sprintf("📡 Can't reach %s — check your internet connection", service)
+	case errors.Is(err, ErrUnavailable):
+		return fmt.Sprintf("☁️ %s is having problems — try again in a minute", service)
+	case errors.Is(err, ErrNoMic):
+		return "🎤 No microphone found — plug one in or choose another input"
+	case errors.Is(err, ErrDeviceBusy):
+		return "🎤 The microphone is being used by another app — close it and try again"
+	}
+	return ""
+}
+
+// Describe returns the hint for err, or fallback when there is none
+func Describe(err error, fallback string) string {
+	if hint := Hint(err); hint != "" {
+		return hint
+	}
+	return fallback
+}
@@ -0,0 +1,104 @@
+// Package events is the assistant's publish/subscribe bus. Subsystems
+// publish what happened (a final transcript, Claude's response, a state
+// change, an error) and anything interested subscribes, so features such
+// as hooks, overlays and history don't need to be called from the pipeline.
+package events
+
+import (
+	"sync"
+
+	"voice-assistant/internal/app"
+)
+
+// Event is anything published on the bus
+type Event interface {
+	event()
+}
+
+// TranscriptPartial is the text recognized so far while the user is
+// still speaking; later partials replace earlier ones
+type TranscriptPartial struct {
+	Text string
+}
+
+// TranscriptFinal is a phrase the speech recognizer has finished with
+type TranscriptFinal struct {
+	Text       string
+	Language   string
+	Confidence float64
+}
+
+// LLMResponse is Claude's answer to a prompt
+type LLMResponse struct {
+	Prompt   string
+	Text     string
+	Model    string
+	Thinking string // Claude's reasoning, shown in history but never spoken
+}
+
+// LLMPartial is the answer so far while it streams in; later partials
+// replace earlier ones
+type LLMPartial struct {
+	Text string
+}
+
+// StateChanged is a transition of the assistant's state machine
+type StateChanged struct {
+	From    app.State
+	To      app.State
+	Reason  string
+	Aborted bool // Cancelled by the user
+}
+
+// Error is a failure in one of the assistant's subsystems
+type Error struct {
+	Source string // e.g. "speech" or "claude"
+	Err    error
+}
+
+func (TranscriptPartial) event() {}
+func (TranscriptFinal) event()   {}
+func (LLMPartial) event()        {}
+func (LLMResponse) event()       {}
+func (StateChanged) event()      {}
+func (Error) event()             {}
+
+// Bus delivers published events to subscribers
+type Bus struct {
+	subscribers []func(Event)
+	mutex       sync.Mutex
+}
+
+// NewBus creates an empty bus
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe calls handler for every event. Handlers run synchronously on
+// the publisher's goroutine, in subscription order, so they must be quick;
+// slow work belongs in a goroutine of the handler's own.
+func (b *Bus) Subscribe(handler func(Event)) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.subscribers = append(b.subscribers, handler)
+}
+
+// Publish delivers an event to every subscriber
+func (b *Bus) Publish(event Event) {
+	b.mutex.Lock()
+	subscribers := append([]func(Event){}, b.subscribers...)
+	b.mutex.Unlock()
+
+	for _, handler := range subscribers {
+		handler(event)
+	}
+}
+
+// On subscribes handler to events of one type only
+func On[T Event](b *Bus, handler func(T)) {
+	b.Subscribe(func(event Event) {
+		if typed, ok := event.(T); ok {
+			handler(typed)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"voice-assistant/internal/claude"
+)
+
+// Claude answers prompts with canned replies, in order, and records the
+// prompts it was sent
+type Claude struct {
+	replies []string
+	prompts []string
+	err     error
+	mutex   sync.Mutex
+}
+
+// NewClaude creates a fake that gives these replies in turn
+func NewClaude(replies ...string) *Claude {
+	return &Claude{replies: replies}
+}
+
+// FailWith makes every following request fail with err; nil stops failing
+func (c *Claude) FailWith(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.err = err
+}
+
+// SetReplies replaces the replies still to be given
+func (c *Claude) SetReplies(replies ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.replies = replies
+}
+
+// Prompts returns the prompts sent so far
+func (c *Claude) Prompts() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]string(nil), c.prompts...)
+}
+
+// SendMessageWithOptions returns the next canned reply
+func (c *Claude) SendMessageWithOptions(ctx context.Context, userMessage string, options claude.RequestOptions) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.prompts = append(c.prompts, userMessage)
+	if c.err != nil {
+		return "", c.err
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if len(c.replies) == 0 {
+		return "", fmt.Errorf("no canned reply for %q", userMessage)
+	}
+	reply := c.replies[0]
+	c.replies = c.replies[1:]
+	return reply, nil
+}
+
+// Model names the fake model
+func (c *Claude) Model() string {
+	return "fake"
+}
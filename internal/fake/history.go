@@ -0,0 +1,28 @@
+package fake
+
+import (
+	"sync"
+
+	"voice-assistant/internal/history"
+)
+
+// History keeps turns in memory instead of the history database
+type History struct {
+	turns []history.Turn
+	mutex sync.Mutex
+}
+
+// Add records a finished turn
+func (h *History) Add(turn history.Turn) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.turns = append(h.turns, turn)
+	return nil
+}
+
+// Turns returns every turn recorded so far
+func (h *History) Turns() []history.Turn {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return append([]history.Turn(nil), h.turns...)
+}
@@ -0,0 +1,47 @@
+package fake
+
+import (
+	"sync"
+
+	"voice-assistant/internal/notify"
+)
+
+// Recorder collects what the pipeline says and shows. It is both a
+// Speaker and a Notifier.
+type Recorder struct {
+	spoken        []string
+	notifications []string
+	mutex         sync.Mutex
+}
+
+// Speak records a spoken response
+func (r *Recorder) Speak(text string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.spoken = append(r.spoken, text)
+}
+
+// Stop does nothing, since Speak returns at once
+func (r *Recorder) Stop() {}
+
+// Notify records a notification's message; actions aren't run
+func (r *Recorder) Notify(event notify.Event, message string, actions ...notify.Action) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.notifications = append(r.notifications, message)
+	return nil
+}
+
+// Spoken returns everything spoken so far
+func (r *Recorder) Spoken() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string(nil), r.spoken...)
+}
+
+// Notifications returns every notification so far
+func (r *Recorder) Notifications() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string(nil), r.notifications...)
+}
@@ -0,0 +1,125 @@
+// Package fake provides stand-ins for the speech, Claude and text-to-speech
+// services, so the pipeline can be driven without credentials, a
+// microphone or speakers.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/speech"
+)
+
+var _ speech.Provider = (*Speech)(nil)
+
+// Speech is a speech.Provider that recognizes whatever it is told to
+type Speech struct {
+	onRecognized func(speech.RecognitionResult)
+	onError      func(error)
+	listening    bool
+	starts       int
+	transcripts  map[int]string // Canned TranscribePCM results by sample count
+	mutex        sync.Mutex
+}
+
+// NewSpeech creates a fake speech provider
+func NewSpeech() *Speech {
+	return &Speech{transcripts: make(map[int]string)}
+}
+
+// Recognize delivers a final transcript as if it had been spoken. It
+// fails unless the provider is listening, like a real session.
+func (s *Speech) Recognize(text string) error {
+	s.mutex.Lock()
+	listening, onRecognized := s.listening, s.onRecognized
+	s.mutex.Unlock()
+
+	if !listening {
+		return fmt.Errorf("not listening")
+	}
+	if onRecognized != nil {
+		onRecognized(speech.RecognitionResult{Text: text, Lexical: text, Language: "en-US", Confidence: 1})
+	}
+	return nil
+}
+
+// Fail reports a recognition error as a real session would
+func (s *Speech) Fail(err error) {
+	s.mutex.Lock()
+	onError := s.onError
+	s.mutex.Unlock()
+
+	if onError != nil {
+		onError(err)
+	}
+}
+
+// SetTranscript makes TranscribePCM return text for audio of this length
+func (s *Speech) SetTranscript(samples []int16, text string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.transcripts[len(samples)] = text
+}
+
+// Starts returns how many sessions have been started
+func (s *Speech) Starts() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.starts
+}
+
+func (s *Speech) Name() string { return "Fake" }
+
+func (s *Speech) SetCallbacks(onRecognized func(result speech.RecognitionResult), onError func(error)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.onRecognized, s.onError = onRecognized, onError
+}
+
+func (s *Speech) SetTurnEndCallback(onTurnEnd func())                    {}
+func (s *Speech) SetHypothesisCallback(onHypothesis func(text string))   {}
+func (s *Speech) SetTurnAudioCallback(onTurnAudio func(samples []int16)) {}
+func (s *Speech) SetEchoGate(gate *audio.EchoGate)                       {}
+func (s *Speech) SetCaptureSource(source audio.Source) error             { return nil }
+func (s *Speech) SetProfanityFilter(filter *speech.ProfanityFilter)      {}
+func (s *Speech) SetMaxDuration(duration time.Duration)                  {}
+func (s *Speech) EnablePreRoll(duration time.Duration) error             { return nil }
+
+func (s *Speech) StartContinuousRecognition(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.listening = true
+	s.starts++
+	return nil
+}
+
+func (s *Speech) StopContinuousRecognition() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.listening = false
+	return nil
+}
+
+func (s *Speech) IsListening() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.listening
+}
+
+func (s *Speech) Reconnect(ctx context.Context) error { return nil }
+
+func (s *Speech) TranscribePCM(ctx context.Context, samples []int16) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	text, ok := s.transcripts[len(samples)]
+	if !ok {
+		return "", fmt.Errorf("no canned transcript for %d samples", len(samples))
+	}
+	return text, nil
+}
+
+func (s *Speech) TestConnection(ctx context.Context) error { return nil }
+func (s *Speech) Close()                                   {}
@@ -0,0 +1,52 @@
+package gui
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// MessageBox flags and results
+const (
+	MB_OK            = 0x00000000
+	MB_YESNO         = 0x00000004
+	MB_ICONINFO      = 0x00000040
+	MB_ICONWARNING   = 0x00000030
+	MB_SETFOREGROUND = 0x00010000
+	MB_TOPMOST       = 0x00040000
+	IDYES            = 6
+)
+
+var (
+	user32      = syscall.NewLazyDLL("user32.dll")
+	messageBoxW = user32.NewProc("MessageBoxW")
+)
+
+// Confirm shows a blocking Yes/No dialog and reports whether Yes was chosen
+func Confirm(title, message string) bool {
+	return messageBox(title, message, MB_YESNO|MB_ICONWARNING) == IDYES
+}
+
+// Info shows a blocking informational dialog
+func Info(title, message string) {
+	messageBox(title, message, MB_OK|MB_ICONINFO)
+}
+
+// messageBox shows a MessageBoxW dialog and returns the button chosen
+func messageBox(title, message string, flags uintptr) uintptr {
+	titlePtr, err := syscall.UTF16PtrFromString(title)
+	if err != nil {
+		return 0
+	}
+	messagePtr, err := syscall.UTF16PtrFromString(message)
+	if err != nil {
+		return 0
+	}
+
+	ret, _, _ := messageBoxW.Call(
+		0,
+		uintptr(unsafe.Pointer(messagePtr)),
+		uintptr(unsafe.Pointer(titlePtr)),
+		flags|MB_SETFOREGROUND|MB_TOPMOST,
+	)
+	return ret
+}
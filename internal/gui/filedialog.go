@@ -0,0 +1,90 @@
+package gui
+
+import (
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+)
+
+// GetOpenFileName flags
+const (
+	OFN_NOCHANGEDIR     = 0x00000008
+	OFN_PATHMUSTEXIST   = 0x00000800
+	OFN_FILEMUSTEXIST   = 0x00001000
+	OFN_EXPLORER        = 0x00080000
+	maxFileDialogBuffer = 32768
+)
+
+var (
+	comdlg32         = syscall.NewLazyDLL("comdlg32.dll")
+	getOpenFileNameW = comdlg32.NewProc("GetOpenFileNameW")
+)
+
+// openFileName mirrors the Win32 OPENFILENAMEW structure
+type openFileName struct {
+	structSize      uint32
+	owner           uintptr
+	instance        uintptr
+	filter          *uint16
+	customFilter    *uint16
+	maxCustomFilter uint32
+	filterIndex     uint32
+	file            *uint16
+	maxFile         uint32
+	fileTitle       *uint16
+	maxFileTitle    uint32
+	initialDir      *uint16
+	title           *uint16
+	flags           uint32
+	fileOffset      uint16
+	fileExtension   uint16
+	defExt          *uint16
+	custData        uintptr
+	hook            uintptr
+	templateName    *uint16
+	reserved        uintptr
+	reserved2       uint32
+	flagsEx         uint32
+}
+
+// FileFilter is one entry of a file dialog's type list, e.g.
+// {"Audio files", "*.wav;*.mp3"}
+type FileFilter struct {
+	Name    string
+	Pattern string
+}
+
+// OpenFile shows the standard open dialog and returns the chosen path,
+// or false when the user cancels
+func OpenFile(title string, filters []FileFilter) (string, bool) {
+	titlePtr, err := syscall.UTF16PtrFromString(title)
+	if err != nil {
+		return "", false
+	}
+
+	// The filter is a list of NUL-separated pairs ending in a double NUL
+	var filter []uint16
+	for _, f := range filters {
+		filter = append(filter, utf16.Encode([]rune(f.Name))...)
+		filter = append(filter, 0)
+		filter = append(filter, utf16.Encode([]rune(f.Pattern))...)
+		filter = append(filter, 0)
+	}
+	filter = append(filter, 0, 0)
+
+	file := make([]uint16, maxFileDialogBuffer)
+	ofn := openFileName{
+		filter:  &filter[0],
+		file:    &file[0],
+		maxFile: uint32(len(file)),
+		title:   titlePtr,
+		flags:   OFN_EXPLORER | OFN_FILEMUSTEXIST | OFN_PATHMUSTEXIST | OFN_NOCHANGEDIR,
+	}
+	ofn.structSize = uint32(unsafe.Sizeof(ofn))
+
+	ret, _, _ := getOpenFileNameW.Call(uintptr(unsafe.Pointer(&ofn)))
+	if ret == 0 {
+		return "", false
+	}
+	return syscall.UTF16ToString(file), true
+}
@@ -0,0 +1,289 @@
+package gui
+
+import (
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Overlay positions
+const (
+	OverlayCorner = "corner" // Bottom-right of the screen the cursor is on
+	OverlayCursor = "cursor" // Just below the mouse cursor
+)
+
+// Window styles and messages used by the overlay
+const (
+	WS_POPUP          = 0x80000000
+	WS_EX_TOOLWINDOW  = 0x00000080
+	WS_EX_TRANSPARENT = 0x00000020
+	WS_EX_LAYERED     = 0x00080000
+	WS_EX_NOACTIVATE  = 0x08000000
+	LWA_ALPHA         = 0x2
+	SW_HIDE           = 0
+	SW_SHOWNOACTIVATE = 4
+	SWP_NOACTIVATE    = 0x0010
+	HWND_TOPMOST      = ^uintptr(0)
+	MONITOR_NEAREST   = 2
+	WM_PAINT          = 0x000F
+	WM_APP            = 0x8000
+	TRANSPARENT       = 1
+	FW_NORMAL         = 400
+	DT_WORDBREAK      = 0x0010
+	DT_NOPREFIX       = 0x0800
+	DT_EDITCONTROL    = 0x2000
+	DT_END_ELLIPSIS   = 0x8000
+
+	overlayClass   = "VoiceAssistantOverlay"
+	overlayWidth   = 520
+	overlayHeight  = 110
+	overlayMargin  = 16
+	overlayPadding = 14
+	overlayAlpha   = 230
+	overlayFontPx  = 22
+	overlayMeterPx = 4
+	overlayShow    = WM_APP + 1
+	overlayHide    = WM_APP + 2
+)
+
+var (
+	gdi32            = syscall.NewLazyDLL("gdi32.dll")
+	createSolidBrush = gdi32.NewProc("CreateSolidBrush")
+	createFontW      = gdi32.NewProc("CreateFontW")
+	selectObject     = gdi32.NewProc("SelectObject")
+	setTextColor     = gdi32.NewProc("SetTextColor")
+	setBkMode        = gdi32.NewProc("SetBkMode")
+
+	beginPaint                 = user32.NewProc("BeginPaint")
+	endPaint                   = user32.NewProc("EndPaint")
+	fillRect                   = user32.NewProc("FillRect")
+	drawTextW                  = user32.NewProc("DrawTextW")
+	invalidateRect             = user32.NewProc("InvalidateRect")
+	showWindow                 = user32.NewProc("ShowWindow")
+	setWindowPos               = user32.NewProc("SetWindowPos")
+	postMessageW               = user32.NewProc("PostMessageW")
+	getCursorPos               = user32.NewProc("GetCursorPos")
+	monitorFromRect            = user32.NewProc("MonitorFromRect")
+	getMonitorInfoW            = user32.NewProc("GetMonitorInfoW")
+	setLayeredWindowAttributes = user32.NewProc("SetLayeredWindowAttributes")
+)
+
+// rect mirrors RECT
+type rect struct {
+	left, top, right, bottom int32
+}
+
+// paintStruct mirrors PAINTSTRUCT
+type paintStruct struct {
+	hdc       uintptr
+	erase     int32
+	paint     rect
+	restore   int32
+	incUpdate int32
+	reserved  [32]byte
+}
+
+// monitorInfo mirrors MONITORINFO
+type monitorInfo struct {
+	size    uint32
+	monitor rect
+	work    rect
+	flags   uint32
+}
+
+// The overlay window lives on its own thread; Show and Hide post to it
+var (
+	overlayOnce     sync.Once
+	overlayReady    = make(chan struct{})
+	overlayWindow   uintptr
+	overlayMutex    sync.Mutex
+	overlayText     string
+	overlayPosition string
+	overlayLevel    float64 // Input level drawn along the bottom edge, 0 hides the meter
+	overlayBrush    uintptr
+	overlayMeter    uintptr
+	overlayFont     uintptr
+)
+
+// ShowOverlay shows text in a small always-on-top bar that never takes
+// focus or clicks, creating it the first time
+func ShowOverlay(text, position string) {
+	overlayOnce.Do(func() { go runOverlay() })
+	<-overlayReady
+	if overlayWindow == 0 {
+		return
+	}
+
+	overlayMutex.Lock()
+	overlayText, overlayPosition = text, position
+	overlayMutex.Unlock()
+	postMessageW.Call(overlayWindow, overlayShow, 0, 0)
+}
+
+// HideOverlay hides the overlay if it is showing
+func HideOverlay() {
+	select {
+	case <-overlayReady:
+	default:
+		return // Never shown
+	}
+	if overlayWindow != 0 {
+		postMessageW.Call(overlayWindow, overlayHide, 0, 0)
+	}
+}
+
+// SetOverlayLevel updates the input level meter along the bottom of the
+// overlay, from 0 to 1
+func SetOverlayLevel(level float64) {
+	overlayMutex.Lock()
+	changed := level != overlayLevel
+	overlayLevel = level
+	overlayMutex.Unlock()
+
+	select {
+	case <-overlayReady:
+		if changed && overlayWindow != 0 {
+			invalidateRect.Call(overlayWindow, 0, 0)
+		}
+	default:
+	}
+}
+
+// runOverlay creates the overlay window and runs its message loop
+func runOverlay() {
+	// Windows and their message loop belong to the creating thread
+	runtime.LockOSThread()
+
+	instance, _, _ := getModuleHandleW.Call(0)
+	classPtr, _ := syscall.UTF16PtrFromString(overlayClass)
+	overlayBrush, _, _ = createSolidBrush.Call(0x202020)
+	overlayMeter, _, _ = createSolidBrush.Call(0x50C878) // BGR green
+	class := wndClassEx{
+		wndProc:    syscall.NewCallback(overlayProc),
+		instance:   instance,
+		background: overlayBrush,
+		className:  classPtr,
+	}
+	class.size = uint32(unsafe.Sizeof(class))
+	registerClassExW.Call(uintptr(unsafe.Pointer(&class)))
+
+	// A negative height asks for the character height rather than the cell
+	fontName, _ := syscall.UTF16PtrFromString("Segoe UI")
+	fontHeight := int32(-overlayFontPx)
+	overlayFont, _, _ = createFontW.Call(uintptr(fontHeight), 0, 0, 0, FW_NORMAL, 0, 0, 0, 0, 0, 0, 0, 0, uintptr(unsafe.Pointer(fontName)))
+
+	overlayWindow, _, _ = createWindowExW.Call(
+		WS_EX_TOPMOST|WS_EX_TOOLWINDOW|WS_EX_NOACTIVATE|WS_EX_LAYERED|WS_EX_TRANSPARENT,
+		uintptr(unsafe.Pointer(classPtr)),
+		0,
+		WS_POPUP,
+		0, 0, overlayWidth, overlayHeight,
+		0, 0, instance, 0,
+	)
+	if overlayWindow != 0 {
+		setLayeredWindowAttributes.Call(overlayWindow, 0, overlayAlpha, LWA_ALPHA)
+	}
+	close(overlayReady)
+	if overlayWindow == 0 {
+		return
+	}
+
+	var m msg
+	for {
+		ret, _, _ := getMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+		if ret == 0 || int32(ret) == -1 {
+			return
+		}
+		translateMessage.Call(uintptr(unsafe.Pointer(&m)))
+		dispatchMessageW.Call(uintptr(unsafe.Pointer(&m)))
+	}
+}
+
+// overlayProc is the overlay window procedure
+func overlayProc(hwnd, message, wParam, lParam uintptr) uintptr {
+	switch message {
+	case overlayShow:
+		overlayMutex.Lock()
+		position := overlayPosition
+		overlayMutex.Unlock()
+		x, y := overlayOrigin(position)
+		setWindowPos.Call(hwnd, HWND_TOPMOST, uintptr(x), uintptr(y), overlayWidth, overlayHeight, SWP_NOACTIVATE)
+		showWindow.Call(hwnd, SW_SHOWNOACTIVATE)
+		invalidateRect.Call(hwnd, 0, 1)
+		return 0
+	case overlayHide:
+		showWindow.Call(hwnd, SW_HIDE)
+		return 0
+	case WM_PAINT:
+		paintOverlay(hwnd)
+		return 0
+	}
+	ret, _, _ := defWindowProcW.Call(hwnd, message, wParam, lParam)
+	return ret
+}
+
+// paintOverlay draws the current text in white on the dark background
+func paintOverlay(hwnd uintptr) {
+	overlayMutex.Lock()
+	text, level := overlayText, overlayLevel
+	overlayMutex.Unlock()
+
+	var ps paintStruct
+	hdc, _, _ := beginPaint.Call(hwnd, uintptr(unsafe.Pointer(&ps)))
+	defer endPaint.Call(hwnd, uintptr(unsafe.Pointer(&ps)))
+
+	bounds := rect{right: overlayWidth, bottom: overlayHeight}
+	fillRect.Call(hdc, uintptr(unsafe.Pointer(&bounds)), overlayBrush)
+	if level > 0 {
+		meter := rect{top: overlayHeight - overlayMeterPx, right: int32(level * overlayWidth), bottom: overlayHeight}
+		fillRect.Call(hdc, uintptr(unsafe.Pointer(&meter)), overlayMeter)
+	}
+
+	selectObject.Call(hdc, overlayFont)
+	setTextColor.Call(hdc, 0xFFFFFF)
+	setBkMode.Call(hdc, TRANSPARENT)
+
+	textPtr, err := syscall.UTF16FromString(text)
+	if err != nil || len(textPtr) < 2 {
+		return
+	}
+	area := rect{left: overlayPadding, top: overlayPadding, right: overlayWidth - overlayPadding, bottom: overlayHeight - overlayPadding}
+	drawTextW.Call(hdc, uintptr(unsafe.Pointer(&textPtr[0])), uintptr(len(textPtr)-1), uintptr(unsafe.Pointer(&area)),
+		DT_WORDBREAK|DT_NOPREFIX|DT_EDITCONTROL|DT_END_ELLIPSIS)
+}
+
+// overlayOrigin places the overlay within the work area of the monitor the
+// cursor is on, either in its bottom-right corner or just below the cursor
+func overlayOrigin(position string) (int32, int32) {
+	var cursor struct{ x, y int32 }
+	getCursorPos.Call(uintptr(unsafe.Pointer(&cursor)))
+
+	point := rect{left: cursor.x, top: cursor.y, right: cursor.x + 1, bottom: cursor.y + 1}
+	monitor, _, _ := monitorFromRect.Call(uintptr(unsafe.Pointer(&point)), MONITOR_NEAREST)
+	info := monitorInfo{}
+	info.size = uint32(unsafe.Sizeof(info))
+	getMonitorInfoW.Call(monitor, uintptr(unsafe.Pointer(&info)))
+	work := info.work
+
+	x := work.right - overlayWidth - overlayMargin
+	y := work.bottom - overlayHeight - overlayMargin
+	if position == OverlayCursor {
+		x, y = cursor.x-overlayWidth/2, cursor.y+24
+	}
+
+	// Keep the whole bar on screen
+	if x+overlayWidth > work.right {
+		x = work.right - overlayWidth
+	}
+	if x < work.left {
+		x = work.left
+	}
+	if y+overlayHeight > work.bottom {
+		y = cursor.y - overlayHeight - 24
+	}
+	if y < work.top {
+		y = work.top
+	}
+	return x, y
+}
@@ -0,0 +1,221 @@
+package gui
+
+import (
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Window styles, messages and control IDs used by the prompt window
+const (
+	WS_OVERLAPPED    = 0x00000000
+	WS_CAPTION       = 0x00C00000
+	WS_SYSMENU       = 0x00080000
+	WS_VISIBLE       = 0x10000000
+	WS_CHILD         = 0x40000000
+	WS_BORDER        = 0x00800000
+	WS_TABSTOP       = 0x00010000
+	WS_EX_TOPMOST    = 0x00000008
+	ES_AUTOHSCROLL   = 0x0080
+	BS_DEFPUSHBUTTON = 0x0001
+	CW_USEDEFAULT    = 0x80000000
+	COLOR_BTNFACE    = 15
+
+	WM_DESTROY = 0x0002
+	WM_CLOSE   = 0x0010
+	WM_COMMAND = 0x0111
+
+	IDOK     = 1
+	IDCANCEL = 2
+
+	promptEditID = 100
+	promptClass  = "VoiceAssistantPrompt"
+)
+
+var (
+	kernel32         = syscall.NewLazyDLL("kernel32.dll")
+	getModuleHandleW = kernel32.NewProc("GetModuleHandleW")
+
+	registerClassExW    = user32.NewProc("RegisterClassExW")
+	createWindowExW     = user32.NewProc("CreateWindowExW")
+	destroyWindow       = user32.NewProc("DestroyWindow")
+	defWindowProcW      = user32.NewProc("DefWindowProcW")
+	getMessageW         = user32.NewProc("GetMessageW")
+	isDialogMessageW    = user32.NewProc("IsDialogMessageW")
+	translateMessage    = user32.NewProc("TranslateMessage")
+	dispatchMessageW    = user32.NewProc("DispatchMessageW")
+	postQuitMessage     = user32.NewProc("PostQuitMessage")
+	getWindowTextW      = user32.NewProc("GetWindowTextW")
+	getWindowTextLength = user32.NewProc("GetWindowTextLengthW")
+	setForegroundWindow = user32.NewProc("SetForegroundWindow")
+	setFocus            = user32.NewProc("SetFocus")
+)
+
+// wndClassEx mirrors WNDCLASSEXW
+type wndClassEx struct {
+	size       uint32
+	style      uint32
+	wndProc    uintptr
+	clsExtra   int32
+	wndExtra   int32
+	instance   uintptr
+	icon       uintptr
+	cursor     uintptr
+	background uintptr
+	menuName   *uint16
+	className  *uint16
+	iconSmall  uintptr
+}
+
+// msg mirrors MSG
+type msg struct {
+	hwnd    uintptr
+	message uint32
+	wParam  uintptr
+	lParam  uintptr
+	time    uint32
+	x, y    int32
+}
+
+// One prompt is shown at a time; the window procedure reports through these
+var (
+	promptMutex       sync.Mutex
+	promptOnce        sync.Once
+	promptRegisterErr error
+	promptEdit        uintptr
+	promptText        string
+	promptAccepted    bool
+)
+
+// Prompt shows a small window asking for one line of text and blocks until
+// it is closed. It reports false when the user cancels.
+func Prompt(title, label string) (string, bool) {
+	promptMutex.Lock()
+	defer promptMutex.Unlock()
+
+	// Windows and their message loop belong to the creating thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	instance, _, _ := getModuleHandleW.Call(0)
+	promptOnce.Do(func() { promptRegisterErr = registerPromptClass(instance) })
+	if promptRegisterErr != nil {
+		return "", false
+	}
+
+	classPtr, _ := syscall.UTF16PtrFromString(promptClass)
+	titlePtr, err := syscall.UTF16PtrFromString(title)
+	if err != nil {
+		return "", false
+	}
+
+	window, _, _ := createWindowExW.Call(
+		WS_EX_TOPMOST,
+		uintptr(unsafe.Pointer(classPtr)),
+		uintptr(unsafe.Pointer(titlePtr)),
+		WS_OVERLAPPED|WS_CAPTION|WS_SYSMENU|WS_VISIBLE,
+		CW_USEDEFAULT, CW_USEDEFAULT, 420, 150,
+		0, 0, instance, 0,
+	)
+	if window == 0 {
+		return "", false
+	}
+
+	promptText, promptAccepted = "", false
+	createControl(window, instance, "STATIC", label, WS_CHILD|WS_VISIBLE, 12, 12, 380, 20, 0)
+	promptEdit = createControl(window, instance, "EDIT", "", WS_CHILD|WS_VISIBLE|WS_BORDER|WS_TABSTOP|ES_AUTOHSCROLL, 12, 36, 380, 24, promptEditID)
+	createControl(window, instance, "BUTTON", "OK", WS_CHILD|WS_VISIBLE|WS_TABSTOP|BS_DEFPUSHBUTTON, 222, 72, 80, 26, IDOK)
+	createControl(window, instance, "BUTTON", "Cancel", WS_CHILD|WS_VISIBLE|WS_TABSTOP, 312, 72, 80, 26, IDCANCEL)
+
+	setForegroundWindow.Call(window)
+	setFocus.Call(promptEdit)
+
+	// IsDialogMessage turns Enter into OK, Escape into Cancel and handles Tab
+	var m msg
+	for {
+		ret, _, _ := getMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+		if ret == 0 || int32(ret) == -1 {
+			break
+		}
+		handled, _, _ := isDialogMessageW.Call(window, uintptr(unsafe.Pointer(&m)))
+		if handled == 0 {
+			translateMessage.Call(uintptr(unsafe.Pointer(&m)))
+			dispatchMessageW.Call(uintptr(unsafe.Pointer(&m)))
+		}
+	}
+
+	return promptText, promptAccepted
+}
+
+// registerPromptClass registers the prompt window class once per process
+func registerPromptClass(instance uintptr) error {
+	classPtr, err := syscall.UTF16PtrFromString(promptClass)
+	if err != nil {
+		return err
+	}
+
+	class := wndClassEx{
+		wndProc:    syscall.NewCallback(promptProc),
+		instance:   instance,
+		background: COLOR_BTNFACE + 1,
+		className:  classPtr,
+	}
+	class.size = uint32(unsafe.Sizeof(class))
+
+	ret, _, err := registerClassExW.Call(uintptr(unsafe.Pointer(&class)))
+	if ret == 0 {
+		return err
+	}
+	return nil
+}
+
+// createControl adds a child control to the prompt window
+func createControl(parent, instance uintptr, class, text string, style uintptr, x, y, width, height int, id uintptr) uintptr {
+	classPtr, _ := syscall.UTF16PtrFromString(class)
+	textPtr, _ := syscall.UTF16PtrFromString(text)
+	control, _, _ := createWindowExW.Call(
+		0,
+		uintptr(unsafe.Pointer(classPtr)),
+		uintptr(unsafe.Pointer(textPtr)),
+		style,
+		uintptr(x), uintptr(y), uintptr(width), uintptr(height),
+		parent, id, instance, 0,
+	)
+	return control
+}
+
+// promptProc is the prompt window procedure
+func promptProc(hwnd, message, wParam, lParam uintptr) uintptr {
+	switch message {
+	case WM_COMMAND:
+		switch wParam & 0xFFFF {
+		case IDOK:
+			promptText = windowText(promptEdit)
+			promptAccepted = true
+			destroyWindow.Call(hwnd)
+		case IDCANCEL:
+			destroyWindow.Call(hwnd)
+		}
+		return 0
+	case WM_CLOSE:
+		destroyWindow.Call(hwnd)
+		return 0
+	case WM_DESTROY:
+		postQuitMessage.Call(0)
+		return 0
+	}
+	ret, _, _ := defWindowProcW.Call(hwnd, message, wParam, lParam)
+	return ret
+}
+
+// windowText reads the text of a control
+func windowText(hwnd uintptr) string {
+	length, _, _ := getWindowTextLength.Call(hwnd)
+	if length == 0 {
+		return ""
+	}
+	buffer := make([]uint16, length+1)
+	getWindowTextW.Call(hwnd, uintptr(unsafe.Pointer(&buffer[0])), uintptr(len(buffer)))
+	return syscall.UTF16ToString(buffer)
+}
@@ -0,0 +1,41 @@
+package gui
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const SW_SHOWNORMAL = 1
+
+var (
+	shell32       = syscall.NewLazyDLL("shell32.dll")
+	shellExecuteW = shell32.NewProc("ShellExecuteW")
+)
+
+// Open opens a URL, file, or application with its default handler
+func Open(target string) error {
+	verbPtr, err := syscall.UTF16PtrFromString("open")
+	if err != nil {
+		return err
+	}
+	targetPtr, err := syscall.UTF16PtrFromString(target)
+	if err != nil {
+		return err
+	}
+
+	ret, _, _ := shellExecuteW.Call(
+		0,
+		uintptr(unsafe.Pointer(verbPtr)),
+		uintptr(unsafe.Pointer(targetPtr)),
+		0,
+		0,
+		SW_SHOWNORMAL,
+	)
+
+	// ShellExecute returns a value greater than 32 on success
+	if ret <= 32 {
+		return fmt.Errorf("failed to open %s (code %d)", target, ret)
+	}
+	return nil
+}
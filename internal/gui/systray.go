@@ -1 +1,57 @@
 package gui
+
+import (
+	"github.com/getlantern/systray"
+)
+
+const (
+	DefaultTitle   = "AI Assistant"
+	DefaultTooltip = "AI Desktop Assistant - Press F12 to start"
+)
+
+// trayDisabled is set when running without a tray icon
+var trayDisabled bool
+
+// recordingLabel is what SetRecordingIndicator is showing, if anything
+var recordingLabel string
+
+// DisableTray turns the tray helpers into no-ops for headless runs
+func DisableTray() {
+	trayDisabled = true
+}
+
+// SetRecordingIndicator shows or clears a persistent "recording" marker in
+// the tray title and tooltip while a long-form capture session is running
+func SetRecordingIndicator(active bool, label string) {
+	if trayDisabled {
+		return
+	}
+	if !active {
+		recordingLabel = ""
+		systray.SetTitle(DefaultTitle)
+		systray.SetTooltip(DefaultTooltip)
+		return
+	}
+
+	recordingLabel = label
+	systray.SetTitle("● REC - " + DefaultTitle)
+	systray.SetTooltip("🔴 Recording: " + label)
+}
+
+// SetInputLevel shows a microphone level bar in the tooltip; an empty bar
+// puts back the usual tooltip
+func SetInputLevel(bar string) {
+	if trayDisabled {
+		return
+	}
+	switch {
+	case bar == "" && recordingLabel != "":
+		systray.SetTooltip("🔴 Recording: " + recordingLabel)
+	case bar == "":
+		systray.SetTooltip(DefaultTooltip)
+	case recordingLabel != "":
+		systray.SetTooltip("🔴 Recording: " + recordingLabel + "\n🎤 " + bar)
+	default:
+		systray.SetTooltip("🎤 Listening " + bar)
+	}
+}
@@ -0,0 +1,125 @@
+package gui
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Styles used by the text window
+const (
+	WS_VSCROLL     = 0x00200000
+	ES_MULTILINE   = 0x0004
+	ES_AUTOVSCROLL = 0x0040
+	ES_READONLY    = 0x0800
+
+	textClass = "VoiceAssistantText"
+)
+
+var (
+	textMutex       sync.Mutex
+	textOnce        sync.Once
+	textRegisterErr error
+)
+
+// ShowText shows read-only text in a scrollable window and blocks until it
+// is closed. Nothing is written to disk, so it suits private text.
+func ShowText(title, text string) error {
+	textMutex.Lock()
+	defer textMutex.Unlock()
+
+	// Windows and their message loop belong to the creating thread
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	instance, _, _ := getModuleHandleW.Call(0)
+	textOnce.Do(func() { textRegisterErr = registerTextClass(instance) })
+	if textRegisterErr != nil {
+		return textRegisterErr
+	}
+
+	classPtr, _ := syscall.UTF16PtrFromString(textClass)
+	titlePtr, err := syscall.UTF16PtrFromString(title)
+	if err != nil {
+		return err
+	}
+
+	window, _, err := createWindowExW.Call(
+		WS_EX_TOPMOST,
+		uintptr(unsafe.Pointer(classPtr)),
+		uintptr(unsafe.Pointer(titlePtr)),
+		WS_OVERLAPPED|WS_CAPTION|WS_SYSMENU|WS_VISIBLE,
+		CW_USEDEFAULT, CW_USEDEFAULT, 640, 480,
+		0, 0, instance, 0,
+	)
+	if window == 0 {
+		return err
+	}
+
+	// Edit controls only break lines at CRLF
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")
+	createControl(window, instance, "EDIT", text, WS_CHILD|WS_VISIBLE|WS_BORDER|WS_VSCROLL|WS_TABSTOP|ES_MULTILINE|ES_AUTOVSCROLL|ES_READONLY, 12, 12, 600, 380, 0)
+	closeButton := createControl(window, instance, "BUTTON", "Close", WS_CHILD|WS_VISIBLE|WS_TABSTOP|BS_DEFPUSHBUTTON, 532, 402, 80, 26, IDOK)
+
+	setForegroundWindow.Call(window)
+	setFocus.Call(closeButton)
+
+	var m msg
+	for {
+		ret, _, _ := getMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+		if ret == 0 || int32(ret) == -1 {
+			break
+		}
+		handled, _, _ := isDialogMessageW.Call(window, uintptr(unsafe.Pointer(&m)))
+		if handled == 0 {
+			translateMessage.Call(uintptr(unsafe.Pointer(&m)))
+			dispatchMessageW.Call(uintptr(unsafe.Pointer(&m)))
+		}
+	}
+	return nil
+}
+
+// registerTextClass registers the text window class once per process
+func registerTextClass(instance uintptr) error {
+	classPtr, err := syscall.UTF16PtrFromString(textClass)
+	if err != nil {
+		return err
+	}
+
+	class := wndClassEx{
+		wndProc:    syscall.NewCallback(textProc),
+		instance:   instance,
+		background: COLOR_BTNFACE + 1,
+		className:  classPtr,
+	}
+	class.size = uint32(unsafe.Sizeof(class))
+
+	ret, _, err := registerClassExW.Call(uintptr(unsafe.Pointer(&class)))
+	if ret == 0 {
+		return err
+	}
+	return nil
+}
+
+// textProc is the text window procedure; Close, Escape and the title bar
+// button all close it
+func textProc(hwnd, message, wParam, lParam uintptr) uintptr {
+	switch message {
+	case WM_COMMAND:
+		switch wParam & 0xFFFF {
+		case IDOK, IDCANCEL:
+			destroyWindow.Call(hwnd)
+		}
+		return 0
+	case WM_CLOSE:
+		destroyWindow.Call(hwnd)
+		return 0
+	case WM_DESTROY:
+		postQuitMessage.Call(0)
+		return 0
+	}
+	ret, _, _ := defWindowProcW.Call(hwnd, message, wParam, lParam)
+	return ret
+}
@@ -0,0 +1,252 @@
+// Package history stores every conversation turn in a local SQLite
+// database so past answers can be browsed and searched.
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS turns (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	time       INTEGER NOT NULL,
+	transcript TEXT NOT NULL,
+	response   TEXT NOT NULL,
+	model      TEXT NOT NULL DEFAULT '',
+	thinking   TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS turns_time ON turns(time);
+`
+
+// columns lists what each query reads, in Turn order
+const columns = "id, time, transcript, response, model, thinking"
+
+// Turn is one question and Claude's answer
+type Turn struct {
+	ID         int64
+	Time       time.Time
+	Transcript string
+	Response   string
+	Model      string
+	Thinking   string // Claude's reasoning when extended thinking was on
+}
+
+// Cipher encrypts transcripts and responses before they are written
+type Cipher interface {
+	Seal(plain string) (string, error)
+	Open(value string) (string, error)
+}
+
+// Store is the history database
+type Store struct {
+	db     *sql.DB
+	cipher Cipher // Nil stores text as is
+}
+
+// Open opens or creates the history database at path
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %v", err)
+	}
+	// SQLite allows one writer; a single connection avoids "database is locked"
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(schema)
+	if err == nil {
+		err = migrate(db)
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create history tables: %v", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// migrate adds columns that databases from older versions lack
+func migrate(db *sql.DB) error {
+	var found int
+	err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('turns') WHERE name = 'thinking'").Scan(&found)
+	if err != nil || found > 0 {
+		return err
+	}
+	_, err = db.Exec("ALTER TABLE turns ADD COLUMN thinking TEXT NOT NULL DEFAULT ''")
+	return err
+}
+
+// SetCipher encrypts turns written from now on. Turns already stored in
+// plain text are encrypted too, and stay readable throughout.
+func (s *Store) SetCipher(cipher Cipher) error {
+	// Read the rows as stored, before the cipher would decrypt them
+	s.cipher = nil
+	turns, err := s.query("SELECT " + columns + " FROM turns")
+	s.cipher = cipher
+	if err != nil {
+		return err
+	}
+
+	for _, turn := range turns {
+		stored := turn
+		err = s.seal(&turn)
+		if err != nil {
+			return err
+		}
+		if turn == stored {
+			continue
+		}
+		_, err = s.db.Exec("UPDATE turns SET transcript = ?, response = ?, thinking = ? WHERE id = ?", turn.Transcript, turn.Response, turn.Thinking, turn.ID)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt history: %v", err)
+		}
+	}
+	return nil
+}
+
+// Add records a turn
+func (s *Store) Add(turn Turn) error {
+	if turn.Time.IsZero() {
+		turn.Time = time.Now()
+	}
+	err := s.seal(&turn)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec("INSERT INTO turns (time, transcript, response, model, thinking) VALUES (?, ?, ?, ?, ?)",
+		turn.Time.Unix(), turn.Transcript, turn.Response, turn.Model, turn.Thinking)
+	if err != nil {
+		return fmt.Errorf("failed to save turn: %v", err)
+	}
+	return nil
+}
+
+// Recent returns the latest turns, newest first
+func (s *Store) Recent(limit int) ([]Turn, error) {
+	return s.query("SELECT "+columns+" FROM turns ORDER BY id DESC LIMIT ?", limit)
+}
+
+// Search returns turns whose transcript or response contains text, newest
+// first. Encrypted history can't be searched by SQLite, so it is scanned.
+func (s *Store) Search(text string, limit int) ([]Turn, error) {
+	if s.cipher != nil {
+		return s.scan(text, limit)
+	}
+
+	pattern := "%" + escapeLike(text) + "%"
+	return s.query(`SELECT `+columns+` FROM turns
+		WHERE transcript LIKE ? ESCAPE '\' OR response LIKE ? ESCAPE '\'
+		ORDER BY id DESC LIMIT ?`, pattern, pattern, limit)
+}
+
+// Clear deletes every turn and returns how many there were
+func (s *Store) Clear() (int64, error) {
+	result, err := s.db.Exec("DELETE FROM turns")
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear history: %v", err)
+	}
+	return result.RowsAffected()
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// scan searches decrypted turns in Go
+func (s *Store) scan(text string, limit int) ([]Turn, error) {
+	turns, err := s.query("SELECT " + columns + " FROM turns ORDER BY id DESC")
+	if err != nil {
+		return nil, err
+	}
+
+	text = strings.ToLower(text)
+	var matches []Turn
+	for _, turn := range turns {
+		if strings.Contains(strings.ToLower(turn.Transcript), text) || strings.Contains(strings.ToLower(turn.Response), text) {
+			matches = append(matches, turn)
+			if len(matches) == limit {
+				break
+			}
+		}
+	}
+	return matches, nil
+}
+
+// seal encrypts the text of a turn when a cipher is set
+func (s *Store) seal(turn *Turn) error {
+	if s.cipher == nil {
+		return nil
+	}
+
+	var err error
+	turn.Transcript, err = s.cipher.Seal(turn.Transcript)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt turn: %v", err)
+	}
+	turn.Response, err = s.cipher.Seal(turn.Response)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt turn: %v", err)
+	}
+	turn.Thinking, err = s.cipher.Seal(turn.Thinking)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt turn: %v", err)
+	}
+	return nil
+}
+
+// open decrypts the text of a turn when a cipher is set
+func (s *Store) open(turn *Turn) error {
+	if s.cipher == nil {
+		return nil
+	}
+
+	var err error
+	turn.Transcript, err = s.cipher.Open(turn.Transcript)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt history: %v", err)
+	}
+	turn.Response, err = s.cipher.Open(turn.Response)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt history: %v", err)
+	}
+	turn.Thinking, err = s.cipher.Open(turn.Thinking)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt history: %v", err)
+	}
+	return nil
+}
+
+// query runs a select over turns
+func (s *Store) query(query string, args ...interface{}) ([]Turn, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %v", err)
+	}
+	defer rows.Close()
+
+	var turns []Turn
+	for rows.Next() {
+		var turn Turn
+		var unix int64
+		err = rows.Scan(&turn.ID, &unix, &turn.Transcript, &turn.Response, &turn.Model, &turn.Thinking)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read history: %v", err)
+		}
+		turn.Time = time.Unix(unix, 0)
+		err = s.open(&turn)
+		if err != nil {
+			return nil, err
+		}
+		turns = append(turns, turn)
+	}
+	return turns, rows.Err()
+}
+
+// escapeLike makes LIKE wildcards in text match literally
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+}
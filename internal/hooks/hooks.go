@@ -0,0 +1,119 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Events hooks can subscribe to
+const (
+	EventTranscript = "on_transcript"
+	EventResponse   = "on_response"
+	EventError      = "on_error"
+)
+
+// Hook is an HTTP endpoint or a local program to notify
+type Hook struct {
+	URL     string
+	Command string
+	Args    []string
+}
+
+// Payload is the JSON body POSTed to HTTP hooks
+type Payload struct {
+	Event string    `json:"event"`
+	Text  string    `json:"text"`
+	Time  time.Time `json:"time"`
+}
+
+// Runner fires the hooks registered for each event
+type Runner struct {
+	hooks   map[string][]Hook
+	timeout time.Duration
+	client  *http.Client
+}
+
+// NewRunner creates a runner for hooks keyed by event
+func NewRunner(hooks map[string][]Hook, timeout time.Duration) *Runner {
+	return &Runner{
+		hooks:   hooks,
+		timeout: timeout,
+		client:  &http.Client{},
+	}
+}
+
+// Fire runs every hook for an event in the background; failures are logged
+// and never affect the assistant
+func (r *Runner) Fire(event, text string) {
+	for _, hook := range r.hooks[event] {
+		go func(hook Hook) {
+			ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+			defer cancel()
+
+			err := r.run(ctx, hook, event, text)
+			if err != nil {
+				log.Printf("⚠️  %s hook failed: %v", event, err)
+			}
+		}(hook)
+	}
+}
+
+// run delivers the event to one hook
+func (r *Runner) run(ctx context.Context, hook Hook, event, text string) error {
+	if hook.URL != "" {
+		return r.post(ctx, hook.URL, Payload{Event: event, Text: text, Time: time.Now()})
+	}
+	if hook.Command != "" {
+		return execute(ctx, hook, event, text)
+	}
+	return fmt.Errorf("hook has neither a url nor a command")
+}
+
+// post sends the payload as JSON
+func (r *Runner) post(ctx context.Context, url string, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+// execute runs a program with the text as its last argument and on stdin;
+// VA_EVENT names the event
+func execute(ctx context.Context, hook Hook, event, text string) error {
+	args := append(append([]string(nil), hook.Args...), text)
+	cmd := exec.CommandContext(ctx, hook.Command, args...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Env = append(os.Environ(), "VA_EVENT="+event)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %v %s", hook.Command, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -10,6 +10,12 @@ const (
 	VK_F12  = 0x7B
 	VK_Q    = 0x51
 	VK_CTRL = 0x11
+	VK_ALT  = 0x12
+	VK_C    = 0x43
+	VK_P    = 0x50
+	VK_R    = 0x52
+	VK_S    = 0x53
+	VK_X    = 0x58
 )
 
 var (
@@ -21,9 +27,17 @@ var (
 type Listener struct {
 	onF12Pressed   func()
 	onCtrlQPressed func()
-	isRecording    bool
-	stopChan       chan bool
-	running        bool
+	bindings       []*binding
+	stopChan       chan struct{}
+	done           chan struct{} // Closed when the polling loop exits
+}
+
+// binding is an extra key combination registered with Bind
+type binding struct {
+	name     string
+	keys     []int
+	callback func()
+	pressed  bool
 }
 
 // NewListener creates a new hotkey listener
@@ -31,12 +45,24 @@ func NewListener(onF12Pressed func(), onCtrlQPressed func()) *Listener {
 	return &Listener{
 		onF12Pressed:   onF12Pressed,
 		onCtrlQPressed: onCtrlQPressed,
-		isRecording:    false,
-		stopChan:       make(chan bool, 1),
-		running:        false,
 	}
 }
 
+// Bind registers a callback for a key combination; all keys must be held.
+// Bindings must be added before Start.
+func (l *Listener) Bind(name string, keys []int, callback func()) {
+	l.bindings = append(l.bindings, &binding{
+		name:     name,
+		keys:     keys,
+		callback: callback,
+	})
+}
+
+// Check reports whether global key state can be read on this system
+func Check() error {
+	return getAsyncKeyState.Find()
+}
+
 // isKeyPressed checks if a key is currently pressed
 func isKeyPressed(vkCode int) bool {
 	ret, _, _ := getAsyncKeyState.Call(uintptr(vkCode))
@@ -46,13 +72,24 @@ func isKeyPressed(vkCode int) bool {
 // Start begins listening for hotkeys
 func (l *Listener) Start() {
 	log.Println("Starting hotkey listener for F12 and Ctrl+Q...")
-	l.running = true
+	stop, done := make(chan struct{}), make(chan struct{})
+	l.stopChan, l.done = stop, done
 
 	// Start the polling loop in a goroutine
 	go func() {
+		defer close(done)
+		ticker := time.NewTicker(50 * time.Millisecond) // Poll every 50ms
+		defer ticker.Stop()
+
 		var lastF12State, lastCtrlQState bool
 
-		for l.running {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+
 			// Check F12 key
 			currentF12State := isKeyPressed(VK_F12)
 			if currentF12State && !lastF12State {
@@ -78,24 +115,33 @@ func (l *Listener) Start() {
 			}
 			lastCtrlQState = currentCtrlQState
 
-			time.Sleep(50 * time.Millisecond) // Poll every 50ms
+			// Check extra bindings
+			for _, b := range l.bindings {
+				pressed := true
+				for _, vkCode := range b.keys {
+					if !isKeyPressed(vkCode) {
+						pressed = false
+						break
+					}
+				}
+				if pressed && !b.pressed {
+					log.Printf("%s pressed!", b.name)
+					go b.callback()
+				}
+				b.pressed = pressed
+			}
 		}
 	}()
 }
 
-// Stop stops the hotkey listener
+// Stop stops the hotkey listener and waits for the polling loop to exit,
+// so no callback runs afterwards. It must not be called from a callback.
 func (l *Listener) Stop() {
+	if l.stopChan == nil {
+		return
+	}
 	log.Println("Stopping hotkey listener...")
-	l.running = false
-	l.stopChan <- true
-}
-
-// IsRecording returns the current recording state
-func (l *Listener) IsRecording() bool {
-	return l.isRecording
-}
-
-// SetRecording manually sets the recording state
-func (l *Listener) SetRecording(recording bool) {
-	l.isRecording = recording
+	close(l.stopChan)
+	<-l.done
+	l.stopChan = nil
 }
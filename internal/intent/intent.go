@@ -0,0 +1,136 @@
+// Package intent recognizes assistant control phrases in transcripts so
+// they can be handled locally instead of being sent to Claude.
+package intent
+
+import (
+	"strings"
+
+	"voice-assistant/internal/commands"
+)
+
+// Intent is a local assistant control action
+type Intent string
+
+const (
+	NewConversation Intent = "new_conversation" // Forget the conversation so far
+	Repeat          Intent = "repeat"           // Say the last response again
+	RepeatPrevious  Intent = "repeat_previous"  // Say the response before the last one again
+	Slower          Intent = "slower"           // Say the last response again, slowly
+	StopListening   Intent = "stop_listening"   // End the session and go idle
+	Copy            Intent = "copy"             // Copy the last response to the clipboard
+	DeleteToday     Intent = "delete_today"     // Delete today's recorded audio
+	DeleteAll       Intent = "delete_all"       // Wipe history, recordings and transcripts
+	Open            Intent = "open"             // Open an application, URL or file; takes a {target}
+	Screen          Intent = "screen"           // Attach a screenshot to the question
+	ClipboardImage  Intent = "clipboard_image"  // Attach the image on the clipboard to the question
+	Note            Intent = "note"             // Append the {target} to the notes file
+	PlayPause       Intent = "play_pause"       // Toggle media playback
+	NextTrack       Intent = "next_track"       // Skip to the next track
+	PreviousTrack   Intent = "previous_track"   // Go back to the previous track
+	VolumeUp        Intent = "volume_up"        // Raise the system volume
+	VolumeDown      Intent = "volume_down"      // Lower the system volume
+	Mute            Intent = "mute"             // Mute or unmute the system volume
+	LockScreen      Intent = "lock_screen"      // Lock the workstation
+	SendEmail       Intent = "send_email"       // Send the email draft read back to the user
+	DiscardEmail    Intent = "discard_email"    // Drop the email draft
+	AddTodo         Intent = "add_todo"         // Add the {target} to the todo list
+)
+
+// TargetSlot at the end of a phrase captures the rest of the transcript
+const TargetSlot = "{target}"
+
+// Politeness around a command doesn't change its meaning
+var fillerPrefixes = []string{"please ", "can you ", "could you "}
+var fillerSuffixes = []string{" please", " now"}
+
+// Match is a recognized intent and the text captured by its {target} slot
+type Match struct {
+	Intent Intent
+	Target string
+	Spoken string // The target as transcribed, with case and punctuation
+}
+
+// Matcher maps transcripts to intents by exact phrase after normalization,
+// or by prefix for phrases ending in {target}
+type Matcher struct {
+	phrases  map[string]Intent
+	prefixes []prefix
+}
+
+// prefix is a phrase whose remainder is captured as the target
+type prefix struct {
+	text   string
+	intent Intent
+}
+
+// NewMatcher creates a matcher from intent names and their trigger phrases.
+// Unknown intent names are kept so callers can decide how to report them.
+func NewMatcher(phrases map[string][]string) *Matcher {
+	m := &Matcher{phrases: make(map[string]Intent)}
+	for name, list := range phrases {
+		for _, phrase := range list {
+			if strings.HasSuffix(phrase, TargetSlot) {
+				text := commands.Normalize(strings.TrimSuffix(phrase, TargetSlot))
+				m.prefixes = append(m.prefixes, prefix{text: text + " ", intent: Intent(name)})
+				continue
+			}
+
+			normalized := normalize(phrase)
+			if normalized != "" {
+				m.phrases[normalized] = Intent(name)
+			}
+		}
+	}
+	return m
+}
+
+// Match returns the intent a transcript triggers, if any. Exact phrases
+// win over {target} phrases so "start over" isn't read as "start {target}".
+func (m *Matcher) Match(transcript string) (Match, bool) {
+	normalized := normalize(transcript)
+	if intent, ok := m.phrases[normalized]; ok {
+		return Match{Intent: intent}, true
+	}
+
+	for _, p := range m.prefixes {
+		if strings.HasPrefix(normalized, p.text) {
+			return Match{
+				Intent: p.intent,
+				Target: strings.TrimPrefix(normalized, p.text),
+				Spoken: spokenTarget(transcript, p.text),
+			}, true
+		}
+	}
+	return Match{}, false
+}
+
+// normalize lowercases, strips punctuation and drops polite filler
+func normalize(text string) string {
+	text = commands.Normalize(text)
+	for _, prefix := range fillerPrefixes {
+		text = strings.TrimPrefix(text, prefix)
+	}
+	for _, suffix := range fillerSuffixes {
+		text = strings.TrimSuffix(text, suffix)
+	}
+	return text
+}
+
+// spokenTarget drops the words of the matched prefix, and any polite filler
+// before it, from the original transcript
+func spokenTarget(transcript, prefix string) string {
+	words := strings.Fields(transcript)
+	for _, filler := range fillerPrefixes {
+		count := len(strings.Fields(filler))
+		if count <= len(words) && commands.Normalize(strings.Join(words[:count], " "))+" " == filler {
+			words = words[count:]
+			break
+		}
+	}
+
+	skip := len(strings.Fields(prefix))
+	if skip > len(words) {
+		return ""
+	}
+	return strings.Join(words[skip:], " ")
+}
@@ -0,0 +1,137 @@
+package keys
+
+import (
+	"fmt"
+	"log"
+	"sync"
+)
+
+// Rotation strategies
+const (
+	RoundRobin = "round_robin" // Spread requests evenly across keys
+	Failover   = "failover"    // Stick to one key until it is rate limited
+)
+
+// Stats holds per-key usage accounting
+type Stats struct {
+	Key         string // Masked key for display
+	Requests    int
+	Failures    int
+	RateLimited int
+}
+
+// KeyRing rotates between several API keys for one provider
+type KeyRing struct {
+	name     string
+	keys     []string
+	strategy string
+	index    int
+	stats    []Stats
+	mutex    sync.Mutex
+}
+
+// NewKeyRing creates a key ring; an unknown strategy falls back to failover
+func NewKeyRing(name string, keys []string, strategy string) *KeyRing {
+	if strategy != RoundRobin {
+		strategy = Failover
+	}
+
+	stats := make([]Stats, len(keys))
+	for i, key := range keys {
+		stats[i].Key = Mask(key)
+	}
+
+	return &KeyRing{
+		name:     name,
+		keys:     keys,
+		strategy: strategy,
+		stats:    stats,
+	}
+}
+
+// Len returns the number of keys in the ring
+func (r *KeyRing) Len() int {
+	return len(r.keys)
+}
+
+// Next returns the key to use for the next request
+func (r *KeyRing) Next() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.keys) == 0 {
+		return ""
+	}
+
+	key := r.keys[r.index]
+	r.stats[r.index].Requests++
+	if r.strategy == RoundRobin {
+		r.index = (r.index + 1) % len(r.keys)
+	}
+	return key
+}
+
+// ReportFailure records a failed request made with key
+func (r *KeyRing) ReportFailure(key string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if i := r.indexOf(key); i >= 0 {
+		r.stats[i].Failures++
+	}
+}
+
+// ReportRateLimited records a 429/quota response and moves off the key
+func (r *KeyRing) ReportRateLimited(key string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	i := r.indexOf(key)
+	if i < 0 {
+		return
+	}
+	r.stats[i].RateLimited++
+
+	// Only advance if nobody rotated past this key in the meantime
+	if r.strategy == Failover && r.index == i && len(r.keys) > 1 {
+		r.index = (i + 1) % len(r.keys)
+		log.Printf("🔑 %s key %s rate limited, failing over to %s", r.name, Mask(key), Mask(r.keys[r.index]))
+	}
+}
+
+// Stats returns a copy of the per-key usage accounting
+func (r *KeyRing) Stats() []Stats {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	result := make([]Stats, len(r.stats))
+	copy(result, r.stats)
+	return result
+}
+
+// Summary formats the usage accounting for display
+func (r *KeyRing) Summary() string {
+	summary := fmt.Sprintf("%s (%s):", r.name, r.strategy)
+	for _, s := range r.Stats() {
+		summary += fmt.Sprintf("\n  %s: %d req, %d failed, %d limited", s.Key, s.Requests, s.Failures, s.RateLimited)
+	}
+	return summary
+}
+
+// indexOf returns the position of key in the ring, or -1
+func (r *KeyRing) indexOf(key string) int {
+	for i, k := range r.keys {
+		if k == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Mask hides all but the last four characters of a key
+func Mask(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "…" + key[len(key)-4:]
+}
@@ -0,0 +1,79 @@
+package latency
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"voice-assistant/internal/metrics"
+)
+
+// Degradation is a shortcut taken to bring a turn back under budget
+type Degradation string
+
+const (
+	FastModel     Degradation = "fast_model"     // Use the configured fallback model
+	ShortResponse Degradation = "short_response" // Lower max_tokens
+)
+
+// Budget tracks end-to-end turn latency against a target and decides
+// which degradations the next turn should apply
+type Budget struct {
+	limit      time.Duration
+	overBudget bool // Whether the previous turn missed the budget
+	mutex      sync.Mutex
+}
+
+// NewBudget creates a latency budget; a zero limit disables enforcement
+func NewBudget(limit time.Duration) *Budget {
+	return &Budget{limit: limit}
+}
+
+// Plan returns the degradations to apply to a turn that has already spent
+// elapsed between the final transcript and the LLM request.
+// Turns degrade while the previous one was over budget, or when half the
+// budget is gone before Claude is even called.
+func (b *Budget) Plan(elapsed time.Duration) []Degradation {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.limit <= 0 {
+		return nil
+	}
+	if !b.overBudget && elapsed < b.limit/2 {
+		return nil
+	}
+
+	return []Degradation{FastModel, ShortResponse}
+}
+
+// Record stores the total duration of a finished turn and the degradations it used
+func (b *Budget) Record(total time.Duration, applied []Degradation) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	metrics.Inc("turns_total")
+	for _, degradation := range applied {
+		metrics.Inc("degradation_" + string(degradation))
+	}
+
+	if b.limit <= 0 {
+		return
+	}
+
+	b.overBudget = total > b.limit
+	if b.overBudget {
+		metrics.Inc("turns_over_budget")
+		log.Printf("🐢 Turn took %v (budget %v) - degrading next turn", total.Round(time.Millisecond), b.limit)
+	}
+}
+
+// Contains reports whether a degradation is in the list
+func Contains(applied []Degradation, degradation Degradation) bool {
+	for _, d := range applied {
+		if d == degradation {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"voice-assistant/internal/claude"
+)
+
+const geminiURL = "https://generativelanguage.googleapis.com/v1beta"
+
+// gemini talks to Google's Generative Language API
+type gemini struct {
+	baseURL string
+}
+
+// geminiPart is text or inline image data
+type geminiPart struct {
+	Text       string            `json:"text,omitempty"`
+	InlineData *geminiInlineData `json:"inline_data,omitempty"`
+}
+
+type geminiInlineData struct {
+	MimeType string `json:"mime_type"`
+	Data     string `json:"data"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"` // "user" or "model"
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiRequest struct {
+	Contents          []geminiContent `json:"contents"`
+	SystemInstruction *geminiContent  `json:"systemInstruction,omitempty"`
+	GenerationConfig  struct {
+		MaxOutputTokens int      `json:"maxOutputTokens,omitempty"`
+		Temperature     *float64 `json:"temperature,omitempty"`
+	} `json:"generationConfig"`
+}
+
+// geminiChunk is one streamed piece of the answer
+type geminiChunk struct {
+	Candidates []struct {
+		Content      geminiContent `json:"content"`
+		FinishReason string        `json:"finishReason"`
+	} `json:"candidates"`
+	UsageMetadata *struct {
+		PromptTokenCount        int `json:"promptTokenCount"`
+		CandidatesTokenCount    int `json:"candidatesTokenCount"`
+		CachedContentTokenCount int `json:"cachedContentTokenCount"`
+		ThoughtsTokenCount      int `json:"thoughtsTokenCount"`
+	} `json:"usageMetadata"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (gemini) name() string {
+	return "Gemini"
+}
+
+func (g gemini) request(ctx context.Context, c call) (*http.Request, error) {
+	var body geminiRequest
+	for _, m := range c.messages {
+		role := "user"
+		if m.role == "assistant" {
+			role = "model"
+		}
+		body.Contents = append(body.Contents, geminiContent{Role: role, Parts: []geminiPart{{Text: m.text}}})
+	}
+	if len(c.images) > 0 {
+		last := &body.Contents[len(body.Contents)-1]
+		var parts []geminiPart
+		for _, image := range c.images {
+			parts = append(parts, geminiPart{InlineData: &geminiInlineData{
+				MimeType: image.MediaType,
+				Data:     base64.StdEncoding.EncodeToString(image.Data),
+			}})
+		}
+		last.Parts = append(parts, last.Parts...)
+	}
+	if c.system != "" {
+		body.SystemInstruction = &geminiContent{Parts: []geminiPart{{Text: c.system}}}
+	}
+	body.GenerationConfig.MaxOutputTokens = c.maxTokens
+	body.GenerationConfig.Temperature = c.temperature
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("%s/models/%s:streamGenerateContent?alt=sse", g.baseURL, url.PathEscape(c.model))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", c.apiKey)
+	return req, nil
+}
+
+func (gemini) parse(data []byte) (chunk, error) {
+	var event geminiChunk
+	err := json.Unmarshal(data, &event)
+	if err != nil {
+		return chunk{}, fmt.Errorf("failed to parse Gemini response: %v", err)
+	}
+	if event.Error != nil {
+		return chunk{}, fmt.Errorf("Gemini API error: %s", event.Error.Message)
+	}
+
+	var result chunk
+	// Counts are running totals; cached tokens are part of the prompt count
+	// and thinking is billed as output
+	if u := event.UsageMetadata; u != nil {
+		result.usage = &claude.Usage{
+			InputTokens:          u.PromptTokenCount - u.CachedContentTokenCount,
+			OutputTokens:         u.CandidatesTokenCount + u.ThoughtsTokenCount,
+			CacheReadInputTokens: u.CachedContentTokenCount,
+		}
+	}
+	if len(event.Candidates) == 0 {
+		return result, nil
+	}
+
+	candidate := event.Candidates[0]
+	var text strings.Builder
+	for _, part := range candidate.Content.Parts {
+		text.WriteString(part.Text)
+	}
+	if candidate.FinishReason == "SAFETY" && text.Len() == 0 {
+		return result, fmt.Errorf("Gemini declined to answer for safety reasons")
+	}
+	result.text = text.String()
+	result.done = candidate.FinishReason != ""
+	return result, nil
+}
@@ -0,0 +1,313 @@
+// Package llm answers questions through other vendors' chat APIs, Google
+// Gemini and Mistral, for users with credits there instead of with
+// Anthropic. Answers stream in, and the conversation is kept between turns
+// like the Claude client keeps it. Tools and extended thinking are
+// Claude-only.
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/errs"
+	"voice-assistant/internal/proxy"
+)
+
+// Providers
+const (
+	ProviderClaude  = "claude"
+	ProviderGemini  = "gemini"
+	ProviderMistral = "mistral"
+)
+
+// Defaults, matching the Claude client's
+const (
+	DefaultMaxTokens       = 1000
+	DefaultConnectTimeout  = 10 * time.Second
+	DefaultResponseTimeout = 60 * time.Second
+	maxEventBytes          = 1 << 20 // Longest single streamed event
+)
+
+// Config configures a vendor client
+type Config struct {
+	APIKey       string
+	Model        string
+	SystemPrompt string
+	MaxTokens    int      // Zero uses DefaultMaxTokens
+	Temperature  *float64 // Nil uses the vendor default
+
+	ConnectTimeout  time.Duration // Zero uses DefaultConnectTimeout
+	ResponseTimeout time.Duration // Longest the stream may go quiet; zero uses DefaultResponseTimeout
+}
+
+// message is one turn of the kept conversation
+type message struct {
+	role string // "user" or "assistant"
+	text string
+}
+
+// call is everything a vendor needs to build one streaming request
+type call struct {
+	apiKey      string
+	model       string
+	system      string
+	messages    []message
+	images      []claude.Image // Attached to the last user message
+	maxTokens   int
+	temperature *float64
+}
+
+// chunk is what one streamed event adds to the answer
+type chunk struct {
+	text  string
+	done  bool          // Whether the stream is finished
+	usage *claude.Usage // Tokens used so far, when the event reports them
+}
+
+// vendor is what differs between chat APIs: how a request is built and
+// how each streamed event is read
+type vendor interface {
+	name() string
+	request(ctx context.Context, c call) (*http.Request, error)
+	parse(data []byte) (chunk, error)
+}
+
+// Client answers through one vendor's streaming chat API
+type Client struct {
+	vendor         vendor
+	config         Config
+	httpClient     *http.Client
+	history        []message
+	historyEnabled bool
+	onUsage        func(model string, usage claude.Usage)
+	mutex          sync.Mutex
+}
+
+// New creates a client for a provider other than Claude
+func New(provider string, config Config) (*Client, error) {
+	switch provider {
+	case ProviderGemini:
+		return newClient(gemini{baseURL: geminiURL}, config), nil
+	case ProviderMistral:
+		return newClient(mistral{baseURL: mistralURL}, config), nil
+	}
+	return nil, fmt.Errorf("unknown LLM provider %q", provider)
+}
+
+// newClient creates a client for a vendor
+func newClient(v vendor, config Config) *Client {
+	if config.ConnectTimeout <= 0 {
+		config.ConnectTimeout = DefaultConnectTimeout
+	}
+	if config.ResponseTimeout <= 0 {
+		config.ResponseTimeout = DefaultResponseTimeout
+	}
+
+	transport := proxy.NewTransport()
+	transport.DialContext = (&net.Dialer{Timeout: config.ConnectTimeout, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = config.ConnectTimeout
+	transport.ResponseHeaderTimeout = config.ResponseTimeout
+
+	return &Client{
+		vendor:         v,
+		config:         config,
+		httpClient:     &http.Client{Transport: transport},
+		historyEnabled: true,
+	}
+}
+
+// Name returns the vendor's name, e.g. "Gemini"
+func (c *Client) Name() string {
+	return c.vendor.name()
+}
+
+// Model returns the model answers come from
+func (c *Client) Model() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.config.Model
+}
+
+// SetModel switches the model used from the next request
+func (c *Client) SetModel(model string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.config.Model = model
+}
+
+// SetSystemPrompt replaces the system prompt
+func (c *Client) SetSystemPrompt(prompt string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.config.SystemPrompt = prompt
+}
+
+// SetHistoryEnabled controls whether previous turns are kept as context
+func (c *Client) SetHistoryEnabled(enabled bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.historyEnabled = enabled
+	if !enabled {
+		c.history = nil
+	}
+}
+
+// SetUsageCallback sets a callback invoked with the token usage of every
+// answer, like the Claude client's
+func (c *Client) SetUsageCallback(onUsage func(model string, usage claude.Usage)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.onUsage = onUsage
+}
+
+// ResetConversation clears the conversation history
+func (c *Client) ResetConversation() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.history = nil
+}
+
+// SendMessageWithOptions sends a message with the conversation so far and
+// returns the whole answer once it has streamed in. options.OnText is
+// given the answer so far as it arrives. Cancelling ctx abandons the turn.
+func (c *Client) SendMessageWithOptions(ctx context.Context, userMessage string, options claude.RequestOptions) (string, error) {
+	c.mutex.Lock()
+	request := call{
+		apiKey:      c.config.APIKey,
+		model:       c.config.Model,
+		system:      c.config.SystemPrompt,
+		images:      options.Images,
+		maxTokens:   c.config.MaxTokens,
+		temperature: c.config.Temperature,
+	}
+	if c.historyEnabled {
+		request.messages = append(request.messages, c.history...)
+	}
+	c.mutex.Unlock()
+
+	if options.Model != "" {
+		request.model = options.Model
+	}
+	if options.MaxTokens > 0 {
+		request.maxTokens = options.MaxTokens
+	}
+	if request.maxTokens <= 0 {
+		request.maxTokens = DefaultMaxTokens
+	}
+	if options.Context != "" {
+		request.system = strings.TrimSpace(request.system + "\n\n" + options.Context)
+	}
+	request.messages = append(request.messages, message{role: "user", text: userMessage})
+
+	log.Printf("Sending message to %s: %s", c.Name(), userMessage)
+	answer, usage, err := c.stream(ctx, request, options.OnText)
+	if usage != nil {
+		c.mutex.Lock()
+		onUsage := c.onUsage
+		c.mutex.Unlock()
+		if onUsage != nil {
+			onUsage(request.model, *usage)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return "", fmt.Errorf("no content in %s response", c.Name())
+	}
+
+	// Images aren't resent with later turns, only noted
+	question := userMessage
+	if len(options.Images) > 0 {
+		question = fmt.Sprintf("[%d image(s) shared earlier]\n%s", len(options.Images), userMessage)
+	}
+	c.mutex.Lock()
+	if c.historyEnabled {
+		c.history = append(c.history, message{role: "user", text: question}, message{role: "assistant", text: answer})
+	}
+	c.mutex.Unlock()
+	return answer, nil
+}
+
+// stream sends a request and gathers the streamed answer and the tokens it
+// used, giving up only if the stream goes quiet for longer than the
+// response timeout
+func (c *Client) stream(ctx context.Context, request call, onText func(string)) (string, *claude.Usage, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req, err := c.vendor.request(streamCtx, request)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", nil, ctx.Err()
+		}
+		return "", nil, errs.FromTransport(c.Name(), fmt.Errorf("failed to execute request: %w", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body bytes.Buffer
+		body.ReadFrom(resp.Body)
+		err = fmt.Errorf("%s API error: %s - %s", c.Name(), resp.Status, body.String())
+		return "", nil, errs.FromStatus(c.Name(), resp.StatusCode, err)
+	}
+
+	// Only the watchdog cancels streamCtx while ctx is still live
+	watchdog := time.AfterFunc(c.config.ResponseTimeout, cancel)
+	defer watchdog.Stop()
+
+	var answer strings.Builder
+	var usage *claude.Usage
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxEventBytes)
+	for scanner.Scan() {
+		watchdog.Reset(c.config.ResponseTimeout)
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue // Blank separators, comments and event names
+		}
+
+		event, err := c.vendor.parse([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))))
+		if err != nil {
+			return "", usage, err
+		}
+		if event.usage != nil {
+			usage = event.usage
+		}
+		if event.text != "" {
+			answer.WriteString(event.text)
+			if onText != nil {
+				onText(answer.String())
+			}
+		}
+		if event.done {
+			break
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", usage, ctx.Err()
+		}
+		if streamCtx.Err() != nil {
+			err = fmt.Errorf("%s stopped responding for %v", c.Name(), c.config.ResponseTimeout)
+			return "", usage, errs.New(errs.ErrUnavailable, c.Name(), err)
+		}
+		return "", usage, fmt.Errorf("failed to read %s response: %v", c.Name(), err)
+	}
+	return strings.TrimSpace(answer.String()), usage, nil
+}
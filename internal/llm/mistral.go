@@ -0,0 +1,115 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"voice-assistant/internal/claude"
+)
+
+const mistralURL = "https://api.mistral.ai/v1"
+
+// mistral talks to Mistral's chat completions API
+type mistral struct {
+	baseURL string
+}
+
+// mistralMessage holds plain text, or text and images for vision models
+type mistralMessage struct {
+	Role    string      `json:"role"`
+	Content interface{} `json:"content"` // string or []mistralPart
+}
+
+type mistralPart struct {
+	Type     string `json:"type"` // "text" or "image_url"
+	Text     string `json:"text,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+}
+
+type mistralRequest struct {
+	Model       string           `json:"model"`
+	Messages    []mistralMessage `json:"messages"`
+	MaxTokens   int              `json:"max_tokens,omitempty"`
+	Temperature *float64         `json:"temperature,omitempty"`
+	Stream      bool             `json:"stream"`
+}
+
+// mistralChunk is one streamed piece of the answer
+type mistralChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason *string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"` // On the last chunk
+}
+
+func (mistral) name() string {
+	return "Mistral"
+}
+
+func (m mistral) request(ctx context.Context, c call) (*http.Request, error) {
+	body := mistralRequest{Model: c.model, MaxTokens: c.maxTokens, Temperature: c.temperature, Stream: true}
+	if c.system != "" {
+		body.Messages = append(body.Messages, mistralMessage{Role: "system", Content: c.system})
+	}
+	for _, message := range c.messages {
+		body.Messages = append(body.Messages, mistralMessage{Role: message.role, Content: message.text})
+	}
+	if len(c.images) > 0 {
+		last := &body.Messages[len(body.Messages)-1]
+		parts := []mistralPart{{Type: "text", Text: last.Content.(string)}}
+		for _, image := range c.images {
+			parts = append(parts, mistralPart{
+				Type:     "image_url",
+				ImageURL: "data:" + image.MediaType + ";base64," + base64.StdEncoding.EncodeToString(image.Data),
+			})
+		}
+		last.Content = parts
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", m.baseURL+"/chat/completions", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	return req, nil
+}
+
+func (mistral) parse(data []byte) (chunk, error) {
+	if string(data) == "[DONE]" {
+		return chunk{done: true}, nil
+	}
+
+	var event mistralChunk
+	err := json.Unmarshal(data, &event)
+	if err != nil {
+		return chunk{}, fmt.Errorf("failed to parse Mistral response: %v", err)
+	}
+
+	var result chunk
+	if u := event.Usage; u != nil {
+		result.usage = &claude.Usage{InputTokens: u.PromptTokens, OutputTokens: u.CompletionTokens}
+	}
+	if len(event.Choices) == 0 {
+		return result, nil
+	}
+	choice := event.Choices[0]
+	result.text = choice.Delta.Content
+	result.done = choice.FinishReason != nil && *choice.FinishReason != ""
+	return result, nil
+}
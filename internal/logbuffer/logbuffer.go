@@ -0,0 +1,72 @@
+// Package logbuffer keeps recent log output in memory so it can be
+// attached to a problem report.
+package logbuffer
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// line is one write to the log with the time it was made
+type line struct {
+	time time.Time
+	text string
+}
+
+// Buffer is an io.Writer holding the most recent log lines
+type Buffer struct {
+	lines []line
+	next  int
+	full  bool
+	mutex sync.Mutex
+}
+
+// New creates a buffer keeping up to size lines
+func New(size int) *Buffer {
+	return &Buffer{lines: make([]line, size)}
+}
+
+// Write stores a log line; the log package writes one line per call
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.lines[b.next] = line{time: time.Now(), text: string(p)}
+	b.next = (b.next + 1) % len(b.lines)
+	if b.next == 0 {
+		b.full = true
+	}
+	return len(p), nil
+}
+
+// Since returns the lines logged within the last duration, oldest first
+func (b *Buffer) Since(duration time.Duration) string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	cutoff := time.Now().Add(-duration)
+	start, count := 0, b.next
+	if b.full {
+		start, count = b.next, len(b.lines)
+	}
+
+	var out strings.Builder
+	for i := 0; i < count; i++ {
+		l := b.lines[(start+i)%len(b.lines)]
+		if l.time.Before(cutoff) {
+			continue
+		}
+		out.WriteString(l.text)
+	}
+	return out.String()
+}
+
+// Clear forgets everything logged so far
+func (b *Buffer) Clear() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.lines = make([]line, len(b.lines))
+	b.next, b.full = 0, false
+}
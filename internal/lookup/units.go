@@ -0,0 +1,156 @@
+package lookup
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Frankfurter publishes European Central Bank reference rates without a key
+const currencyURL = "https://api.frankfurter.app/latest"
+
+// unit is a unit's dimension and its size in that dimension's base unit
+type unit struct {
+	dimension string
+	factor    float64
+}
+
+// units maps unit names and abbreviations to their size. Temperatures are
+// handled separately because they have offsets.
+var units = map[string]unit{
+	// Length, in meters
+	"mm": {"length", 0.001}, "millimeter": {"length", 0.001},
+	"cm": {"length", 0.01}, "centimeter": {"length", 0.01},
+	"m": {"length", 1}, "meter": {"length", 1},
+	"km": {"length", 1000}, "kilometer": {"length", 1000},
+	"in": {"length", 0.0254}, "inch": {"length", 0.0254}, "inches": {"length", 0.0254},
+	"ft": {"length", 0.3048}, "foot": {"length", 0.3048}, "feet": {"length", 0.3048},
+	"yd": {"length", 0.9144}, "yard": {"length", 0.9144},
+	"mi": {"length", 1609.344}, "mile": {"length", 1609.344},
+
+	// Mass, in kilograms
+	"mg": {"mass", 1e-6}, "milligram": {"mass", 1e-6},
+	"g": {"mass", 0.001}, "gram": {"mass", 0.001},
+	"kg": {"mass", 1}, "kilogram": {"mass", 1},
+	"oz": {"mass", 0.028349523125}, "ounce": {"mass", 0.028349523125},
+	"lb": {"mass", 0.45359237}, "pound": {"mass", 0.45359237},
+	"st": {"mass", 6.35029318}, "stone": {"mass", 6.35029318},
+
+	// Volume, in liters
+	"ml": {"volume", 0.001}, "milliliter": {"volume", 0.001},
+	"l": {"volume", 1}, "liter": {"volume", 1},
+	"tsp": {"volume", 0.00492892}, "teaspoon": {"volume", 0.00492892},
+	"tbsp": {"volume", 0.0147868}, "tablespoon": {"volume", 0.0147868},
+	"floz": {"volume", 0.0295735}, "fluid ounce": {"volume", 0.0295735},
+	"cup": {"volume", 0.236588},
+	"pt":  {"volume", 0.473176}, "pint": {"volume", 0.473176},
+	"qt": {"volume", 0.946353}, "quart": {"volume", 0.946353},
+	"gal": {"volume", 3.78541}, "gallon": {"volume", 3.78541},
+
+	// Speed, in meters per second
+	"m/s":  {"speed", 1},
+	"km/h": {"speed", 1 / 3.6}, "kph": {"speed", 1 / 3.6},
+	"mph":  {"speed", 0.44704},
+	"knot": {"speed", 0.514444},
+
+	// Area, in square meters
+	"m2": {"area", 1}, "square meter": {"area", 1},
+	"km2": {"area", 1e6}, "square kilometer": {"area", 1e6},
+	"ft2": {"area", 0.09290304}, "square foot": {"area", 0.09290304}, "square feet": {"area", 0.09290304},
+	"acre":    {"area", 4046.8564224},
+	"hectare": {"area", 10000}, "ha": {"area", 10000},
+}
+
+// ConvertUnits converts a value between units of the same dimension
+func ConvertUnits(value float64, from, to string) (float64, error) {
+	from, to = unitName(from), unitName(to)
+
+	if isTemperature(from) || isTemperature(to) {
+		if !isTemperature(from) || !isTemperature(to) {
+			return 0, fmt.Errorf("can't convert %s to %s", from, to)
+		}
+		return fromKelvin(toKelvin(value, from), to), nil
+	}
+
+	source, ok := units[from]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", from)
+	}
+	target, ok := units[to]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", to)
+	}
+	if source.dimension != target.dimension {
+		return 0, fmt.Errorf("can't convert %s (%s) to %s (%s)", from, source.dimension, to, target.dimension)
+	}
+	return value * source.factor / target.factor, nil
+}
+
+// unitName normalizes a unit as spoken or abbreviated: lowercase, singular
+func unitName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.ReplaceAll(name, "metre", "meter")
+	name = strings.ReplaceAll(name, "litre", "liter")
+	if _, ok := units[name]; !ok && strings.HasSuffix(name, "s") {
+		if _, ok := units[strings.TrimSuffix(name, "s")]; ok {
+			return strings.TrimSuffix(name, "s")
+		}
+	}
+	return name
+}
+
+// isTemperature reports whether a unit is a temperature scale
+func isTemperature(name string) bool {
+	switch name {
+	case "c", "celsius", "f", "fahrenheit", "k", "kelvin":
+		return true
+	}
+	return false
+}
+
+func toKelvin(value float64, scale string) float64 {
+	switch scale {
+	case "c", "celsius":
+		return value + 273.15
+	case "f", "fahrenheit":
+		return (value-32)*5/9 + 273.15
+	}
+	return value
+}
+
+func fromKelvin(value float64, scale string) float64 {
+	switch scale {
+	case "c", "celsius":
+		return value - 273.15
+	case "f", "fahrenheit":
+		return (value-273.15)*9/5 + 32
+	}
+	return value
+}
+
+// ConvertCurrency converts an amount using today's reference rate.
+// Currencies are ISO 4217 codes such as USD or EUR.
+func ConvertCurrency(ctx context.Context, amount float64, from, to string) (float64, error) {
+	from, to = strings.ToUpper(strings.TrimSpace(from)), strings.ToUpper(strings.TrimSpace(to))
+	if from == to {
+		return amount, nil
+	}
+
+	query := url.Values{}
+	query.Set("from", from)
+	query.Set("to", to)
+
+	var rates struct {
+		Rates map[string]float64 `json:"rates"`
+	}
+	err := getJSON(ctx, currencyURL+"?"+query.Encode(), &rates)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s to %s rate: %v", from, to, err)
+	}
+	rate, ok := rates.Rates[to]
+	if !ok {
+		return 0, fmt.Errorf("no rate for %s to %s", from, to)
+	}
+	return amount * rate, nil
+}
@@ -0,0 +1,153 @@
+// Package lookup answers simple factual questions locally or from free
+// public APIs, for the built-in Claude tools.
+package lookup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Open-Meteo endpoints; neither needs an API key
+const (
+	geocodingURL = "https://geocoding-api.open-meteo.com/v1/search"
+	forecastURL  = "https://api.open-meteo.com/v1/forecast"
+)
+
+// RequestTimeout bounds each lookup
+const RequestTimeout = 10 * time.Second
+
+var httpClient = &http.Client{Timeout: RequestTimeout}
+
+// Weather is the current conditions and today's range at a place
+type Weather struct {
+	Place       string
+	Temperature float64
+	High        float64
+	Low         float64
+	Wind        float64
+	Condition   string
+	Imperial    bool
+}
+
+// String describes the weather in one sentence
+func (w Weather) String() string {
+	temperature, speed := "°C", "km/h"
+	if w.Imperial {
+		temperature, speed = "°F", "mph"
+	}
+	return fmt.Sprintf("%s: %s, %.0f%s (high %.0f%s, low %.0f%s), wind %.0f %s",
+		w.Place, w.Condition, w.Temperature, temperature, w.High, temperature, w.Low, temperature, w.Wind, speed)
+}
+
+// CurrentWeather looks a place up by name and fetches its weather
+func CurrentWeather(ctx context.Context, place string, imperial bool) (Weather, error) {
+	var places struct {
+		Results []struct {
+			Name      string  `json:"name"`
+			Country   string  `json:"country"`
+			Latitude  float64 `json:"latitude"`
+			Longitude float64 `json:"longitude"`
+		} `json:"results"`
+	}
+	err := getJSON(ctx, geocodingURL+"?count=1&name="+url.QueryEscape(place), &places)
+	if err != nil {
+		return Weather{}, fmt.Errorf("failed to find %q: %v", place, err)
+	}
+	if len(places.Results) == 0 {
+		return Weather{}, fmt.Errorf("unknown place %q", place)
+	}
+	found := places.Results[0]
+
+	query := url.Values{}
+	query.Set("latitude", fmt.Sprint(found.Latitude))
+	query.Set("longitude", fmt.Sprint(found.Longitude))
+	query.Set("current", "temperature_2m,weather_code,wind_speed_10m")
+	query.Set("daily", "temperature_2m_max,temperature_2m_min")
+	query.Set("forecast_days", "1")
+	query.Set("timezone", "auto")
+	if imperial {
+		query.Set("temperature_unit", "fahrenheit")
+		query.Set("wind_speed_unit", "mph")
+	}
+
+	var forecast struct {
+		Current struct {
+			Temperature float64 `json:"temperature_2m"`
+			WeatherCode int     `json:"weather_code"`
+			WindSpeed   float64 `json:"wind_speed_10m"`
+		} `json:"current"`
+		Daily struct {
+			Max []float64 `json:"temperature_2m_max"`
+			Min []float64 `json:"temperature_2m_min"`
+		} `json:"daily"`
+	}
+	err = getJSON(ctx, forecastURL+"?"+query.Encode(), &forecast)
+	if err != nil {
+		return Weather{}, fmt.Errorf("failed to get weather: %v", err)
+	}
+
+	weather := Weather{
+		Place:       found.Name,
+		Temperature: forecast.Current.Temperature,
+		Wind:        forecast.Current.WindSpeed,
+		Condition:   weatherCondition(forecast.Current.WeatherCode),
+		Imperial:    imperial,
+	}
+	if found.Country != "" {
+		weather.Place += ", " + found.Country
+	}
+	if len(forecast.Daily.Max) > 0 && len(forecast.Daily.Min) > 0 {
+		weather.High, weather.Low = forecast.Daily.Max[0], forecast.Daily.Min[0]
+	}
+	return weather, nil
+}
+
+// weatherCondition names a WMO weather code
+func weatherCondition(code int) string {
+	switch {
+	case code == 0:
+		return "clear sky"
+	case code <= 2:
+		return "partly cloudy"
+	case code == 3:
+		return "overcast"
+	case code == 45 || code == 48:
+		return "fog"
+	case code >= 51 && code <= 57:
+		return "drizzle"
+	case code >= 61 && code <= 67:
+		return "rain"
+	case code >= 71 && code <= 77:
+		return "snow"
+	case code >= 80 && code <= 82:
+		return "rain showers"
+	case code == 85 || code == 86:
+		return "snow showers"
+	case code >= 95:
+		return "thunderstorm"
+	}
+	return "unknown conditions"
+}
+
+// getJSON fetches a URL and decodes its JSON body into v
+func getJSON(ctx context.Context, address string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", address, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+var (
+	counters = make(map[string]uint64)
+	mutex    sync.Mutex
+)
+
+// Inc increments a named counter
+func Inc(name string) {
+	Add(name, 1)
+}
+
+// Add adds delta to a named counter
+func Add(name string, delta uint64) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	counters[name] += delta
+}
+
+// Get returns the current value of a counter
+func Get(name string) uint64 {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return counters[name]
+}
+
+// Snapshot returns a copy of all counters
+func Snapshot() map[string]uint64 {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	result := make(map[string]uint64, len(counters))
+	for name, value := range counters {
+		result[name] = value
+	}
+	return result
+}
+
+// Names returns all counter names in sorted order
+func Names() []string {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	names := make([]string, 0, len(counters))
+	for name := range counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// prefix namespaces exported metric names
+const prefix = "voice_assistant_"
+
+// WritePrometheus writes counters and stage latencies in the Prometheus
+// text exposition format
+func WritePrometheus(w io.Writer) {
+	snapshot := Snapshot()
+	for _, name := range Names() {
+		metric := prefix + sanitize(name)
+		fmt.Fprintf(w, "# TYPE %s counter\n", metric)
+		fmt.Fprintf(w, "%s %d\n", metric, snapshot[name])
+	}
+
+	metric := prefix + "stage_latency_seconds"
+	fmt.Fprintf(w, "# HELP %s Per-stage latency over recent interactions\n", metric)
+	fmt.Fprintf(w, "# TYPE %s summary\n", metric)
+	for _, summary := range Summarize() {
+		stage := summary.Stage.Name
+		if summary.Count > 0 {
+			fmt.Fprintf(w, "%s{stage=%q,quantile=\"0.5\"} %g\n", metric, stage, summary.P50.Seconds())
+			fmt.Fprintf(w, "%s{stage=%q,quantile=\"0.95\"} %g\n", metric, stage, summary.P95.Seconds())
+		}
+		fmt.Fprintf(w, "%s_sum{stage=%q} %g\n", metric, stage, summary.Sum.Seconds())
+		fmt.Fprintf(w, "%s_count{stage=%q} %d\n", metric, stage, summary.Count)
+	}
+}
+
+// Handler serves WritePrometheus for scraping
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		WritePrometheus(w)
+	})
+}
+
+// sanitize maps a counter name onto the Prometheus name alphabet
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Points in an interaction that can be marked
+const (
+	MarkHotkey          = "hotkey"
+	MarkCaptureStart    = "capture_start"
+	MarkFirstAudio      = "first_audio"
+	MarkFirstHypothesis = "first_hypothesis"
+	MarkFinalTranscript = "final_transcript"
+	MarkFirstToken      = "first_token"
+	MarkTTSStart        = "tts_start"
+)
+
+// Stage is the time between two marks
+type Stage struct {
+	Name  string
+	Label string
+	From  string
+	To    string
+}
+
+// Stages lists the measured stages in pipeline order
+var Stages = []Stage{
+	{Name: "capture", Label: "Hotkey → capture start", From: MarkHotkey, To: MarkCaptureStart},
+	{Name: "recognition", Label: "First audio → first hypothesis", From: MarkFirstAudio, To: MarkFirstHypothesis},
+	{Name: "claude", Label: "Transcript → Claude first token", From: MarkFinalTranscript, To: MarkFirstToken},
+	{Name: "tts", Label: "Claude → TTS start", From: MarkFirstToken, To: MarkTTSStart},
+	{Name: "response", Label: "Transcript → TTS start", From: MarkFinalTranscript, To: MarkTTSStart},
+}
+
+// Interaction holds the stage durations of one finished turn
+type Interaction struct {
+	Started   time.Time
+	Durations map[string]time.Duration
+}
+
+// StageSummary aggregates one stage over the recent interactions
+type StageSummary struct {
+	Stage Stage
+	Count int
+	Sum   time.Duration
+	P50   time.Duration
+	P95   time.Duration
+}
+
+// maxInteractions bounds how many finished interactions are kept
+const maxInteractions = 200
+
+var (
+	traceMutex   sync.Mutex
+	current      map[string]time.Time
+	interactions []Interaction
+)
+
+// BeginInteraction starts timing a new interaction, finishing any open one
+func BeginInteraction() {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+	endInteraction()
+	current = make(map[string]time.Time)
+}
+
+// Mark records the first time a point is reached in the open interaction.
+// Marks outside an interaction and repeated marks are ignored.
+func Mark(name string) {
+	now := time.Now()
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+	if current == nil {
+		return
+	}
+	if _, seen := current[name]; !seen {
+		current[name] = now
+	}
+}
+
+// EndInteraction finishes the open interaction and records its stages
+func EndInteraction() {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+	endInteraction()
+}
+
+func endInteraction() {
+	if current == nil {
+		return
+	}
+	marks := current
+	current = nil
+
+	interaction := Interaction{Durations: make(map[string]time.Duration)}
+	for _, at := range marks {
+		if interaction.Started.IsZero() || at.Before(interaction.Started) {
+			interaction.Started = at
+		}
+	}
+	for _, stage := range Stages {
+		from, okFrom := marks[stage.From]
+		to, okTo := marks[stage.To]
+		if okFrom && okTo && !to.Before(from) {
+			interaction.Durations[stage.Name] = to.Sub(from)
+		}
+	}
+	if len(interaction.Durations) == 0 {
+		return
+	}
+
+	interactions = append(interactions, interaction)
+	if len(interactions) > maxInteractions {
+		interactions = interactions[len(interactions)-maxInteractions:]
+	}
+	Inc("interactions_total")
+}
+
+// Interactions returns the recent interactions, oldest first
+func Interactions() []Interaction {
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+	return append([]Interaction(nil), interactions...)
+}
+
+// Summarize aggregates every stage over the recent interactions
+func Summarize() []StageSummary {
+	recent := Interactions()
+
+	summaries := make([]StageSummary, 0, len(Stages))
+	for _, stage := range Stages {
+		var samples []time.Duration
+		summary := StageSummary{Stage: stage}
+		for _, interaction := range recent {
+			if d, ok := interaction.Durations[stage.Name]; ok {
+				samples = append(samples, d)
+				summary.Sum += d
+			}
+		}
+		summary.Count = len(samples)
+		if summary.Count > 0 {
+			sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+			summary.P50 = percentile(samples, 0.50)
+			summary.P95 = percentile(samples, 0.95)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// percentile picks the nearest-rank value from sorted samples
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	index := int(p*float64(len(sorted))+0.5) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
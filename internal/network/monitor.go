@@ -0,0 +1,177 @@
+package network
+
+import (
+	"log"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// How long to wait after an address change before comparing interfaces,
+// so a Wi-Fi handover or VPN connect settles into a single event
+const DebounceDelay = 2 * time.Second
+
+var (
+	iphlpapi             = syscall.NewLazyDLL("iphlpapi.dll")
+	notifyAddrChange     = iphlpapi.NewProc("NotifyAddrChange")
+	cancelIPChangeNotify = iphlpapi.NewProc("CancelIPChangeNotify")
+)
+
+// Monitor watches the OS for network interface changes
+type Monitor struct {
+	onChange        func(description string)
+	lastFingerprint string         // Only touched by the watch goroutine
+	stop            windows.Handle // Event signalled by Stop
+	done            chan struct{}  // Closed once the watch goroutine exits
+	stopOnce        sync.Once
+}
+
+// NewMonitor creates a new network change monitor
+func NewMonitor(onChange func(description string)) *Monitor {
+	return &Monitor{onChange: onChange}
+}
+
+// Start begins listening for network change events
+func (m *Monitor) Start() {
+	log.Println("Starting network change monitor...")
+	stop, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		log.Printf("⚠️  Network monitor unavailable: %v", err)
+		return
+	}
+	m.stop = stop
+	m.done = make(chan struct{})
+	m.lastFingerprint = fingerprint()
+
+	go m.watch()
+}
+
+// watch waits for address changes, or for Stop, and reports settled ones
+func (m *Monitor) watch() {
+	defer close(m.done)
+
+	changed, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		log.Printf("⚠️  Network monitor stopped: %v", err)
+		return
+	}
+	defer windows.CloseHandle(changed)
+
+	for {
+		// The overlapped form signals changed when the IPv4 address table
+		// changes (interface up/down, DHCP, VPN) instead of blocking, so
+		// the wait can include the stop event. The OS writes to both
+		// structures until the request completes, so they live on the heap.
+		overlapped := &windows.Overlapped{HEvent: changed}
+		handle := new(windows.Handle)
+		ret, _, _ := notifyAddrChange.Call(uintptr(unsafe.Pointer(handle)), uintptr(unsafe.Pointer(overlapped)))
+		if syscall.Errno(ret) != windows.ERROR_IO_PENDING {
+			log.Printf("⚠️  NotifyAddrChange failed (code %d), network monitor stopped", ret)
+			return
+		}
+
+		event, err := windows.WaitForMultipleObjects([]windows.Handle{changed, m.stop}, false, windows.INFINITE)
+		if err != nil || event != windows.WAIT_OBJECT_0 {
+			cancelIPChangeNotify.Call(uintptr(unsafe.Pointer(overlapped)))
+			return
+		}
+
+		// Let the change settle, unless stopped meanwhile
+		event, _ = windows.WaitForSingleObject(m.stop, uint32(DebounceDelay/time.Millisecond))
+		if event == windows.WAIT_OBJECT_0 {
+			return
+		}
+
+		current := fingerprint()
+		if current == m.lastFingerprint {
+			continue
+		}
+
+		description := describeChange(m.lastFingerprint, current)
+		m.lastFingerprint = current
+		log.Printf("🌐 Network change detected: %s", description)
+
+		if m.onChange != nil {
+			m.onChange(description)
+		}
+	}
+}
+
+// Stop stops the network monitor and waits for its goroutine to exit
+func (m *Monitor) Stop() {
+	if m.done == nil {
+		return // Never started
+	}
+	m.stopOnce.Do(func() {
+		log.Println("Stopping network change monitor...")
+		windows.SetEvent(m.stop)
+		<-m.done
+		windows.CloseHandle(m.stop)
+	})
+}
+
+// fingerprint summarizes the active interfaces and their addresses
+func fingerprint() string {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return ""
+	}
+
+	var entries []string
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil || len(addrs) == 0 {
+			continue
+		}
+		var addrList []string
+		for _, addr := range addrs {
+			addrList = append(addrList, addr.String())
+		}
+		sort.Strings(addrList)
+		entries = append(entries, iface.Name+"="+strings.Join(addrList, ","))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ";")
+}
+
+// describeChange returns a short human-readable summary of what changed
+func describeChange(before, after string) string {
+	names := func(fp string) map[string]bool {
+		result := make(map[string]bool)
+		for _, entry := range strings.Split(fp, ";") {
+			if entry == "" {
+				continue
+			}
+			result[strings.SplitN(entry, "=", 2)[0]] = true
+		}
+		return result
+	}
+
+	oldNames, newNames := names(before), names(after)
+	var changes []string
+	for name := range newNames {
+		if !oldNames[name] {
+			changes = append(changes, "+"+name)
+		}
+	}
+	for name := range oldNames {
+		if !newNames[name] {
+			changes = append(changes, "-"+name)
+		}
+	}
+	sort.Strings(changes)
+
+	if len(changes) == 0 {
+		return "interface addresses changed"
+	}
+	return strings.Join(changes, ", ")
+}
@@ -0,0 +1,124 @@
+package notify
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Scheme is the URL protocol toast buttons activate. Windows starts a new
+// copy of the assistant with the URL, which hands it to Activate.
+const Scheme = "voice-assistant"
+
+// maxActions is how many buttons stay clickable; older ones expire
+const maxActions = 32
+
+// actionServer runs button actions for the running assistant. It listens
+// on loopback only and requires a per-run token, so other programs can't
+// trigger actions by guessing ids.
+type actionServer struct {
+	listener net.Listener
+	token    string
+	next     int
+	actions  map[int]func()
+	mutex    sync.Mutex
+}
+
+// startActionServer listens on a free loopback port
+func startActionServer() (*actionServer, error) {
+	err := registerScheme()
+	if err != nil {
+		return nil, fmt.Errorf("failed to register %s: URLs: %v", Scheme, err)
+	}
+
+	secret := make([]byte, 16)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create action token: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for actions: %v", err)
+	}
+
+	s := &actionServer{
+		listener: listener,
+		token:    hex.EncodeToString(secret),
+		actions:  make(map[int]func()),
+	}
+	go http.Serve(listener, http.HandlerFunc(s.serve))
+	return s, nil
+}
+
+// add registers an action and returns the URL its button activates
+func (s *actionServer) add(run func()) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.next++
+	s.actions[s.next] = run
+	delete(s.actions, s.next-maxActions)
+
+	query := url.Values{}
+	query.Set("port", strconv.Itoa(s.listener.Addr().(*net.TCPAddr).Port))
+	query.Set("token", s.token)
+	query.Set("id", strconv.Itoa(s.next))
+	return Scheme + ":action?" + query.Encode()
+}
+
+// serve runs the action named in a request from Activate
+func (s *actionServer) serve(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.FormValue("token") != s.token {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	id, _ := strconv.Atoi(r.FormValue("id"))
+
+	s.mutex.Lock()
+	run := s.actions[id]
+	s.mutex.Unlock()
+
+	if run == nil {
+		http.Error(w, "action expired", http.StatusGone)
+		return
+	}
+	go run()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *actionServer) close() {
+	s.listener.Close()
+}
+
+// Activate forwards a clicked button's URL to the running assistant
+func Activate(uri string) error {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != Scheme {
+		return fmt.Errorf("not a %s URL: %q", Scheme, uri)
+	}
+	query := parsed.Query()
+	if parsed.Opaque != "action" || query.Get("port") == "" {
+		return fmt.Errorf("malformed action URL: %q", uri)
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.PostForm("http://127.0.0.1:"+query.Get("port")+"/", url.Values{
+		"token": {query.Get("token")},
+		"id":    {query.Get("id")},
+	})
+	if err != nil {
+		return fmt.Errorf("assistant isn't running: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("action rejected: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Package notify shows desktop notifications, filtered by the configured
+// verbosity and per-event switches. On Windows they are toasts that can
+// carry action buttons such as "Copy response" or "Retry".
+package notify
+
+import (
+	"log"
+	"sync"
+
+	"voice-assistant/config"
+)
+
+// DefaultTitle heads notifications that don't set their own
+const DefaultTitle = "AI Assistant"
+
+// Event is the kind of notification, which decides whether it is shown
+type Event string
+
+const (
+	Status   Event = "status"   // Progress such as recording started, shown only when verbose
+	Info     Event = "info"     // The result of something the user asked for
+	Response Event = "response" // Claude's answer, when it isn't spoken
+	Reminder Event = "reminder" // Timers going off, always with sound
+	Error    Event = "error"    // Something failed
+)
+
+// Action is a button on a notification
+type Action struct {
+	Label string
+	Run   func()
+}
+
+// Notifier shows notifications according to its configuration
+type Notifier struct {
+	config  config.NotificationsConfig
+	actions *actionServer
+	mutex   sync.Mutex
+}
+
+// New creates a notifier
+func New(cfg config.NotificationsConfig) *Notifier {
+	return &Notifier{config: cfg}
+}
+
+// SetConfig applies changed notification settings
+func (n *Notifier) SetConfig(cfg config.NotificationsConfig) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.config = cfg
+}
+
+// Enabled reports whether notifications of an event are shown
+func (n *Notifier) Enabled(event Event) bool {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	switch n.config.Level {
+	case config.NotifySilent:
+		return false
+	case config.NotifyMinimal:
+		if event == Status {
+			return false
+		}
+	}
+	if enabled, ok := n.config.Events[string(event)]; ok {
+		return enabled
+	}
+	return true
+}
+
+// Notify shows a notification under the default title
+func (n *Notifier) Notify(event Event, message string, actions ...Action) error {
+	return n.Show(event, DefaultTitle, message, actions...)
+}
+
+// Show shows a notification with its own title. Actions are dropped where
+// toasts can't carry them, or when turned off.
+func (n *Notifier) Show(event Event, title, message string, actions ...Action) error {
+	if n == nil || !n.Enabled(event) {
+		return nil
+	}
+
+	n.mutex.Lock()
+	sound := n.config.Sound || event == Reminder
+	var buttons []button
+	if n.config.Actions && len(actions) > 0 && actionsSupported {
+		buttons = n.registerActions(actions)
+	}
+	n.mutex.Unlock()
+
+	return push(title, message, event, sound, buttons)
+}
+
+// registerActions makes actions reachable from toast buttons, starting the
+// local action server the first time. Called with the mutex held.
+func (n *Notifier) registerActions(actions []Action) []button {
+	if n.actions == nil {
+		server, err := startActionServer()
+		if err != nil {
+			log.Printf("⚠️  Notification actions unavailable: %v", err)
+			n.config.Actions = false
+			return nil
+		}
+		n.actions = server
+	}
+
+	buttons := make([]button, len(actions))
+	for i, action := range actions {
+		buttons[i] = button{label: action.Label, uri: n.actions.add(action.Run)}
+	}
+	return buttons
+}
+
+// Close stops the action server; buttons on notifications still on
+// screen stop working
+func (n *Notifier) Close() {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.actions != nil {
+		n.actions.close()
+		n.actions = nil
+	}
+}
+
+// button is an action as placed on a toast
+type button struct {
+	label string
+	uri   string
+}
@@ -0,0 +1,21 @@
+//go:build !windows
+
+package notify
+
+import "github.com/gen2brain/beeep"
+
+// actionsSupported reports whether toasts can carry buttons here
+const actionsSupported = false
+
+// push shows a plain notification; buttons need Windows toasts
+func push(title, message string, event Event, sound bool, buttons []button) error {
+	if event == Reminder {
+		return beeep.Alert(title, message, "")
+	}
+	return beeep.Notify(title, message, "")
+}
+
+// registerScheme is unnecessary without action buttons
+func registerScheme() error {
+	return nil
+}
@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gen2brain/beeep"
+	"github.com/go-toast/toast"
+	"golang.org/x/sys/windows/registry"
+)
+
+// actionsSupported reports whether toasts can carry buttons here
+const actionsSupported = true
+
+// toastAppID shows toasts as coming from PowerShell, which every Windows
+// install has registered; an unregistered id is silently dropped
+const toastAppID = `{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe`
+
+// push shows a toast, falling back to beeep where toasts aren't available
+func push(title, message string, event Event, sound bool, buttons []button) error {
+	notification := toast.Notification{
+		AppID:   toastAppID,
+		Title:   title,
+		Message: message,
+		Audio:   toast.Default,
+	}
+	switch {
+	case !sound:
+		notification.Audio = toast.Silent
+	case event == Reminder:
+		notification.Audio = toast.Reminder
+	}
+	for _, b := range buttons {
+		notification.Actions = append(notification.Actions, toast.Action{Type: "protocol", Label: b.label, Arguments: b.uri})
+	}
+
+	err := notification.Push()
+	if err != nil {
+		return beeep.Notify(title, message, "")
+	}
+	return nil
+}
+
+// registerScheme points voice-assistant: URLs at this executable for the
+// current user, so clicking a button runs "voice-assistant notify-action"
+func registerScheme() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, `Software\Classes\`+Scheme, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer key.Close()
+	key.SetStringValue("", "URL:Voice Assistant action")
+	key.SetStringValue("URL Protocol", "")
+
+	command, _, err := registry.CreateKey(registry.CURRENT_USER, `Software\Classes\`+Scheme+`\shell\open\command`, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer command.Close()
+	return command.SetStringValue("", fmt.Sprintf(`"%s" notify-action "%%1"`, exe))
+}
@@ -0,0 +1,206 @@
+// Package oauth signs the user in to web APIs with the OAuth device-code
+// flow and keeps the resulting token in the OS keychain.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// deviceCodeGrant is the OAuth 2.0 device authorization grant type
+const deviceCodeGrant = "urn:ietf:params:oauth:grant-type:device_code"
+
+// RequestTimeout bounds each request to the sign-in endpoints
+const RequestTimeout = 15 * time.Second
+
+var httpClient = &http.Client{Timeout: RequestTimeout}
+
+// DeviceFlow signs the user in with the OAuth device-code flow: the user
+// opens a URL on any device and types a short code, while the assistant
+// polls for the token
+type DeviceFlow struct {
+	DeviceCodeURL string
+	TokenURL      string
+	ClientID      string
+	ClientSecret  string
+	Scopes        []string
+}
+
+// DeviceCode is what the user needs to approve access
+type DeviceCode struct {
+	UserCode        string
+	VerificationURL string
+	Expires         time.Time
+	deviceCode      string
+	interval        time.Duration
+}
+
+// Token is an OAuth access token and the refresh token that renews it
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// Valid reports whether the access token can still be used
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && time.Now().Add(time.Minute).Before(t.Expiry)
+}
+
+// tokenResponse is the token endpoint's reply, success or error
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+	Description  string `json:"error_description"`
+}
+
+// Start asks for a device code to show the user
+func (f *DeviceFlow) Start(ctx context.Context) (*DeviceCode, error) {
+	form := url.Values{}
+	form.Set("client_id", f.ClientID)
+	form.Set("scope", strings.Join(f.Scopes, " "))
+
+	var response struct {
+		DeviceCode      string `json:"device_code"`
+		UserCode        string `json:"user_code"`
+		VerificationURL string `json:"verification_url"` // Google
+		VerificationURI string `json:"verification_uri"` // RFC 8628, Microsoft
+		ExpiresIn       int    `json:"expires_in"`
+		Interval        int    `json:"interval"`
+	}
+	status, err := postForm(ctx, f.DeviceCodeURL, form, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start sign-in: %v", err)
+	}
+	if status != http.StatusOK || response.DeviceCode == "" {
+		return nil, fmt.Errorf("failed to start sign-in: HTTP %d", status)
+	}
+
+	code := &DeviceCode{
+		UserCode:        response.UserCode,
+		VerificationURL: response.VerificationURL,
+		Expires:         time.Now().Add(time.Duration(response.ExpiresIn) * time.Second),
+		deviceCode:      response.DeviceCode,
+		interval:        time.Duration(response.Interval) * time.Second,
+	}
+	if code.VerificationURL == "" {
+		code.VerificationURL = response.VerificationURI
+	}
+	if code.interval <= 0 {
+		code.interval = 5 * time.Second
+	}
+	return code, nil
+}
+
+// Poll waits until the user approves or denies access, or the code expires
+func (f *DeviceFlow) Poll(ctx context.Context, code *DeviceCode) (*Token, error) {
+	form := url.Values{}
+	form.Set("client_id", f.ClientID)
+	form.Set("device_code", code.deviceCode)
+	form.Set("grant_type", deviceCodeGrant)
+	if f.ClientSecret != "" {
+		form.Set("client_secret", f.ClientSecret)
+	}
+
+	interval := code.interval
+	for time.Now().Before(code.Expires) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var response tokenResponse
+		_, err := postForm(ctx, f.TokenURL, form, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		switch response.Error {
+		case "":
+			return response.token(""), nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, fmt.Errorf("sign-in failed: %s", response.describe())
+		}
+	}
+	return nil, fmt.Errorf("the sign-in code expired")
+}
+
+// Refresh renews an expired access token
+func (f *DeviceFlow) Refresh(ctx context.Context, token *Token) (*Token, error) {
+	if token == nil || token.RefreshToken == "" {
+		return nil, fmt.Errorf("not signed in")
+	}
+
+	form := url.Values{}
+	form.Set("client_id", f.ClientID)
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", token.RefreshToken)
+	form.Set("scope", strings.Join(f.Scopes, " "))
+	if f.ClientSecret != "" {
+		form.Set("client_secret", f.ClientSecret)
+	}
+
+	var response tokenResponse
+	_, err := postForm(ctx, f.TokenURL, form, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh sign-in: %v", err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("failed to refresh sign-in: %s", response.describe())
+	}
+	// Google keeps the old refresh token; Microsoft rotates it
+	return response.token(token.RefreshToken), nil
+}
+
+// token converts a successful response, keeping refresh if none was issued
+func (r *tokenResponse) token(refresh string) *Token {
+	if r.RefreshToken != "" {
+		refresh = r.RefreshToken
+	}
+	return &Token{
+		AccessToken:  r.AccessToken,
+		RefreshToken: refresh,
+		Expiry:       time.Now().Add(time.Duration(r.ExpiresIn) * time.Second),
+	}
+}
+
+// describe formats an error response
+func (r *tokenResponse) describe() string {
+	if r.Description != "" {
+		return r.Error + ": " + r.Description
+	}
+	return r.Error
+}
+
+// postForm POSTs a form and decodes the JSON reply, whatever the status
+func postForm(ctx context.Context, endpoint string, form url.Values, v interface{}) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(v)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("invalid response (HTTP %d): %v", resp.StatusCode, err)
+	}
+	return resp.StatusCode, nil
+}
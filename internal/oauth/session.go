@@ -0,0 +1,114 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"voice-assistant/internal/secure"
+)
+
+// ErrNotConnected is returned until the user has signed in
+var ErrNotConnected = errors.New("not signed in; use the Connect item in the tray menu")
+
+// Session is a signed-in account: it refreshes the token as needed and
+// keeps it in the OS keychain under a credential name
+type Session struct {
+	flow       *DeviceFlow
+	credential string
+	token      *Token
+	mutex      sync.Mutex
+}
+
+// NewSession creates a session and loads any saved sign-in
+func NewSession(flow *DeviceFlow, credential string) *Session {
+	s := &Session{flow: flow, credential: credential}
+
+	saved, err := secure.ReadCredential(credential)
+	if err == nil {
+		var token Token
+		if json.Unmarshal([]byte(saved), &token) == nil {
+			s.token = &token
+		}
+	}
+	return s
+}
+
+// IsConnected reports whether the user has signed in
+func (s *Session) IsConnected() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.token != nil
+}
+
+// Connect signs the user in. showCode is called with the code to enter;
+// Connect then blocks until the user approves or the code expires.
+func (s *Session) Connect(ctx context.Context, showCode func(*DeviceCode)) error {
+	code, err := s.flow.Start(ctx)
+	if err != nil {
+		return err
+	}
+	showCode(code)
+
+	token, err := s.flow.Poll(ctx, code)
+	if err != nil {
+		return err
+	}
+	return s.setToken(token)
+}
+
+// Disconnect forgets the sign-in
+func (s *Session) Disconnect() error {
+	s.mutex.Lock()
+	s.token = nil
+	s.mutex.Unlock()
+
+	err := secure.DeleteCredential(s.credential)
+	if err != nil && !errors.Is(err, secure.ErrCredentialNotFound) {
+		return err
+	}
+	return nil
+}
+
+// AccessToken returns a valid access token, refreshing an expired one
+func (s *Session) AccessToken(ctx context.Context) (string, error) {
+	s.mutex.Lock()
+	token := s.token
+	s.mutex.Unlock()
+
+	if token == nil {
+		return "", ErrNotConnected
+	}
+	if token.Valid() {
+		return token.AccessToken, nil
+	}
+
+	refreshed, err := s.flow.Refresh(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	err = s.setToken(refreshed)
+	if err != nil {
+		return "", err
+	}
+	return refreshed.AccessToken, nil
+}
+
+// setToken keeps a token and saves it to the keychain
+func (s *Session) setToken(token *Token) error {
+	s.mutex.Lock()
+	s.token = token
+	s.mutex.Unlock()
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	err = secure.StoreCredential(s.credential, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to save sign-in: %v", err)
+	}
+	return nil
+}
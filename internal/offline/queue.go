@@ -0,0 +1,239 @@
+// Package offline holds what the user asked while the network was down:
+// questions Claude couldn't be sent and recordings speech recognition
+// couldn't hear, to be sent once the network is back.
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"voice-assistant/internal/errs"
+)
+
+// Item is one deferred request: a transcript, or a recording still to be
+// transcribed
+type Item struct {
+	Text  string    `json:"text,omitempty"`
+	Audio string    `json:"audio,omitempty"` // WAV file, removed with the item
+	Added time.Time `json:"added"`
+}
+
+// Cipher encrypts the queue and its recordings before they are written
+type Cipher interface {
+	Seal(plain string) (string, error)
+	Open(value string) (string, error)
+}
+
+// Handler sends one item. It may fill in Text once the audio is
+// transcribed so a later flush doesn't transcribe it again.
+type Handler func(ctx context.Context, item *Item) error
+
+// Queue holds deferred items, saved to a file so they survive a restart
+type Queue struct {
+	path     string
+	maxItems int
+	items    []Item
+	cipher   Cipher // Nil stores the queue and recordings as they are
+	mutex    sync.Mutex
+}
+
+// NewQueue loads the queue saved at path, if any. At most maxItems are
+// kept, 0 for no limit.
+func NewQueue(path string, maxItems int) *Queue {
+	q := &Queue{path: path, maxItems: maxItems}
+	q.load()
+	return q
+}
+
+// SetCipher encrypts the queue and recordings added from now on. A queue
+// saved encrypted is only read once its cipher is set; one saved plain is
+// encrypted straight away.
+func (q *Queue) SetCipher(cipher Cipher) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.cipher = cipher
+	err := q.load()
+	if err != nil {
+		return err
+	}
+	return q.save()
+}
+
+// load reads the saved queue, decrypting it if need be
+func (q *Queue) load() error {
+	data, err := os.ReadFile(q.path)
+	if err != nil {
+		return nil // Nothing queued
+	}
+
+	text := string(data)
+	if q.cipher != nil {
+		text, err = q.cipher.Open(text)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt offline queue: %v", err)
+		}
+	}
+	var items []Item
+	err = json.Unmarshal([]byte(text), &items)
+	if err != nil {
+		return fmt.Errorf("failed to read offline queue: %v", err)
+	}
+	q.items = items
+	return nil
+}
+
+// IsOffline reports whether err means the network is down, so the request
+// should be queued rather than failed
+func IsOffline(err error) bool {
+	return errors.Is(err, errs.ErrNetwork)
+}
+
+// SetMaxItems changes the limit, applied on the next Add
+func (q *Queue) SetMaxItems(maxItems int) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.maxItems = maxItems
+}
+
+// Len returns the number of queued items
+func (q *Queue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.items)
+}
+
+// Add queues an item, dropping the oldest when the queue is full
+func (q *Queue) Add(item Item) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if item.Audio != "" && q.cipher != nil {
+		err := q.sealFile(item.Audio)
+		if err != nil {
+			os.Remove(item.Audio)
+			return fmt.Errorf("failed to encrypt recording: %v", err)
+		}
+	}
+	q.items = append(q.items, item)
+	for q.maxItems > 0 && len(q.items) > q.maxItems {
+		q.remove()
+	}
+	return q.save()
+}
+
+// ReadAudio returns an item's recording, decrypted if need be. It is meant
+// for a Handler, which runs while the queue is locked.
+func (q *Queue) ReadAudio(item *Item) ([]byte, error) {
+	data, err := os.ReadFile(item.Audio)
+	if err != nil {
+		return nil, err
+	}
+	if q.cipher == nil {
+		return data, nil
+	}
+	plain, err := q.cipher.Open(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt recording: %v", err)
+	}
+	return []byte(plain), nil
+}
+
+// Clear drops every queued item along with its recording and returns how
+// many there were
+func (q *Queue) Clear() (int, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	count := len(q.items)
+	for len(q.items) > 0 {
+		q.remove()
+	}
+	return count, q.save()
+}
+
+// Flush sends queued items in order, stopping at the first one that still
+// can't be sent. Items that fail for any other reason are dropped. It
+// returns how many items were sent.
+func (q *Queue) Flush(ctx context.Context, send Handler) (int, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	sent := 0
+	var failure error
+	for len(q.items) > 0 {
+		err := send(ctx, &q.items[0])
+		if IsOffline(err) || ctx.Err() != nil {
+			failure = err
+			break
+		}
+		if err != nil {
+			failure = fmt.Errorf("dropped request from %s: %v", q.items[0].Added.Format("15:04"), err)
+		} else {
+			sent++
+		}
+		q.remove()
+	}
+
+	err := q.save()
+	if err != nil {
+		return sent, err
+	}
+	return sent, failure
+}
+
+// remove drops the oldest item along with its recording
+func (q *Queue) remove() {
+	if q.items[0].Audio != "" {
+		os.Remove(q.items[0].Audio)
+	}
+	q.items = q.items[1:]
+}
+
+// save writes the queue, removing the file once it is empty
+func (q *Queue) save() error {
+	if len(q.items) == 0 {
+		err := os.Remove(q.path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(q.items, "", "  ")
+	if err != nil {
+		return err
+	}
+	if q.cipher != nil {
+		sealed, err := q.cipher.Seal(string(data))
+		if err != nil {
+			return fmt.Errorf("failed to encrypt offline queue: %v", err)
+		}
+		data = []byte(sealed)
+	}
+	err = os.MkdirAll(filepath.Dir(q.path), 0755)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(q.path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to save offline queue: %v", err)
+	}
+	return nil
+}
+
+// sealFile encrypts a recording in place
+func (q *Queue) sealFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sealed, err := q.cipher.Seal(string(data))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(sealed), 0600)
+}
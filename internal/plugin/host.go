@@ -0,0 +1,124 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+)
+
+// IntentPrefix marks intent names that belong to a plugin, as
+// "plugin/<plugin>/<intent>"
+const IntentPrefix = "plugin/"
+
+// Tool is a plugin tool and the plugin that runs it
+type Tool struct {
+	ToolSpec
+	plugin *Plugin
+}
+
+// Call runs the tool
+func (t Tool) Call(ctx context.Context, input json.RawMessage) (string, error) {
+	return t.plugin.CallTool(ctx, t.Name, input)
+}
+
+// Host runs the discovered plugins and routes calls to them
+type Host struct {
+	plugins []*Plugin
+	tools   []Tool
+	intents map[string][]string // Qualified intent names to phrases
+	byName  map[string]*Plugin
+}
+
+// Load starts every plugin in dir except the disabled ones and asks each
+// what it offers. Plugins that fail to start or describe themselves are
+// logged and left out.
+func Load(dir string, disabled []string, timeout time.Duration) *Host {
+	h := &Host{
+		intents: make(map[string][]string),
+		byName:  make(map[string]*Plugin),
+	}
+
+	manifests, err := Discover(dir)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+		return h
+	}
+
+	for _, manifest := range manifests {
+		if contains(disabled, manifest.Name) {
+			log.Printf("🧩 Plugin %s is disabled", manifest.Name)
+			continue
+		}
+		if h.byName[manifest.Name] != nil {
+			log.Printf("⚠️  Skipping duplicate plugin %s in %s", manifest.Name, manifest.Dir)
+			continue
+		}
+
+		p, err := Start(manifest, timeout)
+		if err != nil {
+			log.Printf("❌ %v", err)
+			continue
+		}
+		description, err := p.Describe(context.Background())
+		if err != nil {
+			log.Printf("❌ %v", err)
+			p.Stop()
+			continue
+		}
+
+		h.plugins = append(h.plugins, p)
+		h.byName[p.Name()] = p
+		for _, spec := range description.Tools {
+			h.tools = append(h.tools, Tool{ToolSpec: spec, plugin: p})
+		}
+		for _, spec := range description.Intents {
+			h.intents[IntentPrefix+p.Name()+"/"+spec.Name] = spec.Phrases
+		}
+		log.Printf("🧩 Loaded plugin %s: %d tool(s), %d intent(s)", p.Name(), len(description.Tools), len(description.Intents))
+	}
+	return h
+}
+
+// Tools returns the tools the plugins provide
+func (h *Host) Tools() []Tool {
+	return h.tools
+}
+
+// Intents returns the plugins' intent phrases by qualified intent name
+func (h *Host) Intents() map[string][]string {
+	return h.intents
+}
+
+// HandleIntent runs a plugin intent and returns what to say. ok is false
+// for intents that aren't a plugin's.
+func (h *Host) HandleIntent(ctx context.Context, intent, target, text string) (speak string, ok bool, err error) {
+	if !strings.HasPrefix(intent, IntentPrefix) {
+		return "", false, nil
+	}
+	parts := strings.SplitN(strings.TrimPrefix(intent, IntentPrefix), "/", 2)
+	if len(parts) != 2 || h.byName[parts[0]] == nil {
+		return "", false, nil
+	}
+
+	speak, err = h.byName[parts[0]].HandleIntent(ctx, parts[1], target, text)
+	return speak, true, err
+}
+
+// Stop stops every plugin
+func (h *Host) Stop() {
+	for _, p := range h.plugins {
+		p.Stop()
+	}
+}
+
+// contains reports whether list holds value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// ManifestFile is the file that marks a folder as a plugin
+const ManifestFile = "plugin.json"
+
+// Manifest describes how to start a plugin
+type Manifest struct {
+	Name    string   `json:"name"`
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	Dir     string   `json:"-"` // The plugin's folder
+}
+
+// Discover reads the manifest in each folder of dir. A missing dir means
+// no plugins; broken manifests are logged and skipped.
+func Discover(dir string) ([]Manifest, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugins folder: %v", err)
+	}
+
+	var manifests []Manifest
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		folder := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(filepath.Join(folder, ManifestFile))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			log.Printf("⚠️  Skipping plugin in %s: %v", folder, err)
+			continue
+		}
+
+		var manifest Manifest
+		err = json.Unmarshal(data, &manifest)
+		if err != nil || manifest.Command == "" {
+			log.Printf("⚠️  Skipping plugin in %s: %s needs a command", folder, ManifestFile)
+			continue
+		}
+		if manifest.Name == "" {
+			manifest.Name = entry.Name()
+		}
+		manifest.Dir = folder
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
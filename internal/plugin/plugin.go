@@ -0,0 +1,250 @@
+// Package plugin runs third-party plugins: separate programs that add
+// Claude tools and voice intents without recompiling the assistant.
+//
+// Each plugin lives in its own folder under plugins/ with a plugin.json:
+//
+//	{"name": "spotify", "command": "spotify-plugin.exe", "args": []}
+//
+// The command (relative to the folder) is started once and speaks
+// newline-delimited JSON over stdin/stdout. The assistant sends requests
+//
+//	{"id": 1, "method": "describe"}
+//	{"id": 2, "method": "call_tool", "params": {"name": "...", "input": {...}}}
+//	{"id": 3, "method": "handle_intent", "params": {"name": "...", "target": "...", "text": "..."}}
+//
+// and the plugin answers each with {"id": n, "result": ...} or
+// {"id": n, "error": "message"}. describe returns
+//
+//	{"tools": [{"name": "...", "description": "...", "input_schema": {...}}],
+//	 "intents": [{"name": "...", "phrases": ["play {target} on spotify"]}]}
+//
+// call_tool returns {"text": "..."} for Claude, and handle_intent returns
+// {"speak": "..."}, which may be empty. Anything written to stderr is logged.
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Largest message a plugin may send
+const maxMessageBytes = 4 << 20
+
+// How long a plugin gets to exit after its stdin is closed
+const stopTimeout = 2 * time.Second
+
+// ToolSpec is a Claude tool a plugin provides
+type ToolSpec struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"input_schema"`
+}
+
+// IntentSpec is a voice intent a plugin handles, with phrases in the same
+// form as the built-in intents
+type IntentSpec struct {
+	Name    string   `json:"name"`
+	Phrases []string `json:"phrases"`
+}
+
+// Description is what a plugin offers
+type Description struct {
+	Tools   []ToolSpec   `json:"tools"`
+	Intents []IntentSpec `json:"intents"`
+}
+
+// request is a message to the plugin
+type request struct {
+	ID     int         `json:"id"`
+	Method string      `json:"method"`
+	Params interface{} `json:"params,omitempty"`
+}
+
+// response is a plugin's answer to a request
+type response struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+}
+
+// Plugin is a running plugin process
+type Plugin struct {
+	manifest Manifest
+	timeout  time.Duration
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	done     chan struct{} // Closed when the process's output ends
+
+	mutex   sync.Mutex
+	nextID  int
+	pending map[int]chan response
+}
+
+// Start runs a plugin's program
+func Start(manifest Manifest, timeout time.Duration) (*Plugin, error) {
+	command := manifest.Command
+	if !filepath.IsAbs(command) {
+		command = filepath.Join(manifest.Dir, command)
+	}
+	cmd := exec.Command(command, manifest.Args...)
+	cmd.Dir = manifest.Dir
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start plugin %s: %v", manifest.Name, err)
+	}
+
+	p := &Plugin{
+		manifest: manifest,
+		timeout:  timeout,
+		cmd:      cmd,
+		stdin:    stdin,
+		done:     make(chan struct{}),
+		pending:  make(map[int]chan response),
+	}
+	go p.readResponses(stdout)
+	go p.logErrors(stderr)
+	return p, nil
+}
+
+// Name returns the plugin's name
+func (p *Plugin) Name() string {
+	return p.manifest.Name
+}
+
+// Describe asks the plugin for its tools and intents
+func (p *Plugin) Describe(ctx context.Context) (Description, error) {
+	var description Description
+	err := p.call(ctx, "describe", nil, &description)
+	return description, err
+}
+
+// CallTool runs one of the plugin's tools
+func (p *Plugin) CallTool(ctx context.Context, name string, input json.RawMessage) (string, error) {
+	var result struct {
+		Text string `json:"text"`
+	}
+	params := map[string]interface{}{"name": name, "input": input}
+	err := p.call(ctx, "call_tool", params, &result)
+	return result.Text, err
+}
+
+// HandleIntent runs one of the plugin's intents and returns what to say
+func (p *Plugin) HandleIntent(ctx context.Context, name, target, text string) (string, error) {
+	var result struct {
+		Speak string `json:"speak"`
+	}
+	params := map[string]string{"name": name, "target": target, "text": text}
+	err := p.call(ctx, "handle_intent", params, &result)
+	return result.Speak, err
+}
+
+// Stop closes the plugin's input and kills it if it doesn't exit
+func (p *Plugin) Stop() {
+	p.stdin.Close()
+	select {
+	case <-p.done:
+	case <-time.After(stopTimeout):
+		p.cmd.Process.Kill()
+	}
+	p.cmd.Wait()
+}
+
+// call sends a request and waits for its response
+func (p *Plugin) call(ctx context.Context, method string, params, result interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	p.mutex.Lock()
+	p.nextID++
+	id := p.nextID
+	reply := make(chan response, 1)
+	p.pending[id] = reply
+
+	data, err := json.Marshal(request{ID: id, Method: method, Params: params})
+	if err == nil {
+		_, err = p.stdin.Write(append(data, '\n'))
+	}
+	p.mutex.Unlock()
+
+	defer func() {
+		p.mutex.Lock()
+		delete(p.pending, id)
+		p.mutex.Unlock()
+	}()
+	if err != nil {
+		return fmt.Errorf("plugin %s: %v", p.Name(), err)
+	}
+
+	select {
+	case r := <-reply:
+		if r.Error != "" {
+			return fmt.Errorf("plugin %s: %s", p.Name(), r.Error)
+		}
+		if result == nil || len(r.Result) == 0 {
+			return nil
+		}
+		return json.Unmarshal(r.Result, result)
+	case <-p.done:
+		return fmt.Errorf("plugin %s exited", p.Name())
+	case <-ctx.Done():
+		return fmt.Errorf("plugin %s: %s: %v", p.Name(), method, ctx.Err())
+	}
+}
+
+// readResponses hands each response to the call waiting for it
+func (p *Plugin) readResponses(stdout io.Reader) {
+	defer close(p.done)
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageBytes)
+	for scanner.Scan() {
+		var r response
+		err := json.Unmarshal(scanner.Bytes(), &r)
+		if err != nil {
+			log.Printf("⚠️  Plugin %s sent invalid JSON: %v", p.Name(), err)
+			continue
+		}
+
+		p.mutex.Lock()
+		reply, ok := p.pending[r.ID]
+		p.mutex.Unlock()
+		if ok {
+			select {
+			case reply <- r:
+			default: // A duplicate answer; the first one wins
+			}
+		}
+	}
+}
+
+// logErrors logs what the plugin writes to stderr
+func (p *Plugin) logErrors(stderr io.Reader) {
+	scanner := bufio.NewScanner(stderr)
+	for scanner.Scan() {
+		log.Printf("🧩 [%s] %s", p.Name(), scanner.Text())
+	}
+}
@@ -0,0 +1,68 @@
+// Package proxy routes the assistant's cloud traffic through an HTTP
+// proxy, either configured explicitly or taken from the environment.
+package proxy
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// The configured proxy, nil to follow the environment, and every transport
+// using it
+var (
+	proxyURL   *url.URL
+	transports []*http.Transport
+	proxyMutex sync.RWMutex
+)
+
+// Transport is shared by the HTTP clients that go through the proxy
+var Transport = NewTransport()
+
+// Dialer opens WebSockets through the proxy
+var Dialer = &websocket.Dialer{
+	Proxy:            ForRequest,
+	HandshakeTimeout: 45 * time.Second,
+}
+
+// NewTransport copies the default transport, swapping in ForRequest, for
+// clients that tune their own timeouts and pooling
+func NewTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = ForRequest
+
+	proxyMutex.Lock()
+	transports = append(transports, transport)
+	proxyMutex.Unlock()
+	return transport
+}
+
+// Set sends traffic through proxy, which may carry a username and
+// password, or through whatever HTTP_PROXY and HTTPS_PROXY say when nil
+func Set(proxy *url.URL) {
+	proxyMutex.Lock()
+	proxyURL = proxy
+	current := transports
+	proxyMutex.Unlock()
+
+	// Kept-alive connections still go the old way
+	for _, transport := range current {
+		transport.CloseIdleConnections()
+	}
+}
+
+// ForRequest picks the proxy for a request, for http.Transport and
+// websocket.Dialer
+func ForRequest(req *http.Request) (*url.URL, error) {
+	proxyMutex.RLock()
+	proxy := proxyURL
+	proxyMutex.RUnlock()
+
+	if proxy != nil {
+		return proxy, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
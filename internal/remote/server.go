@@ -0,0 +1,333 @@
+package remote
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"voice-assistant/internal/audio"
+)
+
+// Pairing limits
+const (
+	PairingCodeDigits = 6
+	MaxPairingTries   = 5       // Wrong codes before the pairing window closes
+	MaxMessageBytes   = 1 << 20 // Largest audio message accepted
+)
+
+// Server accepts microphone audio from paired devices over a WebSocket.
+//
+// Pairing: BeginPairing returns a short code the user types on the device,
+// which POSTs {"code": "...", "name": "..."} to /pair and receives a token.
+// The device then streams audio as binary messages to
+// /mic?rate=48000&channels=1: 16-bit little-endian PCM, or one raw Opus
+// packet per message with &encoding=opus.
+//
+// Devices authenticate with "Authorization: Bearer <token>". Browsers
+// can't set headers on a WebSocket, so ?token=<token> is accepted too, but
+// URLs end up in logs and the connection is plain ws://, so anyone on the
+// network can read the token; prefer the header wherever the client allows.
+type Server struct {
+	addr     string
+	source   *Source
+	server   *http.Server
+	upgrader websocket.Upgrader
+
+	mutex         sync.Mutex
+	tokens        []string
+	pairingCode   string
+	pairingUntil  time.Time
+	pairingMisses int
+	onPaired      func(token, name string)
+	connection    *websocket.Conn // The device currently streaming
+}
+
+// NewServer creates a server on addr (e.g. ":8765") accepting the given
+// device tokens
+func NewServer(addr string, tokens []string) *Server {
+	return &Server{
+		addr:   addr,
+		source: &Source{},
+		tokens: append([]string(nil), tokens...),
+		upgrader: websocket.Upgrader{
+			// Devices aren't web pages on this origin; the token is the check
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// Source returns the capture source fed by connected devices
+func (s *Server) Source() *Source {
+	return s.source
+}
+
+// SetPairedCallback sets the function told about each newly paired
+// device, e.g. to save its token
+func (s *Server) SetPairedCallback(onPaired func(token, name string)) {
+	s.onPaired = onPaired
+}
+
+// Start listens for devices in the background
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", s.addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pair", s.handlePair)
+	mux.HandleFunc("/mic", s.handleMic)
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		err := s.server.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			log.Printf("❌ Remote microphone server stopped: %v", err)
+		}
+	}()
+	log.Printf("📱 Remote microphone server listening on %s", listener.Addr())
+	return nil
+}
+
+// Stop closes the listener and any streaming device
+func (s *Server) Stop() {
+	if s.server != nil {
+		s.server.Close()
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.connection != nil {
+		s.connection.Close()
+		s.connection = nil
+	}
+}
+
+// SetTokens replaces the accepted device tokens
+func (s *Server) SetTokens(tokens []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tokens = append([]string(nil), tokens...)
+}
+
+// BeginPairing opens a pairing window and returns the code to enter on
+// the device
+func (s *Server) BeginPairing(window time.Duration) (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < PairingCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate pairing code: %v", err)
+	}
+	code := fmt.Sprintf("%0*d", PairingCodeDigits, n)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pairingCode = code
+	s.pairingUntil = time.Now().Add(window)
+	s.pairingMisses = 0
+	return code, nil
+}
+
+// URL returns the address devices should connect to on this network
+func (s *Server) URL() string {
+	_, port, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		port = s.addr
+	}
+	return fmt.Sprintf("ws://%s/mic", net.JoinHostPort(lanAddress(), port))
+}
+
+// handlePair exchanges a valid pairing code for a new device token
+func (s *Server) handlePair(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST a pairing code", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var request struct {
+		Code string `json:"code"`
+		Name string `json:"name"`
+	}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&request)
+	if err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	token, err := s.pair(request.Code)
+	if err != nil {
+		log.Printf("📱 Pairing attempt from %s refused: %v", r.RemoteAddr, err)
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	name := request.Name
+	if name == "" {
+		name = r.RemoteAddr
+	}
+	log.Printf("📱 Paired device %q", name)
+	if s.onPaired != nil {
+		s.onPaired(token, name)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"token": token})
+}
+
+// pair checks a pairing code and issues a token. The window closes after
+// one success or too many wrong codes.
+func (s *Server) pair(code string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.pairingCode == "" || time.Now().After(s.pairingUntil) {
+		return "", fmt.Errorf("pairing is not open")
+	}
+	if subtle.ConstantTimeCompare([]byte(code), []byte(s.pairingCode)) != 1 {
+		s.pairingMisses++
+		if s.pairingMisses >= MaxPairingTries {
+			s.pairingCode = ""
+		}
+		return "", fmt.Errorf("wrong pairing code")
+	}
+	s.pairingCode = ""
+
+	raw := make([]byte, 32)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %v", err)
+	}
+	token := hex.EncodeToString(raw)
+	s.tokens = append(s.tokens, token)
+	return token, nil
+}
+
+// authorized reports whether the request carries a paired device's token
+func (s *Server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		token = r.URL.Query().Get("token")
+	}
+	if token == "" {
+		return false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, known := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(known)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// handleMic receives audio from one device at a time; a new connection
+// replaces the previous one
+func (s *Server) handleMic(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		http.Error(w, "unknown device", http.StatusUnauthorized)
+		return
+	}
+
+	rate, channels := audio.SampleRate, 1
+	if value, err := strconv.Atoi(r.URL.Query().Get("rate")); err == nil && value > 0 {
+		rate = value
+	}
+	if value, err := strconv.Atoi(r.URL.Query().Get("channels")); err == nil && value > 0 {
+		channels = value
+	}
+
+	var decoder *audio.OpusDecoder
+	switch encoding := r.URL.Query().Get("encoding"); encoding {
+	case "", "pcm":
+	case "opus":
+		var err error
+		decoder, err = audio.NewOpusDecoder(rate, channels)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, fmt.Sprintf("unsupported encoding %q", encoding), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("❌ Remote microphone upgrade failed: %v", err)
+		return
+	}
+	conn.SetReadLimit(MaxMessageBytes)
+
+	s.mutex.Lock()
+	if s.connection != nil {
+		s.connection.Close()
+	}
+	s.connection = conn
+	s.mutex.Unlock()
+
+	log.Printf("📱 Remote microphone connected from %s (%d Hz, %d channel(s), opus: %v)", r.RemoteAddr, rate, channels, decoder != nil)
+	defer func() {
+		s.mutex.Lock()
+		if s.connection == conn {
+			s.connection = nil
+		}
+		s.mutex.Unlock()
+		conn.Close()
+		log.Printf("📱 Remote microphone disconnected")
+	}()
+
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if messageType != websocket.BinaryMessage {
+			continue
+		}
+
+		var samples []int16
+		if decoder != nil {
+			samples, err = decoder.Decode(data)
+			if err != nil {
+				log.Printf("⚠️  Remote microphone: %v", err)
+				continue
+			}
+		} else {
+			samples = make([]int16, len(data)/2)
+			for i := range samples {
+				samples[i] = int16(binary.LittleEndian.Uint16(data[i*2:]))
+			}
+		}
+		samples = audio.Resample(audio.ToMono(samples, channels), rate, audio.SampleRate)
+		s.source.deliver(samples)
+	}
+}
+
+// lanAddress finds this machine's address on the local network. No
+// packets are sent; the route lookup picks the interface.
+func lanAddress() string {
+	conn, err := net.Dial("udp", "192.0.2.1:9")
+	if err != nil {
+		return "localhost"
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP.String()
+}
@@ -0,0 +1,45 @@
+package remote
+
+import (
+	"sync"
+)
+
+// SourceName identifies the remote microphone in logs and config
+const SourceName = "remote"
+
+// Source is an audio.Source fed by whichever device is streaming to the
+// server. Frames only flow while both a device is connected and the source
+// is started.
+type Source struct {
+	onFrame func([]int16)
+	mutex   sync.Mutex
+}
+
+// Name identifies the source in logs
+func (s *Source) Name() string {
+	return SourceName
+}
+
+// Start begins handing received audio to onFrame
+func (s *Source) Start(onFrame func(frame []int16)) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.onFrame = onFrame
+	return nil
+}
+
+// Stop discards received audio until the next Start
+func (s *Source) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.onFrame = nil
+}
+
+// deliver passes a 16kHz mono frame to the current consumer, if any
+func (s *Source) deliver(frame []int16) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.onFrame != nil {
+		s.onFrame(frame)
+	}
+}
@@ -0,0 +1,180 @@
+package retention
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"voice-assistant/internal/audio"
+)
+
+// Files written into each session folder
+const (
+	SessionAudioFile      = "audio.wav"
+	SessionTranscriptFile = "transcript.txt"
+)
+
+// ArchivePolicy limits how much the session archive keeps. Zero values
+// mean no limit.
+type ArchivePolicy struct {
+	MaxDays int
+	MaxGB   float64
+}
+
+// Archive keeps the raw audio and transcript of every session in its own
+// folder, so what was actually said can be reviewed later
+type Archive struct {
+	dir     string
+	policy  ArchivePolicy
+	janitor janitor
+}
+
+// NewArchive creates a session archive rooted at dir
+func NewArchive(dir string, policy ArchivePolicy) *Archive {
+	return &Archive{
+		dir:    dir,
+		policy: policy,
+	}
+}
+
+// Dir returns the folder sessions are written to
+func (a *Archive) Dir() string {
+	return a.dir
+}
+
+// SaveSession writes one session's audio and transcript
+func (a *Archive) SaveSession(samples []int16, transcript string) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	dir := filepath.Join(a.dir, time.Now().Format("20060102_150405.000"))
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create session folder: %v", err)
+	}
+
+	err = audio.WriteWAVFile(filepath.Join(dir, SessionAudioFile), samples)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(dir, SessionTranscriptFile), []byte(transcript), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write transcript: %v", err)
+	}
+
+	log.Printf("🗄️  Archived session: %s", dir)
+	return nil
+}
+
+// StartJanitor periodically enforces the archive limits
+func (a *Archive) StartJanitor() {
+	a.janitor.start(a.Enforce)
+}
+
+// Stop stops the janitor
+func (a *Archive) Stop() {
+	a.janitor.halt()
+}
+
+// DeleteAll removes every archived session
+func (a *Archive) DeleteAll() (int, error) {
+	sessions, err := a.sessions()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, s := range sessions {
+		err := os.RemoveAll(s.path)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete %s: %v", s.path, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// session is an archived session folder
+type session struct {
+	path     string
+	modified time.Time
+	size     int64
+}
+
+// Enforce deletes sessions older than MaxDays, then the oldest sessions
+// until the archive fits in MaxGB
+func (a *Archive) Enforce() {
+	sessions, err := a.sessions()
+	if err != nil {
+		log.Printf("⚠️  Failed to read session archive: %v", err)
+		return
+	}
+
+	var total int64
+	for _, s := range sessions {
+		total += s.size
+	}
+	limit := int64(a.policy.MaxGB * 1024 * 1024 * 1024)
+	cutoff := time.Now().AddDate(0, 0, -a.policy.MaxDays)
+
+	deleted := 0
+	for _, s := range sessions {
+		expired := a.policy.MaxDays > 0 && s.modified.Before(cutoff)
+		oversize := limit > 0 && total > limit
+		if !expired && !oversize {
+			continue
+		}
+
+		err := os.RemoveAll(s.path)
+		if err != nil {
+			log.Printf("⚠️  Failed to delete %s: %v", s.path, err)
+			continue
+		}
+		total -= s.size
+		deleted++
+	}
+
+	if deleted > 0 {
+		log.Printf("🧹 Deleted %d archived sessions", deleted)
+	}
+}
+
+// sessions lists archived sessions, oldest first
+func (a *Archive) sessions() ([]session, error) {
+	entries, err := os.ReadDir(a.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []session
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		s := session{path: filepath.Join(a.dir, entry.Name()), modified: info.ModTime()}
+		files, _ := os.ReadDir(s.path)
+		for _, file := range files {
+			if fileInfo, err := file.Info(); err == nil {
+				s.size += fileInfo.Size()
+			}
+		}
+		sessions = append(sessions, s)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].modified.Before(sessions[j].modified)
+	})
+	return sessions, nil
+}
@@ -0,0 +1,45 @@
+package retention
+
+import (
+	"sync"
+	"time"
+)
+
+// janitor runs a sweep at once and then every JanitorInterval until stopped
+type janitor struct {
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// start begins sweeping in the background
+func (j *janitor) start(sweep func()) {
+	j.stop = make(chan struct{})
+	j.done = make(chan struct{})
+
+	go func(stop <-chan struct{}, done chan<- struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(JanitorInterval)
+		defer ticker.Stop()
+
+		sweep()
+		for {
+			select {
+			case <-ticker.C:
+				sweep()
+			case <-stop:
+				return
+			}
+		}
+	}(j.stop, j.done)
+}
+
+// halt stops sweeping and waits for a sweep in progress to finish. It
+// does nothing if the janitor was never started.
+func (j *janitor) halt() {
+	if j.stop == nil {
+		return
+	}
+	j.stopOnce.Do(func() { close(j.stop) })
+	<-j.done
+}
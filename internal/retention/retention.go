@@ -0,0 +1,139 @@
+package retention
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"voice-assistant/internal/audio"
+)
+
+// Retention modes
+const (
+	Never   = "never"   // Don't keep audio at all
+	Days    = "days"    // Keep audio for a number of days
+	Forever = "forever" // Keep audio until deleted by hand
+)
+
+// How often the janitor enforces the policy
+const JanitorInterval = time.Hour
+
+// Policy decides how long per-turn audio is kept
+type Policy struct {
+	Mode string
+	Days int
+}
+
+// Store saves per-turn audio snippets and deletes them according to policy
+type Store struct {
+	dir     string
+	policy  Policy
+	janitor janitor
+}
+
+// NewStore creates an audio store rooted at dir
+func NewStore(dir string, policy Policy) *Store {
+	return &Store{
+		dir:    dir,
+		policy: policy,
+	}
+}
+
+// Enabled reports whether audio is being kept at all
+func (s *Store) Enabled() bool {
+	return s.policy.Mode == Days || s.policy.Mode == Forever
+}
+
+// SaveTurn stores the audio of one turn
+func (s *Store) SaveTurn(samples []int16) error {
+	if !s.Enabled() || len(samples) == 0 {
+		return nil
+	}
+
+	name := fmt.Sprintf("turn_%s.wav", time.Now().Format("20060102_150405.000"))
+	path := filepath.Join(s.dir, name)
+	err := audio.WriteWAVFile(path, samples)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("💾 Saved turn audio: %s", path)
+	return nil
+}
+
+// StartJanitor periodically deletes audio older than the retention period
+func (s *Store) StartJanitor() {
+	s.janitor.start(s.Enforce)
+}
+
+// Stop stops the janitor
+func (s *Store) Stop() {
+	s.janitor.halt()
+}
+
+// Enforce deletes audio the policy no longer allows keeping
+func (s *Store) Enforce() {
+	switch s.policy.Mode {
+	case Forever:
+		return
+	case Days:
+		cutoff := time.Now().AddDate(0, 0, -s.policy.Days)
+		s.deleteMatching(func(info os.FileInfo) bool {
+			return info.ModTime().Before(cutoff)
+		})
+	default:
+		// "never" also cleans up anything left from a previous policy
+		s.deleteMatching(func(info os.FileInfo) bool { return true })
+	}
+}
+
+// DeleteToday removes everything recorded since midnight
+func (s *Store) DeleteToday() (int, error) {
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return s.deleteMatching(func(info os.FileInfo) bool {
+		return !info.ModTime().Before(midnight)
+	})
+}
+
+// DeleteAll removes every stored audio file
+func (s *Store) DeleteAll() (int, error) {
+	return s.deleteMatching(func(info os.FileInfo) bool { return true })
+}
+
+// deleteMatching removes stored audio files selected by match
+func (s *Store) deleteMatching(match func(os.FileInfo) bool) (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".wav") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !match(info) {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			log.Printf("⚠️  Failed to delete %s: %v", entry.Name(), err)
+			continue
+		}
+		deleted++
+	}
+
+	if deleted > 0 {
+		log.Printf("🧹 Deleted %d stored audio files", deleted)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,122 @@
+// Package schedule fires callbacks at times of day, like an alarm clock
+package schedule
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How often the scheduler checks the clock. Polling rather than one long
+// timer keeps it right across sleep, resume and clock changes.
+const checkInterval = 30 * time.Second
+
+// MaxLateness is how late a missed time may still fire, e.g. after the
+// computer wakes from sleep; older ones are skipped
+const MaxLateness = 10 * time.Minute
+
+// weekdays maps day names to weekdays
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Schedule is a set of times of day on some days of the week
+type Schedule struct {
+	minutes []int // Minutes after midnight
+	days    map[time.Weekday]bool
+}
+
+// Parse builds a schedule from "HH:MM" times and day names such as "mon"
+// or "monday"; no days means every day
+func Parse(times, days []string) (*Schedule, error) {
+	s := &Schedule{days: make(map[time.Weekday]bool)}
+	for _, value := range times {
+		t, err := time.Parse("15:04", strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q, expected HH:MM", value)
+		}
+		s.minutes = append(s.minutes, t.Hour()*60+t.Minute())
+	}
+	for _, value := range days {
+		name := strings.ToLower(strings.TrimSpace(value))
+		if len(name) > 3 {
+			name = name[:3]
+		}
+		day, ok := weekdays[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid day %q", value)
+		}
+		s.days[day] = true
+	}
+	return s, nil
+}
+
+// IsEmpty reports whether the schedule never fires
+func (s *Schedule) IsEmpty() bool {
+	return len(s.minutes) == 0
+}
+
+// Next returns the first scheduled time strictly after t
+func (s *Schedule) Next(t time.Time) time.Time {
+	var next time.Time
+	for day := 0; day <= 7 && next.IsZero(); day++ {
+		date := time.Date(t.Year(), t.Month(), t.Day()+day, 0, 0, 0, 0, t.Location())
+		if len(s.days) > 0 && !s.days[date.Weekday()] {
+			continue
+		}
+		for _, minute := range s.minutes {
+			candidate := date.Add(time.Duration(minute) * time.Minute)
+			if candidate.After(t) && (next.IsZero() || candidate.Before(next)) {
+				next = candidate
+			}
+		}
+	}
+	return next
+}
+
+// Scheduler calls a function at each time in a schedule
+type Scheduler struct {
+	schedule *Schedule
+	fire     func()
+	stop     chan struct{}
+	once     sync.Once
+}
+
+// Start calls fire in the background at each scheduled time
+func Start(schedule *Schedule, fire func()) *Scheduler {
+	s := &Scheduler{schedule: schedule, fire: fire, stop: make(chan struct{})}
+	go s.run()
+	return s
+}
+
+// Stop ends the schedule
+func (s *Scheduler) Stop() {
+	s.once.Do(func() { close(s.stop) })
+}
+
+// run waits for each scheduled time
+func (s *Scheduler) run() {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	next := s.schedule.Next(time.Now())
+	for !next.IsZero() {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			if now.Before(next) {
+				continue
+			}
+			if now.Sub(next) <= MaxLateness {
+				s.fire()
+			} else {
+				log.Printf("⏰ Skipped the %s schedule, missed by %v", next.Format("15:04"), now.Sub(next).Round(time.Minute))
+			}
+			next = s.schedule.Next(now)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package screen captures what is on the user's screen so it can be shared
+// with Claude as image context.
+package screen
+
+import (
+	"fmt"
+	"image"
+	"syscall"
+	"unsafe"
+)
+
+// Capture targets
+const (
+	TargetMonitor = "monitor" // The monitor showing the foreground window
+	TargetWindow  = "window"  // Only the foreground window
+)
+
+const (
+	MONITOR_DEFAULTTONEAREST = 2
+	SRCCOPY                  = 0x00CC0020
+	CAPTUREBLT               = 0x40000000
+	DIB_RGB_COLORS           = 0
+	BI_RGB                   = 0
+)
+
+var (
+	user32                 = syscall.NewLazyDLL("user32.dll")
+	gdi32                  = syscall.NewLazyDLL("gdi32.dll")
+	getForegroundWindow    = user32.NewProc("GetForegroundWindow")
+	getWindowRect          = user32.NewProc("GetWindowRect")
+	monitorFromWindow      = user32.NewProc("MonitorFromWindow")
+	getMonitorInfo         = user32.NewProc("GetMonitorInfoW")
+	getDC                  = user32.NewProc("GetDC")
+	releaseDC              = user32.NewProc("ReleaseDC")
+	createCompatibleDC     = gdi32.NewProc("CreateCompatibleDC")
+	createCompatibleBitmap = gdi32.NewProc("CreateCompatibleBitmap")
+	selectObject           = gdi32.NewProc("SelectObject")
+	bitBlt                 = gdi32.NewProc("BitBlt")
+	getDIBits              = gdi32.NewProc("GetDIBits")
+	deleteObject           = gdi32.NewProc("DeleteObject")
+	deleteDC               = gdi32.NewProc("DeleteDC")
+)
+
+type rect struct {
+	Left, Top, Right, Bottom int32
+}
+
+type monitorInfo struct {
+	Size    uint32
+	Monitor rect
+	Work    rect
+	Flags   uint32
+}
+
+type bitmapInfoHeader struct {
+	Size          uint32
+	Width         int32
+	Height        int32
+	Planes        uint16
+	BitCount      uint16
+	Compression   uint32
+	SizeImage     uint32
+	XPelsPerMeter int32
+	YPelsPerMeter int32
+	ClrUsed       uint32
+	ClrImportant  uint32
+}
+
+// Capture grabs the foreground window or the monitor it is on
+func Capture(target string) (*image.RGBA, error) {
+	hwnd, _, _ := getForegroundWindow.Call()
+	if hwnd == 0 {
+		return nil, fmt.Errorf("no foreground window")
+	}
+
+	var bounds rect
+	if target == TargetWindow {
+		ret, _, err := getWindowRect.Call(hwnd, uintptr(unsafe.Pointer(&bounds)))
+		if ret == 0 {
+			return nil, fmt.Errorf("GetWindowRect failed: %v", err)
+		}
+	} else {
+		monitor, _, _ := monitorFromWindow.Call(hwnd, MONITOR_DEFAULTTONEAREST)
+		info := monitorInfo{Size: uint32(unsafe.Sizeof(monitorInfo{}))}
+		ret, _, err := getMonitorInfo.Call(monitor, uintptr(unsafe.Pointer(&info)))
+		if ret == 0 {
+			return nil, fmt.Errorf("GetMonitorInfo failed: %v", err)
+		}
+		bounds = info.Monitor
+	}
+
+	return captureRect(bounds)
+}
+
+// captureRect copies a region of the desktop into an image
+func captureRect(bounds rect) (*image.RGBA, error) {
+	width := int(bounds.Right - bounds.Left)
+	height := int(bounds.Bottom - bounds.Top)
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("nothing to capture (%dx%d)", width, height)
+	}
+
+	screenDC, _, _ := getDC.Call(0)
+	if screenDC == 0 {
+		return nil, fmt.Errorf("GetDC failed")
+	}
+	defer releaseDC.Call(0, screenDC)
+
+	memDC, _, _ := createCompatibleDC.Call(screenDC)
+	if memDC == 0 {
+		return nil, fmt.Errorf("CreateCompatibleDC failed")
+	}
+	defer deleteDC.Call(memDC)
+
+	bitmap, _, _ := createCompatibleBitmap.Call(screenDC, uintptr(width), uintptr(height))
+	if bitmap == 0 {
+		return nil, fmt.Errorf("CreateCompatibleBitmap failed")
+	}
+	defer deleteObject.Call(bitmap)
+
+	previous, _, _ := selectObject.Call(memDC, bitmap)
+	defer selectObject.Call(memDC, previous)
+
+	ret, _, err := bitBlt.Call(memDC, 0, 0, uintptr(width), uintptr(height),
+		screenDC, uintptr(bounds.Left), uintptr(bounds.Top), SRCCOPY|CAPTUREBLT)
+	if ret == 0 {
+		return nil, fmt.Errorf("BitBlt failed: %v", err)
+	}
+
+	// A negative height asks for top-down rows
+	header := bitmapInfoHeader{
+		Width:       int32(width),
+		Height:      -int32(height),
+		Planes:      1,
+		BitCount:    32,
+		Compression: BI_RGB,
+	}
+	header.Size = uint32(unsafe.Sizeof(header))
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	ret, _, err = getDIBits.Call(memDC, bitmap, 0, uintptr(height),
+		uintptr(unsafe.Pointer(&img.Pix[0])), uintptr(unsafe.Pointer(&header)), DIB_RGB_COLORS)
+	if ret == 0 {
+		return nil, fmt.Errorf("GetDIBits failed: %v", err)
+	}
+
+	// GDI returns BGRA with an undefined alpha byte
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+2] = img.Pix[i+2], img.Pix[i]
+		img.Pix[i+3] = 0xFF
+	}
+	return img, nil
+}
@@ -0,0 +1,68 @@
+package screen
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+)
+
+// JPEGQuality balances legibility of on-screen text against upload size
+const JPEGQuality = 85
+
+// Downscale shrinks img so its longest edge is at most maxEdge pixels,
+// averaging the source pixels that fall into each output pixel
+func Downscale(img *image.RGBA, maxEdge int) *image.RGBA {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	longest := width
+	if height > longest {
+		longest = height
+	}
+	if maxEdge <= 0 || longest <= maxEdge {
+		return img
+	}
+
+	outWidth := width * maxEdge / longest
+	outHeight := height * maxEdge / longest
+	if outWidth < 1 {
+		outWidth = 1
+	}
+	if outHeight < 1 {
+		outHeight = 1
+	}
+	out := image.NewRGBA(image.Rect(0, 0, outWidth, outHeight))
+
+	for y := 0; y < outHeight; y++ {
+		y0, y1 := y*height/outHeight, (y+1)*height/outHeight
+		for x := 0; x < outWidth; x++ {
+			x0, x1 := x*width/outWidth, (x+1)*width/outWidth
+
+			var r, g, b, count int
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					i := img.PixOffset(img.Bounds().Min.X+sx, img.Bounds().Min.Y+sy)
+					r += int(img.Pix[i])
+					g += int(img.Pix[i+1])
+					b += int(img.Pix[i+2])
+					count++
+				}
+			}
+
+			o := out.PixOffset(x, y)
+			out.Pix[o] = uint8(r / count)
+			out.Pix[o+1] = uint8(g / count)
+			out.Pix[o+2] = uint8(b / count)
+			out.Pix[o+3] = 0xFF
+		}
+	}
+	return out
+}
+
+// EncodeJPEG encodes an image for upload
+func EncodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: JPEGQuality})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
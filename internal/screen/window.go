@@ -0,0 +1,78 @@
+package screen
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const PROCESS_QUERY_LIMITED_INFORMATION = 0x1000
+
+var (
+	kernel32                  = syscall.NewLazyDLL("kernel32.dll")
+	getWindowTextW            = user32.NewProc("GetWindowTextW")
+	getWindowThreadProcessId  = user32.NewProc("GetWindowThreadProcessId")
+	openProcess               = kernel32.NewProc("OpenProcess")
+	closeHandle               = kernel32.NewProc("CloseHandle")
+	queryFullProcessImageName = kernel32.NewProc("QueryFullProcessImageNameW")
+)
+
+// Window describes the window the user is working in
+type Window struct {
+	Title string
+	App   string // Executable name without extension, e.g. "EXCEL"
+}
+
+// ActiveWindow returns the title and application of the foreground window
+func ActiveWindow() (Window, error) {
+	hwnd, _, _ := getForegroundWindow.Call()
+	if hwnd == 0 {
+		return Window{}, fmt.Errorf("no foreground window")
+	}
+
+	title := make([]uint16, 512)
+	getWindowTextW.Call(hwnd, uintptr(unsafe.Pointer(&title[0])), uintptr(len(title)))
+
+	window := Window{Title: syscall.UTF16ToString(title)}
+
+	var pid uint32
+	getWindowThreadProcessId.Call(hwnd, uintptr(unsafe.Pointer(&pid)))
+	if pid != 0 {
+		window.App = processName(pid)
+	}
+	return window, nil
+}
+
+// processName returns the executable name of a process, or "" if unknown
+func processName(pid uint32) string {
+	process, _, _ := openProcess.Call(PROCESS_QUERY_LIMITED_INFORMATION, 0, uintptr(pid))
+	if process == 0 {
+		return ""
+	}
+	defer closeHandle.Call(process)
+
+	path := make([]uint16, syscall.MAX_PATH)
+	size := uint32(len(path))
+	ret, _, _ := queryFullProcessImageName.Call(process, 0, uintptr(unsafe.Pointer(&path[0])), uintptr(unsafe.Pointer(&size)))
+	if ret == 0 {
+		return ""
+	}
+
+	name := filepath.Base(syscall.UTF16ToString(path[:size]))
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+// Describe phrases the window as prompt context for Claude
+func (w Window) Describe() string {
+	switch {
+	case w.App != "" && w.Title != "":
+		return fmt.Sprintf("The user is currently working in %s, in the window titled %q.", w.App, w.Title)
+	case w.App != "":
+		return fmt.Sprintf("The user is currently working in %s.", w.App)
+	case w.Title != "":
+		return fmt.Sprintf("The user is currently working in the window titled %q.", w.Title)
+	}
+	return ""
+}
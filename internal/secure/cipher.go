@@ -0,0 +1,122 @@
+// Package secure encrypts data at rest with a key that is itself protected
+// by the operating system (DPAPI), so copies of the config folder are
+// useless without the user's Windows login.
+package secure
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SealedPrefix marks values encrypted by a Cipher
+const SealedPrefix = "enc:v1:"
+
+// keySize is an AES-256 key
+const keySize = 32
+
+// ErrCorrupt is returned for sealed values that can't be decrypted
+var ErrCorrupt = errors.New("encrypted value is corrupt or was sealed with another key")
+
+// Cipher seals strings with AES-GCM
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// LoadCipher reads the DPAPI-protected key at path, creating it on first use
+func LoadCipher(path string) (*Cipher, error) {
+	key, err := loadKey(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// loadKey unprotects the key file, or generates and protects a new key
+func loadKey(path string) ([]byte, error) {
+	protected, err := os.ReadFile(path)
+	if err == nil {
+		key, err := Unprotect(protected)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unlock encryption key: %v", err)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read encryption key: %v", err)
+	}
+
+	key := make([]byte, keySize)
+	_, err = rand.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	protected, err = Protect(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to protect encryption key: %v", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(path, protected, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save encryption key: %v", err)
+	}
+	return key, nil
+}
+
+// Seal encrypts a value; empty and already sealed values are returned as is
+func (c *Cipher) Seal(plain string) (string, error) {
+	if plain == "" || IsSealed(plain) {
+		return plain, nil
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return SealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a sealed value; plain values are returned as is so data
+// written before encryption was enabled stays readable
+func (c *Cipher) Open(value string) (string, error) {
+	if !IsSealed(value) {
+		return value, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, SealedPrefix))
+	if err != nil || len(data) < c.aead.NonceSize() {
+		return "", ErrCorrupt
+	}
+	nonce, sealed := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", ErrCorrupt
+	}
+	return string(plain), nil
+}
+
+// IsSealed reports whether a value was encrypted by a Cipher
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, SealedPrefix)
+}
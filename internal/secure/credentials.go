@@ -0,0 +1,36 @@
+package secure
+
+import (
+	"errors"
+	"strings"
+)
+
+// KeychainPrefix marks config values that name a credential in the OS
+// keychain instead of holding the secret
+const KeychainPrefix = "keychain:"
+
+// credentialPrefix namespaces the assistant's entries in the keychain
+const credentialPrefix = "voice-assistant/"
+
+// ErrCredentialNotFound is returned when the keychain has no such entry
+var ErrCredentialNotFound = errors.New("not found in the keychain")
+
+// Reference returns the config value that points at a keychain entry
+func Reference(name string) string {
+	return KeychainPrefix + name
+}
+
+// IsReference reports whether a config value points at a keychain entry
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, KeychainPrefix)
+}
+
+// ReferenceName returns the keychain entry a reference points at
+func ReferenceName(value string) string {
+	return strings.TrimPrefix(value, KeychainPrefix)
+}
+
+// credentialTarget is the name an entry is stored under in the keychain
+func credentialTarget(name string) string {
+	return credentialPrefix + name
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package secure
+
+// StoreCredential is unavailable off Windows
+func StoreCredential(name, secret string) error {
+	return errUnsupported
+}
+
+// ReadCredential is unavailable off Windows
+func ReadCredential(name string) (string, error) {
+	return "", errUnsupported
+}
+
+// DeleteCredential is unavailable off Windows
+func DeleteCredential(name string) error {
+	return errUnsupported
+}
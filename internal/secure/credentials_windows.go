@@ -0,0 +1,105 @@
+package secure
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// Credential Manager constants
+const (
+	CRED_TYPE_GENERIC          = 1
+	CRED_PERSIST_LOCAL_MACHINE = 2
+	ERROR_NOT_FOUND            = 1168
+)
+
+var (
+	advapi32    = syscall.NewLazyDLL("advapi32.dll")
+	credWriteW  = advapi32.NewProc("CredWriteW")
+	credReadW   = advapi32.NewProc("CredReadW")
+	credDeleteW = advapi32.NewProc("CredDeleteW")
+	credFree    = advapi32.NewProc("CredFree")
+)
+
+// credential mirrors CREDENTIALW
+type credential struct {
+	flags              uint32
+	credType           uint32
+	targetName         *uint16
+	comment            *uint16
+	lastWritten        [2]uint32 // FILETIME
+	credentialBlobSize uint32
+	credentialBlob     *byte
+	persist            uint32
+	attributeCount     uint32
+	attributes         uintptr
+	targetAlias        *uint16
+	userName           *uint16
+}
+
+// StoreCredential saves a secret in the Windows Credential Manager
+func StoreCredential(name, secret string) error {
+	target, err := syscall.UTF16PtrFromString(credentialTarget(name))
+	if err != nil {
+		return err
+	}
+	user, _ := syscall.UTF16PtrFromString(name)
+
+	blob := []byte(secret)
+	cred := credential{
+		credType:   CRED_TYPE_GENERIC,
+		targetName: target,
+		persist:    CRED_PERSIST_LOCAL_MACHINE,
+		userName:   user,
+	}
+	if len(blob) > 0 {
+		cred.credentialBlobSize = uint32(len(blob))
+		cred.credentialBlob = &blob[0]
+	}
+
+	ret, _, err := credWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ret == 0 {
+		return fmt.Errorf("failed to store credential %s: %v", name, err)
+	}
+	return nil
+}
+
+// ReadCredential reads a secret from the Windows Credential Manager
+func ReadCredential(name string) (string, error) {
+	target, err := syscall.UTF16PtrFromString(credentialTarget(name))
+	if err != nil {
+		return "", err
+	}
+
+	var cred *credential
+	ret, _, err := credReadW.Call(uintptr(unsafe.Pointer(target)), CRED_TYPE_GENERIC, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		if errno, ok := err.(syscall.Errno); ok && errno == ERROR_NOT_FOUND {
+			return "", fmt.Errorf("credential %s: %w", name, ErrCredentialNotFound)
+		}
+		return "", fmt.Errorf("failed to read credential %s: %v", name, err)
+	}
+	defer credFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	if cred.credentialBlob == nil {
+		return "", nil
+	}
+	return string(unsafe.Slice(cred.credentialBlob, cred.credentialBlobSize)), nil
+}
+
+// DeleteCredential removes a secret from the Windows Credential Manager
+func DeleteCredential(name string) error {
+	target, err := syscall.UTF16PtrFromString(credentialTarget(name))
+	if err != nil {
+		return err
+	}
+
+	ret, _, err := credDeleteW.Call(uintptr(unsafe.Pointer(target)), CRED_TYPE_GENERIC, 0)
+	if ret == 0 {
+		if errno, ok := err.(syscall.Errno); ok && errno == ERROR_NOT_FOUND {
+			return nil
+		}
+		return fmt.Errorf("failed to delete credential %s: %v", name, err)
+	}
+	return nil
+}
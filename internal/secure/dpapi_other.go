@@ -0,0 +1,18 @@
+//go:build !windows
+
+package secure
+
+import "errors"
+
+// errUnsupported is returned where DPAPI and the Credential Manager aren't available
+var errUnsupported = errors.New("secure storage is only available on Windows")
+
+// Protect is unavailable off Windows
+func Protect(data []byte) ([]byte, error) {
+	return nil, errUnsupported
+}
+
+// Unprotect is unavailable off Windows
+func Unprotect(data []byte) ([]byte, error) {
+	return nil, errUnsupported
+}
@@ -0,0 +1,65 @@
+package secure
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// CRYPTPROTECT_UI_FORBIDDEN fails instead of prompting when DPAPI needs the user
+const CRYPTPROTECT_UI_FORBIDDEN = 0x1
+
+var (
+	crypt32            = syscall.NewLazyDLL("crypt32.dll")
+	cryptProtectData   = crypt32.NewProc("CryptProtectData")
+	cryptUnprotectData = crypt32.NewProc("CryptUnprotectData")
+
+	kernel32  = syscall.NewLazyDLL("kernel32.dll")
+	localFree = kernel32.NewProc("LocalFree")
+)
+
+// dataBlob mirrors DATA_BLOB
+type dataBlob struct {
+	size uint32
+	data *byte
+}
+
+// newBlob points a DATA_BLOB at data
+func newBlob(data []byte) *dataBlob {
+	if len(data) == 0 {
+		return &dataBlob{}
+	}
+	return &dataBlob{size: uint32(len(data)), data: &data[0]}
+}
+
+// bytes copies the blob out of memory DPAPI allocated, then frees it
+func (b *dataBlob) bytes() []byte {
+	if b.data == nil {
+		return nil
+	}
+	defer localFree.Call(uintptr(unsafe.Pointer(b.data)))
+	return append([]byte(nil), unsafe.Slice(b.data, b.size)...)
+}
+
+// Protect encrypts data with DPAPI so only the current Windows user can
+// decrypt it
+func Protect(data []byte) ([]byte, error) {
+	var out dataBlob
+	ret, _, err := cryptProtectData.Call(uintptr(unsafe.Pointer(newBlob(data))), 0, 0, 0, 0,
+		CRYPTPROTECT_UI_FORBIDDEN, uintptr(unsafe.Pointer(&out)))
+	if ret == 0 {
+		return nil, fmt.Errorf("CryptProtectData failed: %v", err)
+	}
+	return out.bytes(), nil
+}
+
+// Unprotect decrypts data encrypted by Protect
+func Unprotect(data []byte) ([]byte, error) {
+	var out dataBlob
+	ret, _, err := cryptUnprotectData.Call(uintptr(unsafe.Pointer(newBlob(data))), 0, 0, 0, 0,
+		CRYPTPROTECT_UI_FORBIDDEN, uintptr(unsafe.Pointer(&out)))
+	if ret == 0 {
+		return nil, fmt.Errorf("CryptUnprotectData failed: %v", err)
+	}
+	return out.bytes(), nil
+}
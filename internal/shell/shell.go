@@ -0,0 +1,145 @@
+// Package shell runs allow-listed commands for the command tool. Every
+// command needs the user's confirmation, even when it is allow-listed.
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/commands"
+	"voice-assistant/internal/gui"
+)
+
+// Characters cmd.exe treats as chaining, redirection, escapes or variable
+// expansion; an allow-listed command followed by any of them would run
+// something that was never allowed
+const metacharacters = "&|<>^%\r\n"
+
+// ErrDeclined is returned when the user refuses to run a command
+var ErrDeclined = errors.New("the user declined to run the command")
+
+// Runner checks commands against the allow-list and runs confirmed ones
+type Runner struct {
+	allowed   map[string]bool
+	patterns  []*regexp.Regexp
+	folders   map[string]string
+	timeout   time.Duration
+	maxOutput int
+	confirm   func(title, message string) bool
+}
+
+// NewRunner creates a runner for the configured allow-list. Invalid
+// patterns are logged and skipped.
+func NewRunner(cfg config.ShellConfig) *Runner {
+	r := &Runner{
+		allowed:   make(map[string]bool),
+		folders:   make(map[string]string),
+		timeout:   time.Duration(cfg.TimeoutSeconds) * time.Second,
+		maxOutput: cfg.MaxOutputBytes,
+		confirm:   gui.Confirm,
+	}
+	for _, command := range cfg.Allowed {
+		r.allowed[strings.Join(strings.Fields(command), " ")] = true
+	}
+	for _, pattern := range cfg.Patterns {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			log.Printf("⚠️  Ignoring invalid command pattern %q: %v", pattern, err)
+			continue
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	for name, path := range cfg.Folders {
+		r.folders[commands.Normalize(name)] = path
+	}
+	return r
+}
+
+// Folders returns the spoken names of the configured folders
+func (r *Runner) Folders() []string {
+	names := make([]string, 0, len(r.folders))
+	for name := range r.folders {
+		names = append(names, name)
+	}
+	return names
+}
+
+// IsAllowed reports whether a command is listed or matches a pattern
+func (r *Runner) IsAllowed(command string) bool {
+	command = strings.Join(strings.Fields(command), " ")
+	if r.allowed[command] {
+		return true
+	}
+	for _, re := range r.patterns {
+		if re.MatchString(command) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs an allow-listed command in a configured folder once the user
+// confirms it, returning its combined output
+func (r *Runner) Run(ctx context.Context, command, folder string) (string, error) {
+	command = strings.TrimSpace(command)
+	if strings.ContainsAny(command, metacharacters) {
+		return "", fmt.Errorf("%q contains characters cmd treats specially: & | < > ^ %% or a line break", command)
+	}
+	if !r.IsAllowed(command) {
+		return "", fmt.Errorf("%q is not on the command allow-list", command)
+	}
+
+	dir, err := r.resolveFolder(folder)
+	if err != nil {
+		return "", err
+	}
+
+	if !r.confirm("AI Assistant - Run command", fmt.Sprintf("Run this command?\n\n%s\n\nin %s", command, dir)) {
+		log.Printf("🚫 Declined to run %s", command)
+		return "", ErrDeclined
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	log.Printf("💻 Running %q in %s", command, dir)
+	cmd := exec.CommandContext(ctx, "cmd", "/C", command)
+	cmd.Dir = dir
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	output, err := cmd.CombinedOutput()
+
+	result := string(output)
+	if len(result) > r.maxOutput {
+		result = result[:r.maxOutput] + "\n[output truncated]"
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return result, fmt.Errorf("command timed out after %v", r.timeout)
+	}
+	if err != nil {
+		// A failing command's output is still what the user wants to hear about
+		return fmt.Sprintf("%s\n[%v]", result, err), nil
+	}
+	return result, nil
+}
+
+// resolveFolder maps a spoken folder name to its path; empty means the
+// user's home folder
+func (r *Runner) resolveFolder(folder string) (string, error) {
+	if strings.TrimSpace(folder) == "" {
+		return os.UserHomeDir()
+	}
+	path, ok := r.folders[commands.Normalize(folder)]
+	if !ok {
+		return "", fmt.Errorf("unknown folder %q", folder)
+	}
+	return os.ExpandEnv(path), nil
+}
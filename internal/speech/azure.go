@@ -2,18 +2,28 @@ package speech
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gordonklaus/portaudio"
 	"github.com/gorilla/websocket"
+
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/errs"
+	"voice-assistant/internal/keys"
+	"voice-assistant/internal/metrics"
+	"voice-assistant/internal/proxy"
 )
 
 // WebSocket message types for Azure Speech Service
@@ -23,27 +33,54 @@ const (
 	SpeechStartedType    = "speech.startDetected"
 	SpeechHypothesisType = "speech.hypothesis"
 	SpeechPhraseType     = "speech.phrase"
+	TurnStartType        = "turn.start"
 	TurnEndType          = "turn.end"
 )
 
+// Formats audio can be streamed to Azure in
+const (
+	AudioFormatPCM  = "pcm"  // Raw 16-bit samples, about 32 KB/s
+	AudioFormatOpus = "opus" // Opus in Ogg, about 3 KB/s
+)
+
 // AzureWebSocketSpeechService handles real-time speech recognition via WebSocket
 type AzureWebSocketSpeechService struct {
-	subscriptionKey string
-	region          string
-	language        string
+	keyRing            *keys.KeyRing
+	region             string
+	host               string // Recognition host, the public regional one unless overridden
+	endpointID         string // Custom Speech deployment, empty for the base model
+	language           string
+	candidateLanguages []string // Languages to auto-detect between; empty recognizes only language
+	mode               string   // Recognition endpoint: interactive, conversation or dictation
+	profanity          *ProfanityFilter
 
 	// WebSocket connection
-	conn           *websocket.Conn
-	isConnected    bool
-	isListening    bool
-	isShuttingDown bool // Flag to prevent error logging during shutdown
-	mutex          sync.Mutex
+	conn        *websocket.Conn
+	isConnected bool
+	isListening bool
+	connecting  bool            // Audio is queued while the connection opens
+	warm        *warmConnection // Opened ahead of the next session when pre-warming
+	warmStop    chan struct{}   // Closed to stop pre-warming; nil when it is off
+	session     chan struct{}   // Closed when the streaming session stops
+	workers     sync.WaitGroup  // Session goroutines, waited for by Close
+	writeMutex  sync.Mutex      // The WebSocket allows only one writer at a time
+	mutex       sync.Mutex
 
 	// Audio recording
-	stream       *portaudio.Stream
-	audioBuffer  []int16
-	onRecognized func(text string)
+	source       audio.Source      // Microphone or system audio
+	capturing    bool              // Whether source is running
+	audioQueue   *audio.FrameQueue // Hands frames from the capture callback to the streaming goroutine
+	preRoll      *audio.RingBuffer // Captures audio while idle so the first word isn't clipped
+	echoGate     *audio.EchoGate   // Mutes input while the assistant is speaking
+	onRecognized func(result RecognitionResult)
 	onError      func(error)
+	onTurnEnd    func()
+	onHypothesis func(text string)
+	onTurnAudio  func(samples []int16)
+	turnAudio    []int16       // Audio streamed during the current turn, kept only when onTurnAudio is set
+	maxDuration  time.Duration // Session length limit, 0 for none
+	audioFormat  string        // AudioFormatPCM or AudioFormatOpus
+	opusFailed   bool          // Opus didn't work, so sessions stream PCM instead
 
 	// Audio settings
 	sampleRate      int
@@ -51,7 +88,11 @@ type AzureWebSocketSpeechService struct {
 	framesPerBuffer int
 
 	// Connection tracking
-	requestId    string
+	requestId    string     // X-RequestId of the current turn
+	turnOpen     bool       // Whether audio is going to requestId; false once the service ends the turn
+	lastHeard    time.Time  // When the service last sent a message
+	lastVoice    time.Time  // When captured audio last sounded like speech
+	turnMutex    sync.Mutex // Guards the turn and lastHeard, which change mid-session
 	connectionId string
 }
 
@@ -60,7 +101,21 @@ const (
 	SampleRate      = 16000 // 16kHz for speech recognition
 	Channels        = 1     // Mono
 	FramesPerBuffer = 1024
-	MaxDuration     = 60 * time.Second // Max recording duration
+	StreamInterval  = 100 * time.Millisecond // How often queued audio is sent
+	AudioBacklog    = 2 * time.Second        // Audio queued before frames are dropped
+	MaxDuration     = 60 * time.Second       // Session limit until SetMaxDuration changes it
+	PingInterval    = 20 * time.Second       // How often an open session is pinged so proxies and NATs keep it
+	PongTimeout     = 10 * time.Second       // How long past a ping the service may stay silent before the connection counts as dead
+	IdleTimeout     = 90 * time.Second       // A session the service has sent nothing on for this long is closed
+	WarmMaxAge      = 60 * time.Second       // A warm connection is replaced after this, well before Azure gives up on it
+	warmCheck       = 5 * time.Second        // How often a missing or stale warm connection is replaced
+	warmDialTimeout = 10 * time.Second
+	SpeechAmplitude = 800         // Average amplitude above which a frame counts as speech
+	RolloverWindow  = time.Second // Speech this recent when the service ends a turn means the speaker isn't done
+
+	// MaxCandidateLanguages is how many languages Azure can identify
+	// between at the start of an utterance
+	MaxCandidateLanguages = 4
 )
 
 // Azure WebSocket protocol messages
@@ -91,20 +146,80 @@ type SpeechResultMessage struct {
 	Offset            int64  `json:"Offset"`
 	Duration          int64  `json:"Duration"`
 	NBest             []struct {
-		Display string `json:"Display"`
+		Confidence float64 `json:"Confidence"`
+		Lexical    string  `json:"Lexical"`
+		Display    string  `json:"Display"`
+		Words      []struct {
+			Word       string  `json:"Word"`
+			Offset     int64   `json:"Offset"`
+			Duration   int64   `json:"Duration"`
+			Confidence float64 `json:"Confidence"`
+		} `json:"Words,omitempty"`
 	} `json:"NBest"`
+	PrimaryLanguage *struct {
+		Language   string `json:"Language"`
+		Confidence string `json:"Confidence"`
+	} `json:"PrimaryLanguage,omitempty"` // Only present with language identification
+}
+
+// SpeechContextMessage requests word timings and, optionally, language
+// identification for a session
+type SpeechContextMessage struct {
+	PhraseOutput struct {
+		Format   string `json:"format"`
+		Detailed struct {
+			Options []string `json:"options"`
+		} `json:"detailed"`
+	} `json:"phraseOutput"`
+	LanguageID *LanguageIDContext `json:"languageId,omitempty"`
+}
+
+// LanguageIDContext lists the languages to identify between
+type LanguageIDContext struct {
+	Languages []string `json:"languages"`
+	OnUnknown struct {
+		Action string `json:"action"`
+	} `json:"onUnknown"`
+	Priority string `json:"priority"`
+}
+
+// FinalText returns the recognized text of a successful phrase result
+func (r *SpeechResultMessage) FinalText() string {
+	// Try DisplayText first (top-level field)
+	if r.DisplayText != "" {
+		return r.DisplayText
+	}
+	// Fallback to NBest[0].Display
+	if len(r.NBest) > 0 {
+		return r.NBest[0].Display
+	}
+	return ""
+}
+
+// DetectedLanguage returns the language Azure identified for the phrase,
+// or fallback when language identification was not enabled
+func (r *SpeechResultMessage) DetectedLanguage(fallback string) string {
+	if r.PrimaryLanguage != nil && r.PrimaryLanguage.Language != "" {
+		return r.PrimaryLanguage.Language
+	}
+	return fallback
 }
 
 // NewAzureWebSocketSpeechService creates a new WebSocket-based speech service
 func NewAzureWebSocketSpeechService(subscriptionKey, region, language string) (*AzureWebSocketSpeechService, error) {
 	service := &AzureWebSocketSpeechService{
-		subscriptionKey: subscriptionKey,
+		keyRing:         keys.NewKeyRing("Azure", []string{subscriptionKey}, keys.Failover),
 		region:          region,
+		host:            fmt.Sprintf("%s.stt.speech.microsoft.com", region),
 		language:        language,
 		sampleRate:      SampleRate,
 		channels:        Channels,
 		framesPerBuffer: FramesPerBuffer,
-		requestId:       generateRequestId(),
+		source:          audio.NewMicrophoneSource(),
+		audioQueue:      audio.NewFrameQueue(AudioBacklog),
+		profanity:       NewProfanityFilter(ProfanityMasked, nil),
+		mode:            ModeConversation,
+		maxDuration:     MaxDuration,
 	}
 
 	// Initialize PortAudio
@@ -122,14 +237,205 @@ func NewAzureWebSocketSpeechService(subscriptionKey, region, language string) (*
 	return service, nil
 }
 
+// Name identifies the provider in logs
+func (a *AzureWebSocketSpeechService) Name() string {
+	return "Azure Speech"
+}
+
 // SetCallbacks sets the recognition and error callbacks
-func (a *AzureWebSocketSpeechService) SetCallbacks(onRecognized func(string), onError func(error)) {
+func (a *AzureWebSocketSpeechService) SetCallbacks(onRecognized func(result RecognitionResult), onError func(error)) {
 	a.onRecognized = onRecognized
 	a.onError = onError
 }
 
+// SetTurnEndCallback sets a callback invoked when Azure ends the current turn
+func (a *AzureWebSocketSpeechService) SetTurnEndCallback(onTurnEnd func()) {
+	a.onTurnEnd = onTurnEnd
+}
+
+// SetHypothesisCallback sets a callback receiving the text recognized so
+// far while the speaker is still talking
+func (a *AzureWebSocketSpeechService) SetHypothesisCallback(onHypothesis func(text string)) {
+	a.onHypothesis = onHypothesis
+}
+
+// SetTurnAudioCallback sets a callback receiving each turn's audio when
+// the session stops; leaving it unset avoids keeping audio in memory
+func (a *AzureWebSocketSpeechService) SetTurnAudioCallback(onTurnAudio func(samples []int16)) {
+	a.onTurnAudio = onTurnAudio
+}
+
+// SetLanguage changes the recognition language. A session in progress
+// keeps its language; the next one connects with the new language.
+func (a *AzureWebSocketSpeechService) SetLanguage(language string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if language == "" || language == a.language {
+		return
+	}
+	a.language = language
+	if a.isListening {
+		log.Printf("🗣️  Language set to %s (applies from the next session)", language)
+	} else {
+		log.Printf("🗣️  Language set to %s", language)
+	}
+}
+
+// SetMode selects the recognition endpoint used from the next session
+func (a *AzureWebSocketSpeechService) SetMode(mode string) error {
+	if !ValidMode(mode) {
+		return fmt.Errorf("unknown recognition mode %q", mode)
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.mode = mode
+	log.Printf("🎚️  Recognition mode: %s", mode)
+	return nil
+}
+
+// SetAudioFormat chooses how audio is streamed from the next session.
+// Opus cuts bandwidth on slow or metered connections; if it can't be
+// encoded or Azure rejects it, sessions fall back to PCM.
+func (a *AzureWebSocketSpeechService) SetAudioFormat(format string) error {
+	switch format {
+	case "":
+		format = AudioFormatPCM
+	case AudioFormatPCM, AudioFormatOpus:
+	default:
+		return fmt.Errorf("unknown audio format %q", format)
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if format != a.audioFormat {
+		a.opusFailed = false // Give Opus another chance when it's switched back on
+	}
+	a.audioFormat = format
+	return nil
+}
+
+// SetEndpoint connects to host instead of the public regional endpoint,
+// for sovereign clouds and private endpoints, and recognizes with a
+// Custom Speech deployment when endpointID is set. It applies from the
+// next session.
+func (a *AzureWebSocketSpeechService) SetEndpoint(host, endpointID string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if host != "" {
+		a.host = host
+	}
+	a.endpointID = endpointID
+	if endpointID != "" {
+		log.Printf("🧪 Custom Speech endpoint %s on %s", endpointID, a.host)
+	}
+}
+
+// Mode returns the recognition mode
+func (a *AzureWebSocketSpeechService) Mode() string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.mode
+}
+
+// SetProfanityFilter chooses how Azure and the local filter treat
+// profanity: ProfanityMasked, ProfanityRemoved or ProfanityRaw
+func (a *AzureWebSocketSpeechService) SetProfanityFilter(filter *ProfanityFilter) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.profanity = filter
+	log.Printf("🧼 Profanity: %s", filter.Mode())
+}
+
+// SetCandidateLanguages lets Azure auto-detect which of the languages is
+// being spoken. Fewer than two languages turns detection off.
+func (a *AzureWebSocketSpeechService) SetCandidateLanguages(languages []string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if len(languages) < 2 {
+		a.candidateLanguages = nil
+		return
+	}
+	if len(languages) > MaxCandidateLanguages {
+		log.Printf("⚠️  Azure detects at most %d languages, ignoring %v", MaxCandidateLanguages, languages[MaxCandidateLanguages:])
+		languages = languages[:MaxCandidateLanguages]
+	}
+	a.candidateLanguages = languages
+	log.Printf("🌍 Auto-detecting language between %v", languages)
+}
+
+// SetSubscriptionKeys configures several keys to rotate through
+func (a *AzureWebSocketSpeechService) SetSubscriptionKeys(subscriptionKeys []string, strategy string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if len(subscriptionKeys) == 0 {
+		return
+	}
+	a.keyRing = keys.NewKeyRing("Azure", subscriptionKeys, strategy)
+	log.Printf("🔑 Rotating between %d Azure keys (%s)", len(subscriptionKeys), strategy)
+}
+
+// KeyUsage returns a summary of per-key usage for display
+func (a *AzureWebSocketSpeechService) KeyUsage() string {
+	return a.keyRing.Summary()
+}
+
+// SetEchoGate mutes microphone input whenever the gate is closed
+func (a *AzureWebSocketSpeechService) SetEchoGate(gate *audio.EchoGate) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.echoGate = gate
+}
+
+// SetMaxDuration limits how long a session streams, 0 for no limit
+func (a *AzureWebSocketSpeechService) SetMaxDuration(duration time.Duration) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.maxDuration = duration
+}
+
+// EnablePreRoll keeps the microphone open while idle and buffers the last
+// duration of audio, which is sent first when recognition starts
+func (a *AzureWebSocketSpeechService) EnablePreRoll(duration time.Duration) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if duration <= 0 || a.preRoll != nil {
+		return nil
+	}
+
+	a.preRoll = audio.NewRingBuffer(duration)
+	err := a.startAudioCapture()
+	if err != nil {
+		a.preRoll = nil
+		return err
+	}
+
+	log.Printf("⏪ Pre-roll buffer enabled (%v)", duration)
+	return nil
+}
+
+// SetCaptureSource switches where audio comes from, restarting capture if
+// it is running
+func (a *AzureWebSocketSpeechService) SetCaptureSource(source audio.Source) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !a.capturing {
+		a.source = source
+		return nil
+	}
+
+	a.cleanup()
+	a.source = source
+	return a.startAudioCapture()
+}
+
 // StartContinuousRecognition starts WebSocket connection and live audio streaming
-func (a *AzureWebSocketSpeechService) StartContinuousRecognition() error {
+func (a *AzureWebSocketSpeechService) StartContinuousRecognition(ctx context.Context) error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
@@ -140,52 +446,104 @@ func (a *AzureWebSocketSpeechService) StartContinuousRecognition() error {
 
 	log.Printf("🔌 CONNECTING TO AZURE WEBSOCKET...")
 
-	// Connect to Azure WebSocket
-	err := a.connectWebSocket()
-	if err != nil {
-		return fmt.Errorf("failed to connect to WebSocket: %v", err)
+	// Every session is a new turn and needs its own request ID
+	a.newTurn()
+
+	// Queue audio from now on, so nothing said while the connection opens
+	// is lost, with the pre-roll ahead of it
+	a.audioQueue.Reset()
+	a.turnAudio = nil
+	a.connecting = true
+	if a.preRoll != nil {
+		samples := a.preRoll.Drain()
+		a.audioQueue.Push(samples)
+		log.Printf("⏪ Flushing %d pre-roll samples", len(samples))
 	}
 
-	// Start audio capture
-	err = a.startAudioCapture()
+	// Start audio capture (already running when pre-roll is enabled)
+	startedCapture := false
+	if !a.capturing {
+		err := a.startAudioCapture()
+		if err != nil {
+			a.connecting = false
+			return fmt.Errorf("failed to start audio capture: %w", err)
+		}
+		startedCapture = true
+	}
+
+	// Connect to Azure WebSocket
+	err := a.connectWebSocket(ctx)
+	a.connecting = false
 	if err != nil {
-		a.disconnectWebSocket()
-		return fmt.Errorf("failed to start audio capture: %v", err)
+		if startedCapture {
+			a.cleanup()
+		}
+		return fmt.Errorf("failed to connect to WebSocket: %w", err)
 	}
 
+	encoder := a.sessionEncoder()
+
 	a.isListening = true
 	log.Printf("🟢 LIVE STREAMING ACTIVE - Speak now!")
 	log.Printf("   💡 Audio is being streamed in real-time to Azure")
 	log.Printf("   💡 You should see recognition results as you speak")
 
-	// Start goroutines for message handling and audio streaming
-	go a.handleWebSocketMessages()
-	go a.handleAudioStreaming()
+	// Start goroutines for message handling and audio streaming. They get
+	// the connection and session so a later session can't pull them over.
+	a.session = make(chan struct{})
+	a.workers.Add(2)
+	go a.handleWebSocketMessages(a.conn, a.session, encoder != nil)
+	go a.handleAudioStreaming(a.conn, a.session, a.maxDuration, encoder)
 
 	return nil
 }
 
-// connectWebSocket establishes WebSocket connection to Azure
-func (a *AzureWebSocketSpeechService) connectWebSocket() error {
-	// Build WebSocket URL
-	u := url.URL{
-		Scheme: "wss",
-		Host:   fmt.Sprintf("%s.stt.speech.microsoft.com", a.region),
-		Path:   "/speech/recognition/conversation/cognitiveservices/v1",
-		RawQuery: fmt.Sprintf("language=%s&format=detailed&Ocp-Apim-Subscription-Key=%s",
-			url.QueryEscape(a.language), url.QueryEscape(a.subscriptionKey)),
+// sessionEncoder returns a fresh Opus encoder when the session should be
+// compressed, or nil to stream PCM. Called with the mutex held.
+func (a *AzureWebSocketSpeechService) sessionEncoder() *audio.OpusEncoder {
+	if a.audioFormat != AudioFormatOpus || a.opusFailed {
+		return nil
 	}
+	encoder, err := audio.NewOpusEncoder()
+	if err != nil {
+		log.Printf("⚠️  Streaming PCM instead of Opus: %v", err)
+		a.opusFailed = true
+		return nil
+	}
+	log.Printf("🗜️  Streaming audio as Opus")
+	return encoder
+}
 
-	log.Printf("📡 Connecting to: %s", u.String())
+// connectionLost records that the connection is finished, closed by the
+// service or gone idle, so nothing more is sent and NextTurn knows to fail
+func (a *AzureWebSocketSpeechService) connectionLost() {
+	a.endTurn()
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.isConnected = false
+}
 
-	// Set up headers
-	headers := http.Header{}
-	headers.Set("Ocp-Apim-Subscription-Key", a.subscriptionKey)
+// opusRejected falls back to PCM from the next session
+func (a *AzureWebSocketSpeechService) opusRejected(err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if !a.opusFailed {
+		log.Printf("⚠️  Opus audio failed, falling back to PCM: %v", err)
+		a.opusFailed = true
+	}
+}
 
-	// Connect
-	conn, _, err := websocket.DefaultDialer.Dial(u.String(), headers)
-	if err != nil {
-		return fmt.Errorf("WebSocket dial failed: %v", err)
+// connectWebSocket establishes WebSocket connection to Azure, using the
+// warm connection when there is one. Called with the mutex held.
+func (a *AzureWebSocketSpeechService) connectWebSocket(ctx context.Context) error {
+	endpoint := a.endpoint()
+	conn := a.takeWarm(endpoint)
+	if conn == nil {
+		var err error
+		conn, err = a.dialWithKeys(ctx, endpoint)
+		if err != nil {
+			return err
+		}
 	}
 
 	a.conn = conn
@@ -199,6 +557,150 @@ func (a *AzureWebSocketSpeechService) connectWebSocket() error {
 	return a.sendSpeechConfig()
 }
 
+// newTurn starts a turn under a fresh request ID. The protocol ties every
+// message to one turn, and a turn can't be reused once the service has
+// ended it.
+func (a *AzureWebSocketSpeechService) newTurn() string {
+	a.turnMutex.Lock()
+	defer a.turnMutex.Unlock()
+	a.requestId = generateRequestId()
+	a.turnOpen = true
+	a.lastHeard = time.Now()
+	return a.requestId
+}
+
+// heard records a message from the service
+func (a *AzureWebSocketSpeechService) heard() {
+	a.turnMutex.Lock()
+	defer a.turnMutex.Unlock()
+	a.lastHeard = time.Now()
+}
+
+// silentFor returns how long the service has sent nothing
+func (a *AzureWebSocketSpeechService) silentFor() time.Duration {
+	a.turnMutex.Lock()
+	defer a.turnMutex.Unlock()
+	return time.Since(a.lastHeard)
+}
+
+// endTurn stops audio going to the current turn
+func (a *AzureWebSocketSpeechService) endTurn() {
+	a.turnMutex.Lock()
+	defer a.turnMutex.Unlock()
+	a.turnOpen = false
+}
+
+// stillSpeaking reports whether speech was captured within RolloverWindow
+func (a *AzureWebSocketSpeechService) stillSpeaking() bool {
+	a.turnMutex.Lock()
+	defer a.turnMutex.Unlock()
+	return time.Since(a.lastVoice) < RolloverWindow
+}
+
+// turnID returns the request ID of the current turn
+func (a *AzureWebSocketSpeechService) turnID() string {
+	a.turnMutex.Lock()
+	defer a.turnMutex.Unlock()
+	return a.requestId
+}
+
+// currentTurn returns the request ID of the current turn and whether it
+// is still taking audio
+func (a *AzureWebSocketSpeechService) currentTurn() (string, bool) {
+	a.turnMutex.Lock()
+	defer a.turnMutex.Unlock()
+	return a.requestId, a.turnOpen
+}
+
+// NextTurn starts a new turn on the open connection after the service
+// ended the last one, which is quicker than reconnecting. It fails once
+// the session is over or the service has closed the connection.
+func (a *AzureWebSocketSpeechService) NextTurn() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !a.isListening || !a.isConnected {
+		return fmt.Errorf("no open session to continue")
+	}
+	id := a.newTurn()
+	log.Printf("🔁 Continuing with turn %s", id)
+	return nil
+}
+
+// dialWithKeys opens a WebSocket to endpoint, moving to the next
+// subscription key when one is rejected or out of quota. It doesn't touch
+// the service's settings, so it can run without the mutex.
+func (a *AzureWebSocketSpeechService) dialWithKeys(ctx context.Context, endpoint url.URL) (*websocket.Conn, error) {
+	var conn *websocket.Conn
+	var err error
+	for attempt := 0; attempt < a.keyRing.Len(); attempt++ {
+		subscriptionKey := a.keyRing.Next()
+
+		var resp *http.Response
+		conn, resp, err = a.dial(ctx, endpoint, subscriptionKey)
+		if err == nil {
+			return conn, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		err = fmt.Errorf("WebSocket dial failed: %w", err)
+		if resp == nil {
+			a.keyRing.ReportFailure(subscriptionKey)
+			return nil, errs.FromTransport("Azure Speech", err)
+		}
+		err = errs.FromStatus("Azure Speech", resp.StatusCode, err)
+
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusUnauthorized, http.StatusForbidden:
+			log.Printf("🔑 Azure key %s rejected (%s)", keys.Mask(subscriptionKey), resp.Status)
+			a.keyRing.ReportRateLimited(subscriptionKey)
+		default:
+			a.keyRing.ReportFailure(subscriptionKey)
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// endpoint builds the WebSocket URL for the current settings, without the
+// subscription key. Called with the mutex held.
+func (a *AzureWebSocketSpeechService) endpoint() url.URL {
+	u := url.URL{
+		Scheme: "wss",
+		Host:   a.host,
+		Path:   fmt.Sprintf("/speech/recognition/%s/cognitiveservices/v1", a.mode),
+		RawQuery: fmt.Sprintf("language=%s&format=detailed&wordLevelTimestamps=true&profanity=%s",
+			url.QueryEscape(a.language), url.QueryEscape(a.profanity.Mode())),
+	}
+
+	// Language identification is only offered on the universal endpoint,
+	// which has no separate modes
+	if len(a.candidateLanguages) > 0 {
+		u.Path = "/speech/universal/v2"
+	}
+	if a.endpointID != "" {
+		u.RawQuery += "&cid=" + url.QueryEscape(a.endpointID)
+	}
+	return u
+}
+
+// dial opens the WebSocket using one subscription key
+func (a *AzureWebSocketSpeechService) dial(ctx context.Context, endpoint url.URL, subscriptionKey string) (*websocket.Conn, *http.Response, error) {
+	u := endpoint
+	u.RawQuery += "&Ocp-Apim-Subscription-Key=" + url.QueryEscape(subscriptionKey)
+
+	log.Printf("📡 Connecting to: %s://%s%s (key %s)", u.Scheme, u.Host, u.Path, keys.Mask(subscriptionKey))
+
+	// Set up headers
+	headers := http.Header{}
+	headers.Set("Ocp-Apim-Subscription-Key", subscriptionKey)
+
+	// Connect
+	return proxy.Dialer.DialContext(ctx, u.String(), headers)
+}
+
 // sendSpeechConfig sends initial configuration to Azure
 func (a *AzureWebSocketSpeechService) sendSpeechConfig() error {
 	config := SpeechConfigMessage{}
@@ -217,122 +719,242 @@ func (a *AzureWebSocketSpeechService) sendSpeechConfig() error {
 
 	// Send as text message with headers
 	message := fmt.Sprintf("Path: speech.config\r\nContent-Type: application/json; charset=utf-8\r\nX-RequestId: %s\r\nX-Timestamp: %s\r\n\r\n%s",
-		a.requestId, time.Now().UTC().Format("2006-01-02T15:04:05.000Z"), string(configBytes))
+		a.turnID(), time.Now().UTC().Format("2006-01-02T15:04:05.000Z"), string(configBytes))
 
 	log.Printf("📤 Sending speech config...")
-	return a.conn.WriteMessage(websocket.TextMessage, []byte(message))
+	err = a.conn.WriteMessage(websocket.TextMessage, []byte(message))
+	if err != nil {
+		return err
+	}
+	return a.sendSpeechContext(a.conn)
 }
 
-// startAudioCapture begins capturing audio from microphone
-func (a *AzureWebSocketSpeechService) startAudioCapture() error {
-	// Reset audio buffer
-	a.audioBuffer = make([]int16, 0)
-
-	// Set up PortAudio stream
-	stream, err := portaudio.OpenDefaultStream(
-		a.channels, // input channels
-		0,          // output channels (no output)
-		float64(a.sampleRate),
-		a.framesPerBuffer,
-		a.processAudio,
-	)
+// sendSpeechContext asks Azure for word timings and to identify the spoken
+// language among any candidates, falling back to the configured language
+// when unsure. It opens each turn.
+func (a *AzureWebSocketSpeechService) sendSpeechContext(conn *websocket.Conn) error {
+	context := SpeechContextMessage{}
+	context.PhraseOutput.Format = "Detailed"
+	context.PhraseOutput.Detailed.Options = []string{"WordTimings"}
+	if len(a.candidateLanguages) > 0 {
+		languageID := &LanguageIDContext{
+			Languages: a.candidateLanguages,
+			Priority:  "PrioritizeLatency",
+		}
+		languageID.OnUnknown.Action = "RecognizeWithDefaultLanguage"
+		context.LanguageID = languageID
+	}
+
+	contextBytes, err := json.Marshal(context)
 	if err != nil {
-		return fmt.Errorf("failed to open audio stream: %v", err)
+		return err
 	}
 
-	a.stream = stream
+	message := fmt.Sprintf("Path: speech.context\r\nContent-Type: application/json; charset=utf-8\r\nX-RequestId: %s\r\nX-Timestamp: %s\r\n\r\n%s",
+		a.turnID(), time.Now().UTC().Format("2006-01-02T15:04:05.000Z"), string(contextBytes))
 
-	// Start the stream
-	err = stream.Start()
+	log.Printf("📤 Sending speech context...")
+	return a.write(conn, websocket.TextMessage, []byte(message))
+}
+
+// startAudioCapture begins capturing audio from the capture source
+func (a *AzureWebSocketSpeechService) startAudioCapture() error {
+	err := a.source.Start(a.processAudio)
 	if err != nil {
-		a.cleanup()
-		return fmt.Errorf("failed to start audio stream: %v", err)
+		return err
 	}
-
-	log.Printf("🎤 Audio capture started")
+	a.capturing = true
 	return nil
 }
 
-// processAudio handles incoming audio data from microphone
+// processAudio handles incoming audio data from the capture source
 func (a *AzureWebSocketSpeechService) processAudio(in []int16) {
-	if !a.isListening || !a.isConnected {
-		return
+	// Don't let the assistant hear itself
+	if a.echoGate != nil {
+		in = a.echoGate.Filter(in)
 	}
 
-	// Add incoming audio to buffer
-	a.audioBuffer = append(a.audioBuffer, in...)
-
-	// Log audio activity
-	var sum int64
-	for _, sample := range in {
-		if sample < 0 {
-			sum += int64(-sample)
-		} else {
-			sum += int64(sample)
+	streaming := a.isListening && a.isConnected
+	if !streaming && !a.connecting {
+		if a.preRoll != nil {
+			a.preRoll.Write(in)
 		}
+		return
+	}
+
+	// Hand the frame to the streaming goroutine
+	if !a.audioQueue.Push(in) {
+		return // Queue full; the frame is counted as dropped
 	}
-	avgAmplitude := sum / int64(len(in))
 
-	if avgAmplitude > 800 { // Threshold for speech detection
+	// Log audio activity
+	avgAmplitude := amplitude(in)
+	if avgAmplitude > SpeechAmplitude {
 		log.Printf("🔊 Audio detected (amplitude: %d)", avgAmplitude)
+		a.turnMutex.Lock()
+		a.lastVoice = time.Now()
+		a.turnMutex.Unlock()
 	}
 }
 
-// handleAudioStreaming sends audio chunks to Azure via WebSocket
-func (a *AzureWebSocketSpeechService) handleAudioStreaming() {
+// handleAudioStreaming sends audio chunks to Azure via WebSocket,
+// compressed with encoder when it isn't nil
+func (a *AzureWebSocketSpeechService) handleAudioStreaming(conn *websocket.Conn, session <-chan struct{}, limit time.Duration, encoder *audio.OpusEncoder) {
+	defer a.workers.Done()
 	log.Printf("🎵 Starting audio streaming handler...")
 
-	ticker := time.NewTicker(100 * time.Millisecond) // Send audio every 100ms
+	ticker := time.NewTicker(StreamInterval)
 	defer ticker.Stop()
+	ping := time.NewTicker(PingInterval)
+	defer ping.Stop()
+
+	// A nil channel never fires, so sessions without a limit run until stopped
+	var maxDuration <-chan time.Time
+	if limit > 0 {
+		timer := time.NewTimer(limit)
+		defer timer.Stop()
+		maxDuration = timer.C
+	}
 
-	maxDuration := time.NewTimer(MaxDuration)
-	defer maxDuration.Stop()
-
+	first := true
+	turn := a.turnID()
 	for {
 		select {
+		case <-session:
+			log.Printf("🛑 Audio streaming stopped")
+			return
+
 		case <-ticker.C:
-			if !a.isListening || !a.isConnected {
-				log.Printf("🛑 Audio streaming stopped")
-				return
+			// Send accumulated audio. Audio heard after the service ended
+			// the turn stays queued for the next one.
+			id, open := a.currentTurn()
+			if !open {
+				continue
 			}
-
-			// Send accumulated audio
-			if len(a.audioBuffer) > 0 {
-				err := a.sendAudioChunk(a.audioBuffer)
+			samples := a.audioQueue.Drain()
+			if len(samples) > 0 {
+				var err error
+				if id != turn {
+					// The service ended the last turn; audio from here
+					// on opens the next one
+					turn, first = id, true
+					err = a.sendSpeechContext(conn)
+					if encoder != nil {
+						encoder.Restart()
+					}
+				}
+				if err == nil {
+					if encoder != nil {
+						err = a.sendOpusChunk(conn, encoder, samples, first)
+					} else {
+						err = a.sendAudioChunk(conn, samples)
+					}
+				}
+				first = false
 				if err != nil {
+					if isClosed(session) {
+						return
+					}
 					log.Printf("❌ Failed to send audio chunk: %v", err)
 					if a.onError != nil {
-						a.onError(err)
+						a.onError(errs.New(errs.ErrNetwork, "Azure Speech", err))
 					}
 					return
 				}
-				a.audioBuffer = make([]int16, 0) // Reset buffer
+				metrics.Mark(metrics.MarkFirstAudio)
+				if a.onTurnAudio != nil {
+					a.turnAudio = append(a.turnAudio, samples...)
+				}
+			}
+
+		case <-ping.C:
+			// A long pause otherwise lets the connection drop without
+			// anything noticing until the next write
+			if idle := a.silentFor(); idle > IdleTimeout {
+				log.Printf("💤 Nothing from Azure for %s, closing the idle session", idle.Round(time.Second))
+				a.connectionLost()
+				if a.onTurnEnd != nil {
+					a.onTurnEnd()
+				}
+				return
+			}
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(PongTimeout))
+			if err != nil && !isClosed(session) {
+				log.Printf("⚠️  Failed to ping Azure: %v", err)
 			}
 
-		case <-maxDuration.C:
+		case <-maxDuration:
 			log.Printf("⏰ Max streaming duration reached, stopping...")
 			a.StopContinuousRecognition()
+			if a.onTurnEnd != nil {
+				a.onTurnEnd()
+			}
 			return
 		}
 	}
 }
 
 // sendAudioChunk sends audio data to Azure via WebSocket
-func (a *AzureWebSocketSpeechService) sendAudioChunk(audioData []int16) error {
+func (a *AzureWebSocketSpeechService) sendAudioChunk(conn *websocket.Conn, audioData []int16) error {
 	if len(audioData) == 0 {
 		return nil
 	}
 
+	// Send as binary message
+	return a.write(conn, websocket.BinaryMessage, audioMessage(a.turnID(), audioData))
+}
+
+// sendOpusChunk compresses audio and sends the finished pages. The first
+// chunk opens the stream with its headers and content type.
+func (a *AzureWebSocketSpeechService) sendOpusChunk(conn *websocket.Conn, encoder *audio.OpusEncoder, audioData []int16, first bool) error {
+	var payload []byte
+	contentType := ""
+	if first {
+		payload = encoder.Header()
+		contentType = audio.OggOpusContentType
+	}
+
+	pages, err := encoder.Encode(audioData)
+	if err != nil {
+		a.opusRejected(err)
+		return err
+	}
+	payload = append(payload, pages...)
+	if len(payload) == 0 {
+		return nil
+	}
+	return a.write(conn, websocket.BinaryMessage, audioFrame(a.turnID(), contentType, payload))
+}
+
+// write sends one message, serialized with every other writer
+func (a *AzureWebSocketSpeechService) write(conn *websocket.Conn, messageType int, data []byte) error {
+	a.writeMutex.Lock()
+	defer a.writeMutex.Unlock()
+	return conn.WriteMessage(messageType, data)
+}
+
+// audioMessage frames audio for the Azure WebSocket protocol. An empty
+// audioData produces the end-of-stream message.
+func audioMessage(requestId string, audioData []int16) []byte {
 	// Convert to bytes (16-bit PCM, little-endian)
 	audioBytes := make([]byte, len(audioData)*2)
 	for i, sample := range audioData {
 		binary.LittleEndian.PutUint16(audioBytes[i*2:], uint16(sample))
 	}
+	return audioFrame(requestId, "", audioBytes)
+}
 
+// audioFrame frames encoded audio for the Azure WebSocket protocol. The
+// content type goes on the first message of a compressed stream only.
+func audioFrame(requestId, contentType string, audioBytes []byte) []byte {
 	// Create proper headers for Azure WebSocket protocol
 	// Headers must be lowercase and follow exact format
-	headers := fmt.Sprintf("path:audio\r\nx-requestid:%s\r\nx-timestamp:%s\r\n\r\n",
-		a.requestId, time.Now().UTC().Format("2006-01-02T15:04:05.000Z"))
+	headers := fmt.Sprintf("path:audio\r\nx-requestid:%s\r\nx-timestamp:%s\r\n",
+		requestId, time.Now().UTC().Format("2006-01-02T15:04:05.000Z"))
+	if contentType != "" {
+		headers += "content-type:" + contentType + "\r\n"
+	}
+	headers += "\r\n"
 
 	headerBytes := []byte(headers)
 	headerLength := uint16(len(headerBytes))
@@ -349,29 +971,60 @@ func (a *AzureWebSocketSpeechService) sendAudioChunk(audioData []int16) error {
 	// Write audio data
 	copy(message[2+len(headerBytes):], audioBytes)
 
-	// Send as binary message
-	return a.conn.WriteMessage(websocket.BinaryMessage, message)
+	return message
 }
 
-// handleWebSocketMessages processes incoming messages from Azure
-func (a *AzureWebSocketSpeechService) handleWebSocketMessages() {
+// handleWebSocketMessages processes incoming messages from Azure;
+// compressed says whether the session streams Opus, so that a rejection
+// can fall back to PCM
+func (a *AzureWebSocketSpeechService) handleWebSocketMessages(conn *websocket.Conn, session <-chan struct{}, compressed bool) {
+	defer a.workers.Done()
 	log.Printf("📬 Starting WebSocket message handler...")
 
-	for a.isConnected {
-		messageType, data, err := a.conn.ReadMessage()
+	// The service answers pings, so a read that waits past the next pong
+	// means the connection is gone
+	extendDeadline := func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(PingInterval + PongTimeout))
+	}
+	extendDeadline("")
+	conn.SetPongHandler(extendDeadline)
+
+	for {
+		messageType, data, err := conn.ReadMessage()
 		if err != nil {
 			// Only log errors if we're not intentionally shutting down
-			if !a.isShuttingDown && !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+			if isClosed(session) {
+				break
+			}
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				// The service ended the session, e.g. after a long silence;
+				// the app decides whether to reconnect
+				log.Printf("🔌 Azure closed the session: %v", err)
+				a.connectionLost()
+				if a.onTurnEnd != nil {
+					a.onTurnEnd()
+				}
+			} else {
+				a.endTurn() // Writes would only fail too
+				var netErr net.Error
+				if errors.As(err, &netErr) && netErr.Timeout() {
+					err = fmt.Errorf("no response from Azure for %s: %w", PingInterval+PongTimeout, err)
+				}
 				log.Printf("❌ WebSocket read error: %v", err)
+				if compressed && websocket.IsCloseError(err, websocket.CloseUnsupportedData, websocket.CloseInvalidFramePayloadData) {
+					a.opusRejected(err)
+				}
 				if a.onError != nil {
-					a.onError(err)
+					a.onError(errs.New(errs.ErrNetwork, "Azure Speech", err))
 				}
 			}
 			break
 		}
 
+		extendDeadline("")
 		switch messageType {
 		case websocket.TextMessage:
+			a.heard()
 			a.handleTextMessage(data)
 		case websocket.BinaryMessage:
 			log.Printf("📦 Received binary message (%d bytes)", len(data))
@@ -393,9 +1046,20 @@ func (a *AzureWebSocketSpeechService) handleTextMessage(data []byte) {
 
 	headers := string(parts[0])
 	body := parts[1]
+	path := headerValue(headers, "Path")
+
+	// Results for a turn that has already ended would be answered twice
+	turn := a.turnID()
+	if id := headerValue(headers, "X-RequestId"); id != "" && !strings.EqualFold(id, turn) {
+		log.Printf("🗑️  Ignoring %s for an earlier turn (%s)", path, id)
+		return
+	}
+
+	switch path {
+	case TurnStartType:
+		log.Printf("🔛 Turn %s started", turn)
 
-	// Only process speech.phrase messages (final results)
-	if bytes.Contains([]byte(headers), []byte("Path:speech.phrase")) {
+	case SpeechPhraseType:
 		// Parse the JSON body
 		var result SpeechResultMessage
 		err := json.Unmarshal(body, &result)
@@ -406,30 +1070,63 @@ func (a *AzureWebSocketSpeechService) handleTextMessage(data []byte) {
 
 		// Check if recognition was successful and we have text
 		if result.RecognitionStatus == "Success" {
-			var finalText string
-
-			// Try DisplayText first (top-level field)
-			if result.DisplayText != "" {
-				finalText = result.DisplayText
-			} else if len(result.NBest) > 0 && result.NBest[0].Display != "" {
-				// Fallback to NBest[0].Display
-				finalText = result.NBest[0].Display
-			}
-
-			if finalText != "" {
-				log.Printf("🎯 FINAL RESULT: '%s'", finalText)
+			recognized := a.profanity.CleanResult(result.Result(a.language))
+			if recognized.Text != "" {
+				log.Printf("🎯 FINAL RESULT: '%s' (confidence %.2f, %d words timed)",
+					recognized.Text, recognized.Confidence, len(recognized.Words))
+				if result.PrimaryLanguage != nil {
+					log.Printf("   🌍 Detected language: %s (%s confidence)", recognized.Language, result.PrimaryLanguage.Confidence)
+				}
 				log.Printf("   📤 Sending to Claude API...")
 
-				// Call the recognition callback with the final text
+				// Call the recognition callback with the final result
 				if a.onRecognized != nil {
-					a.onRecognized(finalText)
+					a.onRecognized(recognized)
 				}
 			}
 		} else {
 			log.Printf("🔇 No speech recognized (status: %s)", result.RecognitionStatus)
 		}
+
+	case SpeechHypothesisType:
+		metrics.Mark(metrics.MarkFirstHypothesis)
+		if a.onHypothesis != nil {
+			var hypothesis SpeechResultMessage
+			if json.Unmarshal(body, &hypothesis) == nil && hypothesis.Text != "" {
+				a.onHypothesis(a.profanity.Clean(hypothesis.Text))
+			}
+		}
+	case TurnEndType:
+		// The service caps how long a turn runs; if the speaker is still
+		// going, carry on in a new turn without bothering the app
+		if a.stillSpeaking() {
+			log.Printf("↪️  Turn %s hit the service limit mid-speech, rolling over", turn)
+			a.newTurn()
+			return
+		}
+
+		// Anything more sent under this ID would be rejected as a
+		// completed request, so audio waits for NextTurn or the end of
+		// the session
+		log.Printf("🔚 Turn %s ended by service", turn)
+		a.endTurn()
+		if a.onTurnEnd != nil {
+			a.onTurnEnd()
+		}
 	}
-	// Ignore all other message types (hypothesis, speech start/end, etc.)
+	// Ignore all other message types (speech start/end, etc.)
+}
+
+// headerValue returns a header from an Azure text message, matching the
+// name case-insensitively
+func headerValue(headers, name string) string {
+	for _, line := range strings.Split(headers, "\r\n") {
+		key, value, found := strings.Cut(line, ":")
+		if found && strings.EqualFold(strings.TrimSpace(key), name) {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
 }
 
 // StopContinuousRecognition stops WebSocket connection and audio capture
@@ -443,45 +1140,59 @@ func (a *AzureWebSocketSpeechService) StopContinuousRecognition() error {
 	}
 
 	log.Printf("🛑 STOPPING LIVE STREAMING...")
-	a.isShuttingDown = true // Set flag to prevent error logging during shutdown
-
-	// Send end of audio signal (empty audio chunk with proper format)
-	if a.isConnected && a.conn != nil {
-		headers := fmt.Sprintf("path:audio\r\nx-requestid:%s\r\nx-timestamp:%s\r\n\r\n",
-			a.requestId, time.Now().UTC().Format("2006-01-02T15:04:05.000Z"))
+	close(a.session) // The session goroutines stop and ignore errors from here on
 
-		headerBytes := []byte(headers)
-		headerLength := uint16(len(headerBytes))
-
-		// Create end-of-stream message (header only, no audio data)
-		message := make([]byte, 2+len(headerBytes))
-		binary.BigEndian.PutUint16(message[0:2], headerLength) // BIG-ENDIAN
-		copy(message[2:], headerBytes)
+	// Send end of audio signal (empty audio chunk with proper format),
+	// unless the service has already ended the turn
+	if id, open := a.currentTurn(); open && a.isConnected && a.conn != nil {
+		a.write(a.conn, websocket.BinaryMessage, audioMessage(id, nil))
+	}
 
-		a.conn.WriteMessage(websocket.BinaryMessage, message)
+	// Stop audio capture first, unless it keeps feeding the pre-roll buffer
+	if a.preRoll == nil {
+		err := a.cleanup()
+		if err != nil {
+			log.Printf("❌ Error during cleanup: %v", err)
+		}
 	}
 
-	// Stop audio capture first
-	err := a.cleanup()
-	if err != nil {
-		log.Printf("❌ Error during cleanup: %v", err)
+	if a.onTurnAudio != nil && len(a.turnAudio) > 0 {
+		go a.onTurnAudio(a.turnAudio)
+		a.turnAudio = nil
 	}
 
+	pushed, dropped := a.audioQueue.Stats()
+	log.Printf("📊 Audio frames streamed: %d, dropped: %d", pushed, dropped)
+
 	// Close WebSocket connection
 	a.disconnectWebSocket()
 	a.isListening = false
-	a.isShuttingDown = false // Reset flag
 
 	log.Printf("🔴 STREAMING STOPPED")
 	return nil
 }
 
+// Reconnect tears down and re-establishes an active streaming session,
+// used when the network underneath the WebSocket has changed
+func (a *AzureWebSocketSpeechService) Reconnect(ctx context.Context) error {
+	if !a.IsListening() {
+		return nil
+	}
+
+	log.Printf("🔄 RECONNECTING WEBSOCKET...")
+	err := a.StopContinuousRecognition()
+	if err != nil {
+		return fmt.Errorf("failed to stop session: %v", err)
+	}
+
+	return a.StartContinuousRecognition(ctx)
+}
+
 // cleanup handles audio stream cleanup
 func (a *AzureWebSocketSpeechService) cleanup() error {
-	if a.stream != nil {
-		a.stream.Stop()
-		a.stream.Close()
-		a.stream = nil
+	if a.capturing {
+		a.source.Stop()
+		a.capturing = false
 	}
 	return nil
 }
@@ -504,14 +1215,14 @@ func (a *AzureWebSocketSpeechService) IsListening() bool {
 }
 
 // TestConnection tests the Azure Speech Services connection
-func (a *AzureWebSocketSpeechService) TestConnection() error {
+func (a *AzureWebSocketSpeechService) TestConnection(ctx context.Context) error {
 	log.Printf("🧪 TESTING AZURE WEBSOCKET CONNECTION...")
-	log.Printf("   🌐 Region: %s", a.region)
+	log.Printf("   🌐 Host: %s", a.host)
 	log.Printf("   🗣️  Language: %s", a.language)
-	log.Printf("   🔑 Key configured: %t", a.subscriptionKey != "")
+	log.Printf("   🔑 Keys configured: %d", a.keyRing.Len())
 
 	// Try to establish WebSocket connection
-	err := a.connectWebSocket()
+	err := a.connectWebSocket(ctx)
 	if err != nil {
 		return fmt.Errorf("connection test failed: %v", err)
 	}
@@ -527,17 +1238,26 @@ func (a *AzureWebSocketSpeechService) TestConnection() error {
 // Close releases all resources
 func (a *AzureWebSocketSpeechService) Close() {
 	log.Printf("🧹 Cleaning up WebSocket Speech Service...")
+	a.SetPrewarm(false)
 
 	if a.isListening {
 		a.StopContinuousRecognition()
 	}
 
+	a.mutex.Lock()
+	a.cleanup()
+	a.mutex.Unlock()
+
 	a.disconnectWebSocket()
+	if !waitWorkers(&a.workers) {
+		log.Printf("⚠️  Speech session goroutines did not exit in time")
+	}
 	portaudio.Terminate()
 	log.Printf("✅ Cleanup completed")
 }
 
-// generateRequestId creates a proper UUID/GUID for Azure
+// generateRequestId creates a random UUID in the no-dash form the Azure
+// protocol expects for request and connection IDs
 func generateRequestId() string {
 	// Generate a proper UUID v4 format: xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx
 	b := make([]byte, 16)
@@ -547,5 +1267,5 @@ func generateRequestId() string {
 	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
 	b[8] = (b[8] & 0x3f) | 0x80 // Variant 10
 
-	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	return fmt.Sprintf("%X", b)
 }
@@ -0,0 +1,438 @@
+package speech
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	gspeech "cloud.google.com/go/speech/apiv1"
+	"cloud.google.com/go/speech/apiv1/speechpb"
+	"github.com/gordonklaus/portaudio"
+	"google.golang.org/api/option"
+
+	"voice-assistant/internal/audio"
+)
+
+// GoogleService recognizes speech with Google Cloud Speech-to-Text over a
+// streaming gRPC connection, authenticated with a service account
+type GoogleService struct {
+	language string
+	model    string // Recognition model, e.g. "latest_short"; empty uses Google's default
+	client   *gspeech.Client
+
+	mutex       sync.Mutex
+	mic         *microphone
+	isListening bool
+	maxDuration time.Duration // Streaming call limit, 0 for none
+	profanity   *ProfanityFilter
+	cancel      context.CancelFunc // Ends the current streaming call
+	workers     sync.WaitGroup     // Streaming goroutines, waited for by Close
+
+	onRecognized func(result RecognitionResult)
+	onError      func(error)
+	onTurnEnd    func()
+	onHypothesis func(text string)
+	onTurnAudio  func(samples []int16)
+}
+
+// NewGoogleService creates a Google STT backend using the service account
+// key in credentialsFile
+func NewGoogleService(credentialsFile, language, model string) (*GoogleService, error) {
+	client, err := gspeech.NewClient(context.Background(), option.WithCredentialsFile(credentialsFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Google Speech client: %v", err)
+	}
+
+	err = portaudio.Initialize()
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to initialize PortAudio: %v", err)
+	}
+
+	log.Printf("☁️  Google Speech Service initialized")
+	log.Printf("   🗣️  Language: %s", language)
+	return &GoogleService{
+		language:    language,
+		model:       model,
+		client:      client,
+		mic:         newMicrophone(),
+		maxDuration: MaxDuration,
+		profanity:   NewProfanityFilter(ProfanityMasked, nil),
+	}, nil
+}
+
+// Name identifies the provider in logs
+func (g *GoogleService) Name() string {
+	return "Google Speech"
+}
+
+// SetCallbacks sets the recognition and error callbacks
+func (g *GoogleService) SetCallbacks(onRecognized func(result RecognitionResult), onError func(error)) {
+	g.onRecognized = onRecognized
+	g.onError = onError
+}
+
+// SetTurnEndCallback sets a callback invoked when a turn ends without speech
+func (g *GoogleService) SetTurnEndCallback(onTurnEnd func()) {
+	g.onTurnEnd = onTurnEnd
+}
+
+// SetHypothesisCallback sets a callback receiving interim results while
+// the speaker is still talking
+func (g *GoogleService) SetHypothesisCallback(onHypothesis func(text string)) {
+	g.onHypothesis = onHypothesis
+}
+
+// SetTurnAudioCallback sets a callback receiving each turn's audio
+func (g *GoogleService) SetTurnAudioCallback(onTurnAudio func(samples []int16)) {
+	g.onTurnAudio = onTurnAudio
+}
+
+// SetEchoGate mutes microphone input whenever the gate is closed
+func (g *GoogleService) SetEchoGate(gate *audio.EchoGate) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.mic.echoGate = gate
+}
+
+// SetCaptureSource switches where audio comes from
+func (g *GoogleService) SetCaptureSource(source audio.Source) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.mic.setSource(source)
+}
+
+// SetProfanityFilter cleans transcripts; any mode but raw also turns on
+// Google's own filter
+func (g *GoogleService) SetProfanityFilter(filter *ProfanityFilter) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.profanity = filter
+}
+
+// SetMaxDuration limits how long a streaming call lasts, 0 for no limit
+func (g *GoogleService) SetMaxDuration(duration time.Duration) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.maxDuration = duration
+}
+
+// EnablePreRoll keeps the microphone open while idle and buffers the last
+// duration of audio, which starts the next utterance
+func (g *GoogleService) EnablePreRoll(duration time.Duration) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.mic.enablePreRoll(duration)
+}
+
+// recognitionConfig describes the audio and requested details
+func (g *GoogleService) recognitionConfig() *speechpb.RecognitionConfig {
+	return &speechpb.RecognitionConfig{
+		Encoding:                   speechpb.RecognitionConfig_LINEAR16,
+		SampleRateHertz:            SampleRate,
+		LanguageCode:               g.language,
+		Model:                      g.model,
+		EnableAutomaticPunctuation: true,
+		EnableWordTimeOffsets:      true,
+		EnableWordConfidence:       true,
+		ProfanityFilter:            g.profanity.Mode() != ProfanityRaw,
+	}
+}
+
+// StartContinuousRecognition opens a streaming call and the microphone
+func (g *GoogleService) StartContinuousRecognition(ctx context.Context) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.isListening {
+		log.Printf("⚠️  Already listening - ignoring start request")
+		return nil
+	}
+
+	log.Printf("🔌 CONNECTING TO GOOGLE SPEECH...")
+	// The call outlives ctx, which only bounds opening it
+	var streamCtx context.Context
+	var cancel context.CancelFunc
+	if g.maxDuration > 0 {
+		streamCtx, cancel = context.WithTimeout(context.Background(), g.maxDuration)
+	} else {
+		streamCtx, cancel = context.WithCancel(context.Background())
+	}
+	stop := watchContext(ctx, cancel)
+	defer stop()
+	stream, err := g.client.StreamingRecognize(streamCtx)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to open streaming call: %v", err)
+	}
+
+	// The first message configures the stream; audio follows
+	err = stream.Send(&speechpb.StreamingRecognizeRequest{
+		StreamingRequest: &speechpb.StreamingRecognizeRequest_StreamingConfig{
+			StreamingConfig: &speechpb.StreamingRecognitionConfig{
+				Config:          g.recognitionConfig(),
+				SingleUtterance: true,
+				InterimResults:  g.onHypothesis != nil,
+			},
+		},
+	})
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to send streaming config: %v", err)
+	}
+
+	err = g.mic.start()
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to start audio capture: %w", err)
+	}
+
+	if ctx.Err() != nil {
+		cancel()
+		g.mic.stop()
+		return ctx.Err()
+	}
+
+	g.cancel = cancel
+	g.isListening = true
+	endOfUtterance := make(chan struct{})
+	g.workers.Add(2)
+	go g.handleAudioStreaming(streamCtx, stream, endOfUtterance)
+	go g.handleResponses(streamCtx, stream, endOfUtterance)
+
+	log.Printf("🟢 LIVE STREAMING ACTIVE - Speak now!")
+	return nil
+}
+
+// handleAudioStreaming sends queued audio until the call ends or Google
+// has heard the end of the utterance
+func (g *GoogleService) handleAudioStreaming(ctx context.Context, stream speechpb.Speech_StreamingRecognizeClient, endOfUtterance <-chan struct{}) {
+	defer g.workers.Done()
+	ticker := time.NewTicker(StreamInterval)
+	defer ticker.Stop()
+
+	var turnAudio []int16
+	defer func() {
+		if g.onTurnAudio != nil && len(turnAudio) > 0 {
+			go g.onTurnAudio(turnAudio)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-endOfUtterance:
+			stream.CloseSend()
+			return
+		case <-ticker.C:
+		}
+
+		samples := g.mic.queue.Drain()
+		if len(samples) == 0 {
+			continue
+		}
+
+		audioBytes := make([]byte, len(samples)*2)
+		for i, sample := range samples {
+			binary.LittleEndian.PutUint16(audioBytes[i*2:], uint16(sample))
+		}
+		err := stream.Send(&speechpb.StreamingRecognizeRequest{
+			StreamingRequest: &speechpb.StreamingRecognizeRequest_AudioContent{AudioContent: audioBytes},
+		})
+		if err != nil {
+			// The server closed the stream; handleResponses reports why
+			return
+		}
+		if g.onTurnAudio != nil {
+			turnAudio = append(turnAudio, samples...)
+		}
+	}
+}
+
+// handleResponses reports the final result of the utterance, or the end
+// of the turn when nothing was recognized
+func (g *GoogleService) handleResponses(ctx context.Context, stream speechpb.Speech_StreamingRecognizeClient, endOfUtterance chan<- struct{}) {
+	defer g.workers.Done()
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			log.Printf("❌ Google Speech error: %v", err)
+			if g.onError != nil {
+				g.onError(err)
+			}
+			return
+		}
+
+		if resp.SpeechEventType == speechpb.StreamingRecognizeResponse_END_OF_SINGLE_UTTERANCE {
+			// Google stops listening; end the audio and wait for the final result
+			close(endOfUtterance)
+			continue
+		}
+
+		for _, result := range resp.Results {
+			if len(result.Alternatives) == 0 {
+				continue
+			}
+			if !result.IsFinal {
+				if g.onHypothesis != nil {
+					g.onHypothesis(g.profanity.Clean(result.Alternatives[0].Transcript))
+				}
+				continue
+			}
+			recognized := g.profanity.CleanResult(googleResult(result, g.language))
+			if recognized.Text == "" {
+				continue
+			}
+			log.Printf("🎯 FINAL RESULT: '%s' (confidence %.2f)", recognized.Text, recognized.Confidence)
+			if g.onRecognized != nil {
+				g.onRecognized(recognized)
+			}
+		}
+	}
+
+	// The utterance is over, or the call ran into the listening limit; a
+	// caller still listening needs a new turn
+	if ctx.Err() == context.DeadlineExceeded {
+		log.Printf("⏰ Max streaming duration reached, stopping...")
+	}
+	if ctx.Err() == nil || ctx.Err() == context.DeadlineExceeded {
+		log.Printf("🔚 Turn ended by service")
+		if g.onTurnEnd != nil {
+			g.onTurnEnd()
+		}
+	}
+}
+
+// googleResult converts Google's best alternative to a RecognitionResult
+func googleResult(result *speechpb.StreamingRecognitionResult, fallbackLanguage string) RecognitionResult {
+	best := result.Alternatives[0]
+	recognized := RecognitionResult{
+		Text:       best.Transcript,
+		Lexical:    best.Transcript,
+		Language:   result.LanguageCode,
+		Confidence: float64(best.Confidence),
+	}
+	if recognized.Language == "" {
+		recognized.Language = fallbackLanguage
+	}
+
+	for _, word := range best.Words {
+		start := word.StartTime.AsDuration()
+		recognized.Words = append(recognized.Words, Word{
+			Text:       word.Word,
+			Offset:     start,
+			Duration:   word.EndTime.AsDuration() - start,
+			Confidence: float64(word.Confidence),
+		})
+	}
+	if len(recognized.Words) > 0 {
+		recognized.Offset = recognized.Words[0].Offset
+		last := recognized.Words[len(recognized.Words)-1]
+		recognized.Duration = last.Offset + last.Duration - recognized.Offset
+	}
+	return recognized
+}
+
+// TranscribePCM recognizes pre-recorded 16kHz mono audio with a single
+// synchronous request
+func (g *GoogleService) TranscribePCM(ctx context.Context, samples []int16) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, TranscribeTimeout)
+	defer cancel()
+
+	audioBytes := make([]byte, len(samples)*2)
+	for i, sample := range samples {
+		binary.LittleEndian.PutUint16(audioBytes[i*2:], uint16(sample))
+	}
+
+	resp, err := g.client.Recognize(ctx, &speechpb.RecognizeRequest{
+		Config: g.recognitionConfig(),
+		Audio:  &speechpb.RecognitionAudio{AudioSource: &speechpb.RecognitionAudio_Content{Content: audioBytes}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("Google recognition failed: %v", err)
+	}
+
+	var text string
+	for _, result := range resp.Results {
+		if len(result.Alternatives) == 0 {
+			continue
+		}
+		if text != "" {
+			text += " "
+		}
+		text += result.Alternatives[0].Transcript
+	}
+	text = g.profanity.Clean(text)
+	log.Printf("📝 Transcribed %d samples: '%s'", len(samples), text)
+	return text, nil
+}
+
+// StopContinuousRecognition ends the streaming call and audio capture
+func (g *GoogleService) StopContinuousRecognition() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if !g.isListening {
+		return nil
+	}
+
+	g.cancel()
+	g.isListening = false
+	g.mic.stop()
+
+	log.Printf("🔴 STREAMING STOPPED")
+	return nil
+}
+
+// IsListening returns whether a streaming call is active
+func (g *GoogleService) IsListening() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.isListening
+}
+
+// Reconnect restarts an active streaming call on a fresh connection
+func (g *GoogleService) Reconnect(ctx context.Context) error {
+	if !g.IsListening() {
+		return nil
+	}
+
+	log.Printf("🔄 RECONNECTING GOOGLE STREAM...")
+	err := g.StopContinuousRecognition()
+	if err != nil {
+		return fmt.Errorf("failed to stop session: %v", err)
+	}
+	return g.StartContinuousRecognition(ctx)
+}
+
+// TestConnection recognizes a moment of silence to check the credentials
+func (g *GoogleService) TestConnection(ctx context.Context) error {
+	_, err := g.TranscribePCM(ctx, make([]int16, SampleRate/10))
+	if err != nil {
+		return fmt.Errorf("connection test failed: %v", err)
+	}
+	return nil
+}
+
+// Close releases the microphone and the gRPC connection
+func (g *GoogleService) Close() {
+	g.StopContinuousRecognition()
+
+	g.mutex.Lock()
+	g.mic.close()
+	g.mutex.Unlock()
+
+	if !waitWorkers(&g.workers) {
+		log.Printf("⚠️  Speech session goroutines did not exit in time")
+	}
+	g.client.Close()
+	portaudio.Terminate()
+}
@@ -0,0 +1,116 @@
+package speech
+
+import (
+	"log"
+	"time"
+
+	"voice-assistant/internal/audio"
+)
+
+// microphone captures 16kHz mono audio for providers that batch or stream
+// it themselves. Frames go to queue while live and to the pre-roll buffer
+// otherwise. Callers serialize access with their own mutex.
+type microphone struct {
+	source    audio.Source
+	capturing bool
+	queue     *audio.FrameQueue
+	preRoll   *audio.RingBuffer
+	echoGate  *audio.EchoGate
+	live      bool
+}
+
+// newMicrophone creates a microphone on the default input device
+func newMicrophone() *microphone {
+	return &microphone{
+		source: audio.NewMicrophoneSource(),
+		queue:  audio.NewFrameQueue(AudioBacklog),
+	}
+}
+
+// setSource switches the capture source, reopening it if it was capturing
+func (m *microphone) setSource(source audio.Source) error {
+	if !m.capturing {
+		m.source = source
+		return nil
+	}
+
+	m.close()
+	m.source = source
+	return m.open()
+}
+
+// enablePreRoll keeps the source open while idle, buffering duration of audio
+func (m *microphone) enablePreRoll(duration time.Duration) error {
+	if duration <= 0 || m.preRoll != nil {
+		return nil
+	}
+
+	m.preRoll = audio.NewRingBuffer(duration)
+	err := m.open()
+	if err != nil {
+		m.preRoll = nil
+		return err
+	}
+
+	log.Printf("⏪ Pre-roll buffer enabled (%v)", duration)
+	return nil
+}
+
+// start sends audio to the queue, beginning with the pre-roll
+func (m *microphone) start() error {
+	if !m.capturing {
+		err := m.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	m.queue.Reset()
+	if m.preRoll != nil {
+		m.queue.Push(m.preRoll.Drain())
+	}
+	m.live = true
+	return nil
+}
+
+// stop ends a live capture, closing the source unless pre-roll needs it
+func (m *microphone) stop() {
+	m.live = false
+	if m.preRoll == nil {
+		m.close()
+	}
+}
+
+// open starts the capture source
+func (m *microphone) open() error {
+	err := m.source.Start(m.process)
+	if err != nil {
+		return err
+	}
+	m.capturing = true
+	return nil
+}
+
+// close stops the capture source
+func (m *microphone) close() {
+	if m.capturing {
+		m.source.Stop()
+		m.capturing = false
+	}
+}
+
+// process handles incoming audio data from the capture source
+func (m *microphone) process(in []int16) {
+	// Don't let the assistant hear itself
+	if m.echoGate != nil {
+		in = m.echoGate.Filter(in)
+	}
+
+	if !m.live {
+		if m.preRoll != nil {
+			m.preRoll.Write(in)
+		}
+		return
+	}
+	m.queue.Push(in)
+}
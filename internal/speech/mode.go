@@ -0,0 +1,39 @@
+package speech
+
+// Azure recognition modes. Each has its own endpoint tuned for a kind of
+// speech: interactive expects short commands, conversation handles
+// natural back-and-forth, and dictation turns spoken punctuation like
+// "comma" or "new line" into text.
+const (
+	ModeInteractive  = "interactive"
+	ModeConversation = "conversation"
+	ModeDictation    = "dictation"
+)
+
+// Use cases the assistant recognizes speech for
+const (
+	UseChat    = "chat"    // Talking to Claude
+	UseTyping  = "typing"  // Text typed into another application
+	UseCommand = "command" // Short local commands
+)
+
+// ModeFor returns the recognition mode that suits a use case
+func ModeFor(useCase string) string {
+	switch useCase {
+	case UseTyping:
+		return ModeDictation
+	case UseCommand:
+		return ModeInteractive
+	default:
+		return ModeConversation
+	}
+}
+
+// ValidMode reports whether mode is one of Azure's recognition modes
+func ValidMode(mode string) bool {
+	switch mode {
+	case ModeInteractive, ModeConversation, ModeDictation:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package speech
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// warmConnection is a WebSocket opened ahead of the next session, so
+// audio flows as soon as listening starts instead of after the handshake
+type warmConnection struct {
+	conn     *websocket.Conn
+	endpoint string // The URL it was opened with, so changed settings retire it
+	opened   time.Time
+}
+
+// SetPrewarm keeps a connection open between sessions when enabled. Each
+// one is replaced after WarmMaxAge so it is still good when used.
+func (a *AzureWebSocketSpeechService) SetPrewarm(enabled bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if enabled == (a.warmStop != nil) {
+		return
+	}
+	if enabled {
+		a.warmStop = make(chan struct{})
+		go a.keepWarm(a.warmStop)
+		log.Printf("🔥 Keeping an Azure connection warm")
+		return
+	}
+	close(a.warmStop)
+	a.warmStop = nil
+	a.dropWarm()
+}
+
+// keepWarm replaces the warm connection whenever it is used, stale or
+// opened with old settings, backing off after a failure
+func (a *AzureWebSocketSpeechService) keepWarm(stop <-chan struct{}) {
+	for {
+		wait := warmCheck
+		err := a.warmUp()
+		if err != nil {
+			log.Printf("⚠️  Failed to warm up an Azure connection: %v", err)
+			wait = WarmMaxAge
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// warmUp opens a connection for the next session unless a good one is
+// ready or a session is running
+func (a *AzureWebSocketSpeechService) warmUp() error {
+	a.mutex.Lock()
+	endpoint := a.endpoint()
+	if a.warm != nil && (a.warm.endpoint != endpoint.String() || time.Since(a.warm.opened) > WarmMaxAge) {
+		a.dropWarm()
+	}
+	needed := a.warmStop != nil && a.warm == nil && !a.isListening
+	a.mutex.Unlock()
+	if !needed {
+		return nil
+	}
+
+	// Dial without the mutex, so a session can start meanwhile
+	ctx, cancel := context.WithTimeout(context.Background(), warmDialTimeout)
+	defer cancel()
+	conn, err := a.dialWithKeys(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	current := a.endpoint()
+	if a.warmStop == nil || a.warm != nil || current.String() != endpoint.String() {
+		conn.Close()
+		return nil
+	}
+	a.warm = &warmConnection{conn: conn, endpoint: endpoint.String(), opened: time.Now()}
+	return nil
+}
+
+// takeWarm hands over the warm connection if it was opened for endpoint
+// and is still fresh. Called with the mutex held.
+func (a *AzureWebSocketSpeechService) takeWarm(endpoint url.URL) *websocket.Conn {
+	warm := a.warm
+	a.warm = nil
+	if warm == nil {
+		return nil
+	}
+	if warm.endpoint != endpoint.String() || time.Since(warm.opened) > WarmMaxAge {
+		warm.conn.Close()
+		return nil
+	}
+	log.Printf("🔥 Using a warm connection (%s old)", time.Since(warm.opened).Round(time.Second))
+	return warm.conn
+}
+
+// dropWarm closes the warm connection. Called with the mutex held.
+func (a *AzureWebSocketSpeechService) dropWarm() {
+	if a.warm != nil {
+		a.warm.conn.Close()
+		a.warm = nil
+	}
+}
@@ -0,0 +1,88 @@
+package speech
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Profanity options, matching Azure's profanity query parameter
+const (
+	ProfanityMasked  = "masked"  // Replace each letter with an asterisk
+	ProfanityRemoved = "removed" // Drop the word entirely
+	ProfanityRaw     = "raw"     // Leave transcripts untouched
+)
+
+// Words filtered locally in addition to Azure's own list
+var defaultProfanity = []string{
+	"fuck", "fucking", "fucked", "shit", "bullshit", "bitch", "bastard",
+	"asshole", "dick", "cunt", "damn", "crap", "piss",
+}
+
+// ProfanityFilter cleans transcripts locally so nothing Azure missed
+// reaches Claude or the screen
+type ProfanityFilter struct {
+	mode    string
+	pattern *regexp.Regexp
+}
+
+// NewProfanityFilter creates a filter for the mode, matching the default
+// word list plus extra words. Unknown modes mask, erring on the clean side.
+func NewProfanityFilter(mode string, extra []string) *ProfanityFilter {
+	switch mode {
+	case ProfanityMasked, ProfanityRemoved, ProfanityRaw:
+	default:
+		mode = ProfanityMasked
+	}
+
+	var words []string
+	for _, word := range append(append([]string{}, defaultProfanity...), extra...) {
+		if word = strings.TrimSpace(word); word != "" {
+			words = append(words, regexp.QuoteMeta(word))
+		}
+	}
+
+	return &ProfanityFilter{
+		mode:    mode,
+		pattern: regexp.MustCompile(`(?i)\b(` + strings.Join(words, "|") + `)\b`),
+	}
+}
+
+// Mode returns the Azure profanity option for the filter
+func (f *ProfanityFilter) Mode() string {
+	return f.mode
+}
+
+// Clean masks or removes profanity from text
+func (f *ProfanityFilter) Clean(text string) string {
+	switch f.mode {
+	case ProfanityRaw:
+		return text
+	case ProfanityRemoved:
+		cleaned := f.pattern.ReplaceAllString(text, "")
+		return strings.Join(strings.Fields(cleaned), " ")
+	default:
+		return f.pattern.ReplaceAllStringFunc(text, func(word string) string {
+			return strings.Repeat("*", len([]rune(word)))
+		})
+	}
+}
+
+// CleanResult applies Clean to every text in a recognition result
+func (f *ProfanityFilter) CleanResult(result RecognitionResult) RecognitionResult {
+	if f.mode == ProfanityRaw {
+		return result
+	}
+
+	result.Text = f.Clean(result.Text)
+	result.Lexical = f.Clean(result.Lexical)
+
+	words := result.Words[:0:0]
+	for _, word := range result.Words {
+		word.Text = f.Clean(word.Text)
+		if word.Text != "" {
+			words = append(words, word)
+		}
+	}
+	result.Words = words
+	return result
+}
@@ -0,0 +1,53 @@
+package speech
+
+import "time"
+
+// Word is one recognized word with its timing within the session audio
+type Word struct {
+	Text       string
+	Offset     time.Duration // From the start of the session audio
+	Duration   time.Duration
+	Confidence float64 // 0-1
+}
+
+// RecognitionResult is a final recognized phrase with its details
+type RecognitionResult struct {
+	Text       string  // Display form with punctuation and capitalization
+	Lexical    string  // Words as spoken, without formatting
+	Language   string  // Language the phrase was recognized in
+	Confidence float64 // 0-1, for the best alternative
+	Offset     time.Duration
+	Duration   time.Duration
+	Words      []Word // Empty when the service returned no word timings
+}
+
+// ticks converts Azure's 100-nanosecond units to a duration
+func ticks(value int64) time.Duration {
+	return time.Duration(value) * 100
+}
+
+// Result converts a successful phrase message to a RecognitionResult,
+// using fallbackLanguage when language identification was off
+func (r *SpeechResultMessage) Result(fallbackLanguage string) RecognitionResult {
+	result := RecognitionResult{
+		Text:     r.FinalText(),
+		Language: r.DetectedLanguage(fallbackLanguage),
+		Offset:   ticks(r.Offset),
+		Duration: ticks(r.Duration),
+	}
+
+	if len(r.NBest) > 0 {
+		best := r.NBest[0]
+		result.Lexical = best.Lexical
+		result.Confidence = best.Confidence
+		for _, word := range best.Words {
+			result.Words = append(result.Words, Word{
+				Text:       word.Word,
+				Offset:     ticks(word.Offset),
+				Duration:   ticks(word.Duration),
+				Confidence: word.Confidence,
+			})
+		}
+	}
+	return result
+}
@@ -1 +1,110 @@
 package speech
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"voice-assistant/internal/audio"
+)
+
+// Provider names accepted in config
+const (
+	ProviderAzure   = "azure"   // Azure Speech Services over WebSocket
+	ProviderWhisper = "whisper" // A local whisper.cpp server, works offline
+	ProviderGoogle  = "google"  // Google Cloud Speech-to-Text over gRPC
+)
+
+// Provider recognizes speech from the microphone. Providers capture 16 kHz
+// mono PCM, decide on their own where an utterance ends and report it
+// through the recognition callback while still listening.
+type Provider interface {
+	Name() string
+
+	SetCallbacks(onRecognized func(result RecognitionResult), onError func(error))
+	SetTurnEndCallback(onTurnEnd func())
+	SetHypothesisCallback(onHypothesis func(text string))
+	SetTurnAudioCallback(onTurnAudio func(samples []int16))
+	SetEchoGate(gate *audio.EchoGate)
+	SetCaptureSource(source audio.Source) error
+	SetProfanityFilter(filter *ProfanityFilter)
+	SetMaxDuration(duration time.Duration) // 0 listens until stopped
+	EnablePreRoll(duration time.Duration) error
+
+	// StartContinuousRecognition connects and starts listening; ctx bounds
+	// connecting, the session itself runs until StopContinuousRecognition
+	StartContinuousRecognition(ctx context.Context) error
+	StopContinuousRecognition() error
+	IsListening() bool
+	Reconnect(ctx context.Context) error
+
+	// TranscribePCM recognizes pre-recorded audio outside a live session
+	TranscribePCM(ctx context.Context, samples []int16) (string, error)
+
+	TestConnection(ctx context.Context) error
+	Close()
+}
+
+// TurnContinuer is implemented by providers that can start a new turn on
+// the open session after the service ends one, without reconnecting
+type TurnContinuer interface {
+	NextTurn() error
+}
+
+// WorkerShutdownTimeout bounds how long Close waits for session goroutines,
+// which may be busy in a callback
+const WorkerShutdownTimeout = 3 * time.Second
+
+// waitWorkers waits for session goroutines to exit and reports whether they
+// did within WorkerShutdownTimeout
+func waitWorkers(workers *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(WorkerShutdownTimeout):
+		return false
+	}
+}
+
+// isClosed reports whether a session channel has been closed
+func isClosed(session <-chan struct{}) bool {
+	select {
+	case <-session:
+		return true
+	default:
+		return false
+	}
+}
+
+// watchContext calls onDone if ctx ends before the returned stop function
+// is called, for operations that can't take a context themselves
+func watchContext(ctx context.Context, onDone func()) (stop func()) {
+	var mutex sync.Mutex
+	stopped := false
+	finished := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			mutex.Lock()
+			if !stopped {
+				onDone()
+			}
+			mutex.Unlock()
+		case <-finished:
+		}
+	}()
+
+	return func() {
+		mutex.Lock()
+		stopped = true
+		mutex.Unlock()
+		close(finished)
+	}
+}
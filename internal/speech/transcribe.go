@@ -0,0 +1,95 @@
+package speech
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// TranscribeTimeout bounds how long a one-shot transcription may take
+const TranscribeTimeout = 30 * time.Second
+
+// TranscribePCM recognizes pre-recorded 16kHz mono audio over a dedicated
+// WebSocket session and returns the recognized phrases joined together.
+// It cannot run while live recognition is active.
+func (a *AzureWebSocketSpeechService) TranscribePCM(ctx context.Context, samples []int16) (string, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.isListening {
+		return "", fmt.Errorf("cannot transcribe while listening")
+	}
+
+	a.newTurn()
+	err := a.connectWebSocket(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to WebSocket: %w", err)
+	}
+	defer a.disconnectWebSocket()
+
+	// Closing the connection unblocks any pending write or read
+	conn := a.conn
+	stop := watchContext(ctx, func() { conn.Close() })
+	defer stop()
+
+	// Audio is already recorded, so send it as fast as the service accepts it
+	chunkSize := SampleRate * int(StreamInterval) / int(time.Second)
+	for start := 0; start < len(samples); start += chunkSize {
+		end := start + chunkSize
+		if end > len(samples) {
+			end = len(samples)
+		}
+		err = a.sendAudioChunk(conn, samples[start:end])
+		if err != nil {
+			return "", fmt.Errorf("failed to send audio: %v", err)
+		}
+	}
+	err = a.write(conn, websocket.BinaryMessage, audioMessage(a.turnID(), nil))
+	if err != nil {
+		return "", fmt.Errorf("failed to send end of audio: %v", err)
+	}
+
+	// Collect phrases until the service ends the turn
+	conn.SetReadDeadline(time.Now().Add(TranscribeTimeout))
+	var phrases []string
+	for {
+		messageType, data, err := conn.ReadMessage()
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read result: %v", err)
+		}
+		if messageType != websocket.TextMessage {
+			continue
+		}
+
+		parts := bytes.SplitN(data, []byte("\r\n\r\n"), 2)
+		if len(parts) < 2 {
+			continue
+		}
+		headers := parts[0]
+
+		if bytes.Contains(headers, []byte("Path:speech.phrase")) {
+			var result SpeechResultMessage
+			if json.Unmarshal(parts[1], &result) != nil || result.RecognitionStatus != "Success" {
+				continue
+			}
+			if text := a.profanity.Clean(result.FinalText()); text != "" {
+				phrases = append(phrases, text)
+			}
+		} else if bytes.Contains(headers, []byte("Path:turn.end")) {
+			break
+		}
+	}
+
+	text := strings.Join(phrases, " ")
+	log.Printf("📝 Transcribed %d samples: '%s'", len(samples), text)
+	return text, nil
+}
@@ -0,0 +1,471 @@
+package speech
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gordonklaus/portaudio"
+
+	"voice-assistant/internal/audio"
+)
+
+// Endpointing for the whisper backend, which has no server-side
+// voice activity detection
+const (
+	WhisperSpeechThreshold = 800                    // Average amplitude counted as speech
+	WhisperEndSilence      = 800 * time.Millisecond // Silence that ends an utterance
+	WhisperInitialSilence  = 8 * time.Second        // Silence before anything is said that ends the turn
+	WhisperTimeout         = 60 * time.Second       // Bounds one transcription request
+	WhisperStartupTimeout  = 30 * time.Second       // Time a launched server gets to load its model
+)
+
+// WhisperService recognizes speech with a whisper.cpp server, either one
+// already running or one it launches itself, so no audio leaves the machine
+type WhisperService struct {
+	serverURL  string
+	language   string // Whisper language code, e.g. "en"
+	httpClient *http.Client
+	server     *exec.Cmd // Launched server, nil when connecting to an existing one
+
+	mutex       sync.Mutex
+	mic         *microphone
+	isListening bool
+	maxDuration time.Duration // Session length limit, 0 for none
+	profanity   *ProfanityFilter
+	session     chan struct{}  // Closed when the current session stops
+	workers     sync.WaitGroup // Session goroutines, waited for by Close
+
+	onRecognized func(result RecognitionResult)
+	onError      func(error)
+	onTurnEnd    func()
+	onTurnAudio  func(samples []int16)
+}
+
+// WhisperServer describes how to launch a local whisper.cpp server
+type WhisperServer struct {
+	Executable string // Path to whisper-server; empty connects to ServerURL instead
+	Model      string // Path to the ggml model file
+	Port       int
+}
+
+// NewWhisperService creates a whisper backend for the server at serverURL,
+// launching it first when server names an executable. language is a BCP-47
+// tag such as "en-US"; whisper only uses the language part.
+func NewWhisperService(serverURL string, server WhisperServer, language string) (*WhisperService, error) {
+	service := &WhisperService{
+		serverURL:   strings.TrimRight(serverURL, "/"),
+		language:    whisperLanguage(language),
+		httpClient:  &http.Client{Timeout: WhisperTimeout},
+		mic:         newMicrophone(),
+		maxDuration: MaxDuration,
+		profanity:   NewProfanityFilter(ProfanityMasked, nil),
+	}
+
+	if server.Executable != "" {
+		err := service.launch(server)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := portaudio.Initialize()
+	if err != nil {
+		service.stopServer()
+		return nil, fmt.Errorf("failed to initialize PortAudio: %v", err)
+	}
+
+	log.Printf("🐚 Whisper Speech Service initialized")
+	log.Printf("   📡 Server: %s", service.serverURL)
+	log.Printf("   🗣️  Language: %s", service.language)
+	return service, nil
+}
+
+// whisperLanguage reduces a tag like "en-US" to whisper's "en"
+func whisperLanguage(language string) string {
+	if language == "" {
+		return "auto"
+	}
+	return strings.ToLower(strings.SplitN(language, "-", 2)[0])
+}
+
+// launch starts a whisper.cpp server and waits for it to accept requests
+func (w *WhisperService) launch(server WhisperServer) error {
+	if _, err := os.Stat(server.Model); err != nil {
+		return fmt.Errorf("whisper model not found: %v", err)
+	}
+
+	cmd := exec.Command(server.Executable,
+		"-m", server.Model,
+		"-l", w.language,
+		"--host", "127.0.0.1",
+		"--port", fmt.Sprint(server.Port),
+	)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	err := cmd.Start()
+	if err != nil {
+		return fmt.Errorf("failed to start whisper server: %v", err)
+	}
+	w.server = cmd
+	w.serverURL = fmt.Sprintf("http://127.0.0.1:%d", server.Port)
+	log.Printf("🚀 Started whisper server (pid %d) with %s", cmd.Process.Pid, server.Model)
+
+	deadline := time.Now().Add(WhisperStartupTimeout)
+	for time.Now().Before(deadline) {
+		if w.TestConnection(context.Background()) == nil {
+			return nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	w.stopServer()
+	return fmt.Errorf("whisper server did not start within %v", WhisperStartupTimeout)
+}
+
+// stopServer ends a launched server
+func (w *WhisperService) stopServer() {
+	if w.server != nil && w.server.Process != nil {
+		w.server.Process.Kill()
+		w.server.Wait()
+		w.server = nil
+	}
+}
+
+// Name identifies the provider in logs
+func (w *WhisperService) Name() string {
+	return "Whisper (local)"
+}
+
+// SetCallbacks sets the recognition and error callbacks
+func (w *WhisperService) SetCallbacks(onRecognized func(result RecognitionResult), onError func(error)) {
+	w.onRecognized = onRecognized
+	w.onError = onError
+}
+
+// SetTurnEndCallback sets a callback invoked when a turn ends without speech
+func (w *WhisperService) SetTurnEndCallback(onTurnEnd func()) {
+	w.onTurnEnd = onTurnEnd
+}
+
+// SetHypothesisCallback is accepted for the interface; whisper transcribes
+// whole utterances and has no interim text to report
+func (w *WhisperService) SetHypothesisCallback(onHypothesis func(text string)) {}
+
+// SetTurnAudioCallback sets a callback receiving each turn's audio
+func (w *WhisperService) SetTurnAudioCallback(onTurnAudio func(samples []int16)) {
+	w.onTurnAudio = onTurnAudio
+}
+
+// SetEchoGate mutes microphone input whenever the gate is closed
+func (w *WhisperService) SetEchoGate(gate *audio.EchoGate) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.mic.echoGate = gate
+}
+
+// SetCaptureSource switches where audio comes from
+func (w *WhisperService) SetCaptureSource(source audio.Source) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.mic.setSource(source)
+}
+
+// SetProfanityFilter cleans transcripts before they are reported
+func (w *WhisperService) SetProfanityFilter(filter *ProfanityFilter) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.profanity = filter
+}
+
+// SetMaxDuration limits how long a session listens, 0 for no limit
+func (w *WhisperService) SetMaxDuration(duration time.Duration) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.maxDuration = duration
+}
+
+// EnablePreRoll keeps the microphone open while idle and buffers the last
+// duration of audio, which starts the next utterance
+func (w *WhisperService) EnablePreRoll(duration time.Duration) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.mic.enablePreRoll(duration)
+}
+
+// StartContinuousRecognition opens the microphone and listens for one
+// utterance
+func (w *WhisperService) StartContinuousRecognition(ctx context.Context) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.isListening {
+		log.Printf("⚠️  Already listening - ignoring start request")
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	err := w.mic.start()
+	if err != nil {
+		return fmt.Errorf("failed to start audio capture: %w", err)
+	}
+
+	w.isListening = true
+	w.session = make(chan struct{})
+	w.workers.Add(1)
+	go w.handleUtterances(w.session, w.maxDuration)
+
+	log.Printf("🟢 LISTENING LOCALLY - Speak now!")
+	return nil
+}
+
+// handleUtterances collects audio until the speaker pauses, then
+// transcribes it and starts on the next utterance. It gives up when the
+// session stops.
+func (w *WhisperService) handleUtterances(session chan struct{}, maxDuration time.Duration) {
+	defer w.workers.Done()
+	ticker := time.NewTicker(StreamInterval)
+	defer ticker.Stop()
+
+	// Stopping the session cancels a transcription in flight
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-session:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+
+	var utterance []int16
+	var heardSpeech bool
+	var silence time.Duration
+	sessionStart := time.Now()
+	started := sessionStart
+
+	for {
+		select {
+		case <-session:
+			return
+		case <-ticker.C:
+		}
+
+		if maxDuration > 0 && time.Since(sessionStart) > maxDuration {
+			log.Printf("⏰ Max listening duration reached, stopping...")
+			w.StopContinuousRecognition()
+			if w.onTurnEnd != nil {
+				w.onTurnEnd()
+			}
+			return
+		}
+
+		samples := w.mic.queue.Drain()
+		utterance = append(utterance, samples...)
+
+		if amplitude(samples) > WhisperSpeechThreshold {
+			heardSpeech = true
+			silence = 0
+		} else {
+			silence += StreamInterval
+		}
+
+		switch {
+		case !heardSpeech && time.Since(started) > WhisperInitialSilence:
+			log.Printf("🔚 No speech heard, ending turn")
+			if w.onTurnEnd != nil {
+				w.onTurnEnd()
+			}
+			return
+		case heardSpeech && silence >= WhisperEndSilence, time.Since(started) > MaxDuration:
+			w.recognize(ctx, utterance)
+
+			// Keep listening unless the callback ended the session
+			select {
+			case <-session:
+				return
+			default:
+			}
+			utterance, heardSpeech, silence, started = nil, false, 0, time.Now()
+		}
+	}
+}
+
+// recognize transcribes a finished utterance and reports it
+func (w *WhisperService) recognize(ctx context.Context, utterance []int16) {
+	if w.onTurnAudio != nil {
+		go w.onTurnAudio(utterance)
+	}
+
+	start := time.Now()
+	text, err := w.transcribe(ctx, utterance)
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Whisper transcription failed: %v", err)
+		if w.onError != nil {
+			w.onError(err)
+		}
+		return
+	}
+
+	if text == "" {
+		log.Printf("🔇 No speech recognized")
+		if w.onTurnEnd != nil {
+			w.onTurnEnd()
+		}
+		return
+	}
+
+	result := w.profanity.CleanResult(RecognitionResult{
+		Text:     text,
+		Lexical:  text,
+		Language: w.language,
+		Duration: time.Duration(len(utterance)) * time.Second / SampleRate,
+	})
+	log.Printf("🎯 FINAL RESULT: '%s' (transcribed in %v)", result.Text, time.Since(start).Round(time.Millisecond))
+	if w.onRecognized != nil {
+		w.onRecognized(result)
+	}
+}
+
+// transcribe posts audio to the whisper server's inference endpoint
+func (w *WhisperService) transcribe(ctx context.Context, samples []int16) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", "audio.wav")
+	if err != nil {
+		return "", err
+	}
+	part.Write(audio.EncodeWAV(samples))
+	form.WriteField("response_format", "json")
+	form.WriteField("temperature", "0")
+	form.Close()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", w.serverURL+"/inference", &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("whisper request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read whisper response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("whisper server error %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var result struct {
+		Text string `json:"text"`
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse whisper response: %v", err)
+	}
+	return strings.TrimSpace(result.Text), nil
+}
+
+// TranscribePCM recognizes pre-recorded 16kHz mono audio
+func (w *WhisperService) TranscribePCM(ctx context.Context, samples []int16) (string, error) {
+	text, err := w.transcribe(ctx, samples)
+	if err != nil {
+		return "", err
+	}
+	text = w.profanity.Clean(text)
+	log.Printf("📝 Transcribed %d samples: '%s'", len(samples), text)
+	return text, nil
+}
+
+// StopContinuousRecognition stops listening, discarding any utterance in
+// progress
+func (w *WhisperService) StopContinuousRecognition() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if !w.isListening {
+		return nil
+	}
+
+	close(w.session)
+	w.isListening = false
+	w.mic.stop()
+
+	log.Printf("🔴 LISTENING STOPPED")
+	return nil
+}
+
+// IsListening returns whether an utterance is being listened for
+func (w *WhisperService) IsListening() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.isListening
+}
+
+// Reconnect is a no-op: each utterance is a separate request
+func (w *WhisperService) Reconnect(ctx context.Context) error {
+	return nil
+}
+
+// TestConnection checks that the whisper server is reachable
+func (w *WhisperService) TestConnection(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", w.serverURL+"/", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("whisper server unreachable: %v", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Close releases the microphone and any launched server
+func (w *WhisperService) Close() {
+	w.StopContinuousRecognition()
+
+	w.mutex.Lock()
+	w.mic.close()
+	w.mutex.Unlock()
+
+	if !waitWorkers(&w.workers) {
+		log.Printf("⚠️  Speech session goroutines did not exit in time")
+	}
+	w.stopServer()
+	portaudio.Terminate()
+}
+
+// amplitude returns the average absolute sample value
+func amplitude(samples []int16) int64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	var sum int64
+	for _, sample := range samples {
+		if sample < 0 {
+			sum += int64(-sample)
+		} else {
+			sum += int64(sample)
+		}
+	}
+	return sum / int64(len(samples))
+}
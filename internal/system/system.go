@@ -0,0 +1,91 @@
+// Package system controls media playback, the system volume and the
+// session through Windows APIs, so these commands work without a network.
+package system
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Virtual key codes for the media and volume keys
+const (
+	VK_VOLUME_MUTE      = 0xAD
+	VK_VOLUME_DOWN      = 0xAE
+	VK_VOLUME_UP        = 0xAF
+	VK_MEDIA_NEXT_TRACK = 0xB0
+	VK_MEDIA_PREV_TRACK = 0xB1
+	VK_MEDIA_PLAY_PAUSE = 0xB3
+
+	KEYEVENTF_EXTENDEDKEY = 0x0001
+	KEYEVENTF_KEYUP       = 0x0002
+)
+
+// Each volume key press moves the system volume by 2%
+const VolumeSteps = 5
+
+var (
+	user32          = syscall.NewLazyDLL("user32.dll")
+	keybdEvent      = user32.NewProc("keybd_event")
+	lockWorkStation = user32.NewProc("LockWorkStation")
+)
+
+// PlayPause toggles playback in the active media application
+func PlayPause() error {
+	return pressKey(VK_MEDIA_PLAY_PAUSE)
+}
+
+// NextTrack skips to the next track
+func NextTrack() error {
+	return pressKey(VK_MEDIA_NEXT_TRACK)
+}
+
+// PreviousTrack goes back to the previous track
+func PreviousTrack() error {
+	return pressKey(VK_MEDIA_PREV_TRACK)
+}
+
+// VolumeUp raises the system volume by one step
+func VolumeUp() error {
+	return pressKeyTimes(VK_VOLUME_UP, VolumeSteps)
+}
+
+// VolumeDown lowers the system volume by one step
+func VolumeDown() error {
+	return pressKeyTimes(VK_VOLUME_DOWN, VolumeSteps)
+}
+
+// ToggleMute mutes or unmutes the system volume
+func ToggleMute() error {
+	return pressKey(VK_VOLUME_MUTE)
+}
+
+// LockScreen locks the workstation
+func LockScreen() error {
+	ret, _, err := lockWorkStation.Call()
+	if ret == 0 {
+		return fmt.Errorf("LockWorkStation failed: %v", err)
+	}
+	return nil
+}
+
+// pressKeyTimes presses a key repeatedly
+func pressKeyTimes(vk uintptr, times int) error {
+	for i := 0; i < times; i++ {
+		err := pressKey(vk)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pressKey sends a key down and up, as if the keyboard's media key was used
+func pressKey(vk uintptr) error {
+	err := keybdEvent.Find()
+	if err != nil {
+		return fmt.Errorf("keyboard input unavailable: %v", err)
+	}
+	keybdEvent.Call(vk, 0, KEYEVENTF_EXTENDEDKEY, 0)
+	keybdEvent.Call(vk, 0, KEYEVENTF_EXTENDEDKEY|KEYEVENTF_KEYUP, 0)
+	return nil
+}
@@ -0,0 +1,96 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"voice-assistant/internal/oauth"
+)
+
+const graphTodoURL = "https://graph.microsoft.com/v1.0/me/todo/lists"
+
+// tokenCredential is the keychain entry holding the Microsoft sign-in
+const tokenCredential = "todo_token"
+
+// MicrosoftToDo is the Microsoft Graph To Do API
+type MicrosoftToDo struct {
+	session *oauth.Session
+	list    string
+
+	listID    string // Resolved from the list name on first use
+	listMutex sync.Mutex
+}
+
+// NewMicrosoftToDo creates a To Do provider; an empty list uses the
+// default "Tasks" list
+func NewMicrosoftToDo(clientID, tenant, list string) *MicrosoftToDo {
+	authority := "https://login.microsoftonline.com/" + tenant + "/oauth2/v2.0"
+	flow := &oauth.DeviceFlow{
+		DeviceCodeURL: authority + "/devicecode",
+		TokenURL:      authority + "/token",
+		ClientID:      clientID,
+		Scopes:        []string{"offline_access", "Tasks.ReadWrite"},
+	}
+	return &MicrosoftToDo{session: oauth.NewSession(flow, tokenCredential), list: list}
+}
+
+// Name identifies the provider
+func (m *MicrosoftToDo) Name() string {
+	return "Microsoft To Do"
+}
+
+// Session returns the user's sign-in
+func (m *MicrosoftToDo) Session() *oauth.Session {
+	return m.session
+}
+
+// Add creates a task in the configured list
+func (m *MicrosoftToDo) Add(ctx context.Context, task Task) error {
+	accessToken, err := m.session.AccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	listID, err := m.resolveList(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	body := map[string]interface{}{"title": task.Content}
+	return callAPI(ctx, "POST", graphTodoURL+"/"+url.PathEscape(listID)+"/tasks", accessToken, body, nil)
+}
+
+// resolveList finds the ID of the configured list, or the default list
+func (m *MicrosoftToDo) resolveList(ctx context.Context, accessToken string) (string, error) {
+	m.listMutex.Lock()
+	defer m.listMutex.Unlock()
+	if m.listID != "" {
+		return m.listID, nil
+	}
+
+	var response struct {
+		Value []struct {
+			ID                string `json:"id"`
+			DisplayName       string `json:"displayName"`
+			WellknownListName string `json:"wellknownListName"`
+		} `json:"value"`
+	}
+	err := callAPI(ctx, "GET", graphTodoURL, accessToken, nil, &response)
+	if err != nil {
+		return "", err
+	}
+
+	for _, list := range response.Value {
+		if (m.list == "" && list.WellknownListName == "defaultList") ||
+			(m.list != "" && strings.EqualFold(list.DisplayName, m.list)) {
+			m.listID = list.ID
+			return m.listID, nil
+		}
+	}
+	if m.list == "" {
+		return "", &APIError{Status: "no default list", Detail: "Microsoft To Do has no default list"}
+	}
+	return "", &APIError{Status: "unknown list", Detail: fmt.Sprintf("no To Do list named %q", m.list)}
+}
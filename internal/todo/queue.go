@@ -0,0 +1,108 @@
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Queue holds tasks that couldn't be sent, saved to a file so they survive
+// a restart
+type Queue struct {
+	path  string
+	tasks []Task
+	mutex sync.Mutex
+}
+
+// NewQueue loads the queue saved at path, if any
+func NewQueue(path string) *Queue {
+	q := &Queue{path: path}
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		json.Unmarshal(data, &q.tasks)
+	}
+	return q
+}
+
+// Len returns the number of queued tasks
+func (q *Queue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.tasks)
+}
+
+// Add queues a task
+func (q *Queue) Add(task Task) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.tasks = append(q.tasks, task)
+	return q.save()
+}
+
+// Flush sends queued tasks in order, stopping at the first one that still
+// can't be sent. Tasks the service rejects are dropped. It returns how
+// many tasks were sent.
+func (q *Queue) Flush(ctx context.Context, provider Provider) (int, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	sent := 0
+	var failure error
+	for len(q.tasks) > 0 {
+		err := provider.Add(ctx, q.tasks[0])
+		if IsOffline(err) {
+			failure = err
+			break
+		}
+		if err != nil {
+			failure = fmt.Errorf("dropped %q: %v", q.tasks[0].Content, err)
+		} else {
+			sent++
+		}
+		q.tasks = q.tasks[1:]
+	}
+
+	err := q.save()
+	if err != nil {
+		return sent, err
+	}
+	return sent, failure
+}
+
+// Clear drops every queued task and returns how many there were
+func (q *Queue) Clear() (int, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	count := len(q.tasks)
+	q.tasks = nil
+	return count, q.save()
+}
+
+// save writes the queue, removing the file once it is empty
+func (q *Queue) save() error {
+	if len(q.tasks) == 0 {
+		err := os.Remove(q.path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(q.tasks, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(q.path), 0755)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(q.path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to save todo queue: %v", err)
+	}
+	return nil
+}
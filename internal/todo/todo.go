@@ -0,0 +1,86 @@
+// Package todo adds tasks to Todoist or Microsoft To Do, queueing them on
+// disk while the network is down.
+package todo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestTimeout bounds each task API request
+const RequestTimeout = 15 * time.Second
+
+var httpClient = &http.Client{Timeout: RequestTimeout}
+
+// Task is a todo item
+type Task struct {
+	Content string    `json:"content"`
+	Added   time.Time `json:"added"`
+}
+
+// Provider is a task API
+type Provider interface {
+	Name() string
+	Add(ctx context.Context, task Task) error
+}
+
+// APIError is a request the service received and rejected; retrying it
+// later won't help, unlike a network failure
+type APIError struct {
+	Status string
+	Detail string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("task API returned %s: %s", e.Status, e.Detail)
+}
+
+// IsOffline reports whether err means the service couldn't be reached, or
+// the user hasn't signed in yet, so the task should be queued and retried
+func IsOffline(err error) bool {
+	var apiErr *APIError
+	return err != nil && !errors.As(err, &apiErr) && !errors.Is(err, context.Canceled)
+}
+
+// callAPI sends an authorized JSON request and decodes the reply into v,
+// which may be nil
+func callAPI(ctx context.Context, method, url, accessToken string, body, v interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &APIError{Status: resp.Status, Detail: string(bytes.TrimSpace(detail))}
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,36 @@
+package todo
+
+import (
+	"context"
+)
+
+const todoistTasksURL = "https://api.todoist.com/rest/v2/tasks"
+
+// Todoist is the Todoist REST API
+type Todoist struct {
+	token   string
+	project string
+	labels  []string
+}
+
+// NewTodoist creates a Todoist provider; an empty project uses the Inbox
+func NewTodoist(token, project string, labels []string) *Todoist {
+	return &Todoist{token: token, project: project, labels: labels}
+}
+
+// Name identifies the provider
+func (t *Todoist) Name() string {
+	return "Todoist"
+}
+
+// Add creates a task
+func (t *Todoist) Add(ctx context.Context, task Task) error {
+	body := map[string]interface{}{"content": task.Content}
+	if t.project != "" {
+		body["project_id"] = t.project
+	}
+	if len(t.labels) > 0 {
+		body["labels"] = t.labels
+	}
+	return callAPI(ctx, "POST", todoistTasksURL, t.token, body, nil)
+}
@@ -0,0 +1,238 @@
+package tts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"voice-assistant/internal/errs"
+	"voice-assistant/internal/keys"
+	"voice-assistant/internal/proxy"
+)
+
+// AzureSampleRate matches the raw PCM output format requested from Azure
+const AzureSampleRate = 16000
+
+// Default neural voices per recognition language
+var defaultAzureVoices = map[string]string{
+	"en-US": "en-US-JennyNeural",
+	"en-GB": "en-GB-SoniaNeural",
+	"en-AU": "en-AU-NatashaNeural",
+	"de-DE": "de-DE-KatjaNeural",
+	"fr-FR": "fr-FR-DeniseNeural",
+	"es-ES": "es-ES-ElviraNeural",
+	"es-MX": "es-MX-DaliaNeural",
+	"it-IT": "it-IT-ElsaNeural",
+	"nl-NL": "nl-NL-ColetteNeural",
+	"pt-BR": "pt-BR-FranciscaNeural",
+	"ja-JP": "ja-JP-NanamiNeural",
+	"zh-CN": "zh-CN-XiaoxiaoNeural",
+}
+
+// AzureProvider speaks with Azure neural voices over the REST API
+type AzureProvider struct {
+	keyRing    *keys.KeyRing
+	region     string
+	host       string // The public regional host unless overridden
+	language   string
+	voices     map[string]string // Per-language overrides of the defaults
+	pitch      string
+	httpClient *http.Client
+}
+
+// NewAzureProvider creates an Azure TTS provider using the speech keys
+func NewAzureProvider(subscriptionKeys []string, strategy, region, language string) *AzureProvider {
+	return &AzureProvider{
+		keyRing:  keys.NewKeyRing("Azure TTS", subscriptionKeys, strategy),
+		region:   region,
+		host:     region + ".tts.speech.microsoft.com",
+		language: language,
+		voices:   make(map[string]string),
+		httpClient: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: proxy.Transport,
+		},
+	}
+}
+
+// SetVoices overrides the default voice for some languages
+func (p *AzureProvider) SetVoices(voices map[string]string) {
+	for language, voice := range voices {
+		p.voices[language] = voice
+	}
+}
+
+// SetHost requests speech from host instead of the public regional
+// endpoint, for sovereign clouds and private endpoints
+func (p *AzureProvider) SetHost(host string) {
+	if host != "" {
+		p.host = host
+	}
+}
+
+// SetLanguage sets the default language, used when options name none
+func (p *AzureProvider) SetLanguage(language string) {
+	p.language = language
+}
+
+// SetPitch sets the SSML prosody pitch, e.g. "+5%" or "low"
+func (p *AzureProvider) SetPitch(pitch string) {
+	p.pitch = pitch
+}
+
+// Name identifies the provider in logs
+func (p *AzureProvider) Name() string {
+	return "Azure Neural TTS"
+}
+
+// SampleRate returns the rate of streamed audio
+func (p *AzureProvider) SampleRate() int {
+	return AzureSampleRate
+}
+
+// Synthesize renders text to audio
+func (p *AzureProvider) Synthesize(text string, options Options) (*Speech, error) {
+	chunks := make(chan []int16, 16)
+	var samples []int16
+	done := make(chan struct{})
+	go func() {
+		for chunk := range chunks {
+			samples = append(samples, chunk...)
+		}
+		close(done)
+	}()
+
+	err := p.Stream(text, options, chunks)
+	<-done
+	if err != nil {
+		return nil, err
+	}
+	return &Speech{Samples: samples, SampleRate: AzureSampleRate}, nil
+}
+
+// Stream sends audio to chunks as it arrives from Azure so playback can
+// start before synthesis finishes. chunks is closed when Stream returns.
+func (p *AzureProvider) Stream(text string, options Options, chunks chan<- []int16) error {
+	defer close(chunks)
+
+	resp, err := p.post(p.ssml(text, options))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Forward whole samples as they arrive
+	buf := make([]byte, 4096)
+	var pending []byte
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			pending = append(pending, buf[:n]...)
+			usable := len(pending) &^ 1
+			chunk := make([]int16, usable/2)
+			for i := range chunk {
+				chunk[i] = int16(binary.LittleEndian.Uint16(pending[i*2:]))
+			}
+			pending = pending[usable:]
+			chunks <- chunk
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read audio: %v", err)
+		}
+	}
+}
+
+// post sends SSML to Azure, moving to the next subscription key when one
+// is rejected or out of quota, and returns the streaming audio response
+func (p *AzureProvider) post(ssml string) (*http.Response, error) {
+	url := fmt.Sprintf("https://%s/cognitiveservices/v1", p.host)
+
+	var lastErr error
+	for attempt := 0; attempt < p.keyRing.Len(); attempt++ {
+		subscriptionKey := p.keyRing.Next()
+
+		req, err := http.NewRequest("POST", url, strings.NewReader(ssml))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %v", err)
+		}
+		req.Header.Set("Ocp-Apim-Subscription-Key", subscriptionKey)
+		req.Header.Set("Content-Type", "application/ssml+xml")
+		req.Header.Set("X-Microsoft-OutputFormat", "raw-16khz-16bit-mono-pcm")
+		req.Header.Set("User-Agent", "VoiceAssistant")
+
+		resp, err := p.httpClient.Do(req)
+		if err != nil {
+			p.keyRing.ReportFailure(subscriptionKey)
+			return nil, errs.FromTransport("Azure Speech", fmt.Errorf("failed to execute request: %w", err))
+		}
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		lastErr = errs.FromStatus("Azure Speech", resp.StatusCode, fmt.Errorf("Azure TTS error: %s - %s", resp.Status, string(body)))
+
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusUnauthorized, http.StatusForbidden:
+			log.Printf("🔑 Azure key %s rejected (%s)", keys.Mask(subscriptionKey), resp.Status)
+			p.keyRing.ReportRateLimited(subscriptionKey)
+		default:
+			p.keyRing.ReportFailure(subscriptionKey)
+			return nil, lastErr
+		}
+	}
+
+	return nil, lastErr
+}
+
+// Voice returns the voice used for options in their language
+func (p *AzureProvider) Voice(options Options) string {
+	if options.Voice != "" {
+		return options.Voice
+	}
+	language := p.Language(options)
+	if voice, ok := p.voices[language]; ok {
+		return voice
+	}
+	if voice, ok := defaultAzureVoices[language]; ok {
+		return voice
+	}
+	return defaultAzureVoices["en-US"]
+}
+
+// Language returns the language options are spoken in
+func (p *AzureProvider) Language(options Options) string {
+	if options.Language != "" {
+		return options.Language
+	}
+	return p.language
+}
+
+// ssml wraps text in SSML with the selected voice and prosody
+func (p *AzureProvider) ssml(text string, options Options) string {
+	rate := options.Rate
+	if rate <= 0 {
+		rate = 1
+	}
+	pitch := p.pitch
+	if pitch == "" {
+		pitch = "default"
+	}
+
+	var escaped bytes.Buffer
+	xml.EscapeText(&escaped, []byte(text))
+
+	return fmt.Sprintf(`<speak version="1.0" xmlns="http://www.w3.org/2001/10/synthesis" xml:lang="%s">`+
+		`<voice name="%s"><prosody rate="%+d%%" pitch="%s">%s</prosody></voice></speak>`,
+		p.Language(options), p.Voice(options), int((rate-1)*100), pitch, escaped.String())
+}
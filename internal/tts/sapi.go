@@ -0,0 +1,91 @@
+package tts
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"voice-assistant/internal/audio"
+)
+
+// sapiScript synthesizes stdin to a 16kHz mono WAV file using the SAPI
+// voices installed with Windows. Text is read from stdin so it never needs
+// quoting on the command line.
+const sapiScript = `
+[Console]::InputEncoding = [Text.Encoding]::UTF8
+Add-Type -AssemblyName System.Speech
+$synth = New-Object System.Speech.Synthesis.SpeechSynthesizer
+if ($env:VA_TTS_VOICE) { $synth.SelectVoice($env:VA_TTS_VOICE) }
+elseif ($env:VA_TTS_CULTURE) { $synth.SelectVoiceByHints('NotSet', 'NotSet', 0, [Globalization.CultureInfo]$env:VA_TTS_CULTURE) }
+$synth.Rate = [int]$env:VA_TTS_RATE
+$format = New-Object System.Speech.AudioFormat.SpeechAudioFormatInfo(16000, [System.Speech.AudioFormat.AudioBitsPerSample]::Sixteen, [System.Speech.AudioFormat.AudioChannel]::Mono)
+$synth.SetOutputToWaveFile($env:VA_TTS_OUT, $format)
+$synth.Speak([Console]::In.ReadToEnd())
+$synth.Dispose()
+`
+
+// SAPIProvider speaks with Windows SAPI voices through System.Speech.
+// It needs no network access or API key.
+type SAPIProvider struct{}
+
+// NewSAPIProvider creates the local Windows voice provider
+func NewSAPIProvider() *SAPIProvider {
+	return &SAPIProvider{}
+}
+
+// Name identifies the provider in logs
+func (p *SAPIProvider) Name() string {
+	return "Windows SAPI"
+}
+
+// Synthesize renders text to audio
+func (p *SAPIProvider) Synthesize(text string, options Options) (*Speech, error) {
+	file, err := os.CreateTemp("", "voice_assistant_tts_*.wav")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %v", err)
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+
+	cmd := exec.Command("powershell.exe", "-NoProfile", "-NonInteractive", "-Command", sapiScript)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Env = append(os.Environ(),
+		"VA_TTS_OUT="+filepath.Clean(path),
+		"VA_TTS_VOICE="+options.Voice,
+		"VA_TTS_CULTURE="+options.Language,
+		fmt.Sprintf("VA_TTS_RATE=%d", sapiRate(options.Rate)),
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("SAPI synthesis failed: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	samples, sampleRate, _, err := audio.ReadWAVFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read synthesized audio: %v", err)
+	}
+	return &Speech{Samples: samples, SampleRate: sampleRate}, nil
+}
+
+// sapiRate maps a relative rate to SAPI's -10..10 scale, where each step
+// is roughly 10% faster or slower
+func sapiRate(rate float64) int {
+	if rate <= 0 {
+		rate = 1
+	}
+	steps := int(math.Round((rate - 1) * 10))
+	if steps < -10 {
+		steps = -10
+	}
+	if steps > 10 {
+		steps = 10
+	}
+	return steps
+}
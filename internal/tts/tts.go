@@ -0,0 +1,58 @@
+// Package tts turns response text into audio for playback.
+package tts
+
+import (
+	"fmt"
+
+	"voice-assistant/config"
+)
+
+// Provider names accepted in config
+const (
+	ProviderLocal = "local" // Windows SAPI voices, works offline
+	ProviderAzure = "azure" // Azure neural voices, reusing the speech key and region
+)
+
+// Options controls how text is spoken
+type Options struct {
+	Voice    string  // Provider voice name; empty uses the provider default
+	Rate     float64 // Relative speaking rate, 1.0 is normal
+	Language string  // Language of the text, e.g. "es-MX"; empty uses the provider default
+}
+
+// Speech is synthesized mono 16-bit audio
+type Speech struct {
+	Samples    []int16
+	SampleRate int
+}
+
+// Provider synthesizes speech
+type Provider interface {
+	Name() string
+	Synthesize(text string, options Options) (*Speech, error)
+}
+
+// Streamer is a provider that can deliver audio while it is still being
+// synthesized. Stream closes chunks when it returns.
+type Streamer interface {
+	SampleRate() int
+	Stream(text string, options Options, chunks chan<- []int16) error
+}
+
+// NewProviderFromConfig creates the configured provider
+func NewProviderFromConfig(cfg *config.Config) (Provider, error) {
+	switch cfg.TTS.Provider {
+	case ProviderLocal, "":
+		return NewSAPIProvider(), nil
+	case ProviderAzure:
+		if !cfg.Azure.IsConfigured() {
+			return nil, fmt.Errorf("Azure TTS needs the Azure subscription key and region")
+		}
+		provider := NewAzureProvider(cfg.Azure.Keys(), cfg.Azure.KeyRotation, cfg.Azure.Region, cfg.Azure.Language)
+		provider.SetHost(cfg.Azure.TTSHostName())
+		provider.SetVoices(cfg.TTS.Voices)
+		provider.SetPitch(cfg.TTS.Pitch)
+		return provider, nil
+	}
+	return nil, fmt.Errorf("unknown TTS provider %q", cfg.TTS.Provider)
+}
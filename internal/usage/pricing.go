@@ -0,0 +1,67 @@
+package usage
+
+import (
+	"strings"
+)
+
+// Price is the cost in USD per million tokens
+type Price struct {
+	Input  float64
+	Output float64
+}
+
+// Prompt cache writes and reads are billed relative to the input price
+const (
+	CacheWriteMultiplier = 1.25
+	CacheReadMultiplier  = 0.1
+)
+
+// prices maps model ID prefixes to their list price; the longest matching
+// prefix wins so dated snapshots share their family's price
+var prices = map[string]Price{
+	"claude-opus-4":     {Input: 15, Output: 75},
+	"claude-sonnet-4":   {Input: 3, Output: 15},
+	"claude-haiku-4":    {Input: 1, Output: 5},
+	"claude-3-7-sonnet": {Input: 3, Output: 15},
+	"claude-3-5-sonnet": {Input: 3, Output: 15},
+	"claude-3-5-haiku":  {Input: 0.8, Output: 4},
+	"claude-3-opus":     {Input: 15, Output: 75},
+	"claude-3-haiku":    {Input: 0.25, Output: 1.25},
+
+	"gemini-2.5-pro":        {Input: 1.25, Output: 10},
+	"gemini-2.5-flash":      {Input: 0.3, Output: 2.5},
+	"gemini-2.5-flash-lite": {Input: 0.1, Output: 0.4},
+	"gemini-2.0-flash":      {Input: 0.1, Output: 0.4},
+
+	"mistral-large":  {Input: 2, Output: 6},
+	"mistral-medium": {Input: 0.4, Output: 2},
+	"mistral-small":  {Input: 0.1, Output: 0.3},
+}
+
+// PriceFor returns the price of a model, and false for unknown models
+func PriceFor(model string) (Price, bool) {
+	best := ""
+	for prefix := range prices {
+		if strings.HasPrefix(model, prefix) && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+	if best == "" {
+		return Price{}, false
+	}
+	return prices[best], true
+}
+
+// Cost estimates the USD cost of a request
+func Cost(model string, input, output, cacheWrite, cacheRead int) float64 {
+	price, ok := PriceFor(model)
+	if !ok {
+		return 0
+	}
+
+	const perToken = 1.0 / 1000000
+	return float64(input)*price.Input*perToken +
+		float64(output)*price.Output*perToken +
+		float64(cacheWrite)*price.Input*CacheWriteMultiplier*perToken +
+		float64(cacheRead)*price.Input*CacheReadMultiplier*perToken
+}
@@ -0,0 +1,143 @@
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Totals accumulates token counts and estimated cost
+type Totals struct {
+	Requests     int     `json:"requests"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CacheWrite   int     `json:"cache_write_tokens"`
+	CacheRead    int     `json:"cache_read_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// add folds one request into the totals
+func (t *Totals) add(input, output, cacheWrite, cacheRead int, cost float64) {
+	t.Requests++
+	t.InputTokens += input
+	t.OutputTokens += output
+	t.CacheWrite += cacheWrite
+	t.CacheRead += cacheRead
+	t.CostUSD += cost
+}
+
+// Tracker accumulates usage per session and per day and persists the
+// daily totals so they survive restarts
+type Tracker struct {
+	path    string
+	Daily   map[string]*Totals `json:"daily"` // Keyed by YYYY-MM-DD
+	Models  map[string]*Totals `json:"models"`
+	session Totals
+	mutex   sync.Mutex
+}
+
+// NewTracker loads persisted totals from path, starting empty if absent
+func NewTracker(path string) *Tracker {
+	t := &Tracker{
+		path:   path,
+		Daily:  make(map[string]*Totals),
+		Models: make(map[string]*Totals),
+	}
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(data, t)
+		if err != nil {
+			log.Printf("⚠️  Failed to parse usage file, starting fresh: %v", err)
+		}
+	}
+	if t.Daily == nil {
+		t.Daily = make(map[string]*Totals)
+	}
+	if t.Models == nil {
+		t.Models = make(map[string]*Totals)
+	}
+	return t
+}
+
+// Record adds the usage of one request and saves the totals
+func (t *Tracker) Record(model string, input, output, cacheWrite, cacheRead int) {
+	cost := Cost(model, input, output, cacheWrite, cacheRead)
+
+	t.mutex.Lock()
+	today := time.Now().Format("2006-01-02")
+	if t.Daily[today] == nil {
+		t.Daily[today] = &Totals{}
+	}
+	if t.Models[model] == nil {
+		t.Models[model] = &Totals{}
+	}
+	t.Daily[today].add(input, output, cacheWrite, cacheRead, cost)
+	t.Models[model].add(input, output, cacheWrite, cacheRead, cost)
+	t.session.add(input, output, cacheWrite, cacheRead, cost)
+	t.mutex.Unlock()
+
+	log.Printf("💰 Request used %d in / %d out tokens (~$%.4f)", input, output, cost)
+
+	err := t.save()
+	if err != nil {
+		log.Printf("⚠️  Failed to save usage: %v", err)
+	}
+}
+
+// Today returns today's totals
+func (t *Tracker) Today() Totals {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if totals := t.Daily[time.Now().Format("2006-01-02")]; totals != nil {
+		return *totals
+	}
+	return Totals{}
+}
+
+// Session returns the totals since the app started
+func (t *Tracker) Session() Totals {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.session
+}
+
+// Export writes all totals to a JSON file for expense tracking
+func (t *Tracker) Export(path string) error {
+	t.mutex.Lock()
+	export := struct {
+		ExportedAt time.Time          `json:"exported_at"`
+		Session    Totals             `json:"session"`
+		Daily      map[string]*Totals `json:"daily"`
+		Models     map[string]*Totals `json:"models"`
+	}{time.Now(), t.session, t.Daily, t.Models}
+	data, err := json.MarshalIndent(export, "", "  ")
+	t.mutex.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage: %v", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// save persists the daily and per-model totals
+func (t *Tracker) save() error {
+	t.mutex.Lock()
+	data, err := json.MarshalIndent(t, "", "  ")
+	t.mutex.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage: %v", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(t.path), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(t.path, data, 0644)
+}
@@ -0,0 +1,159 @@
+// Package vision prepares images for Claude: it decodes image files,
+// shrinks them to the size Claude works best with and re-encodes them so
+// they stay under the API's upload limit.
+package vision
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // Registers the GIF decoder
+	"image/jpeg"
+	_ "image/png" // Registers the PNG decoder
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Size defaults. Claude downscales anything with a longer edge than 1568
+// pixels itself, and rejects images over 5 MB once base64 encoded.
+const (
+	DefaultMaxEdge  = 1568
+	DefaultMaxBytes = 3750 * 1024 // 5 MB after base64's 4/3 growth
+	minEdge         = 256         // Shrinking further to fit the size cap makes images useless
+)
+
+// Qualities tried in turn until the JPEG fits the size cap
+var qualities = []int{85, 70, 55, 40}
+
+// Extensions lists the image files that can be attached
+var Extensions = []string{".png", ".jpg", ".jpeg", ".gif"}
+
+// Limits caps the size of an image sent to Claude
+type Limits struct {
+	MaxEdge  int // Longest edge in pixels; zero uses DefaultMaxEdge
+	MaxBytes int // Largest encoded size; zero uses DefaultMaxBytes
+}
+
+// Image is an image ready to attach to a message
+type Image struct {
+	MediaType string
+	Data      []byte
+	Width     int
+	Height    int
+}
+
+// IsImageFile reports whether path has an extension that can be attached
+func IsImageFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, known := range Extensions {
+		if ext == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Load reads and decodes an image file
+func Load(path string) (image.Image, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %v", filepath.Base(path), err)
+	}
+	return img, nil
+}
+
+// Prepare downscales img to the limits and encodes it as JPEG, lowering
+// the quality and then the size until it fits
+func Prepare(img image.Image, limits Limits) (Image, error) {
+	if limits.MaxEdge <= 0 {
+		limits.MaxEdge = DefaultMaxEdge
+	}
+	if limits.MaxBytes <= 0 {
+		limits.MaxBytes = DefaultMaxBytes
+	}
+
+	edge := limits.MaxEdge
+	for {
+		scaled := Resize(img, edge)
+		for _, quality := range qualities {
+			var buf bytes.Buffer
+			err := jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: quality})
+			if err != nil {
+				return Image{}, fmt.Errorf("failed to encode image: %v", err)
+			}
+			if buf.Len() <= limits.MaxBytes {
+				bounds := scaled.Bounds()
+				return Image{MediaType: "image/jpeg", Data: buf.Bytes(), Width: bounds.Dx(), Height: bounds.Dy()}, nil
+			}
+		}
+
+		if edge <= minEdge {
+			return Image{}, fmt.Errorf("image is still over %d KB at %d pixels", limits.MaxBytes/1024, edge)
+		}
+		edge = edge * 3 / 4
+		if edge < minEdge {
+			edge = minEdge
+		}
+	}
+}
+
+// Resize flattens img onto white, since JPEG has no transparency, and
+// shrinks it so its longest edge is at most maxEdge pixels by averaging
+// the source pixels that fall into each output pixel
+func Resize(img image.Image, maxEdge int) *image.RGBA {
+	bounds := img.Bounds()
+	source := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(source, source.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(source, source.Bounds(), img, bounds.Min, draw.Over)
+
+	width, height := bounds.Dx(), bounds.Dy()
+	longest := width
+	if height > longest {
+		longest = height
+	}
+	if maxEdge <= 0 || longest <= maxEdge {
+		return source
+	}
+
+	outWidth := width * maxEdge / longest
+	outHeight := height * maxEdge / longest
+	if outWidth < 1 {
+		outWidth = 1
+	}
+	if outHeight < 1 {
+		outHeight = 1
+	}
+	out := image.NewRGBA(image.Rect(0, 0, outWidth, outHeight))
+
+	for y := 0; y < outHeight; y++ {
+		y0, y1 := y*height/outHeight, (y+1)*height/outHeight
+		for x := 0; x < outWidth; x++ {
+			x0, x1 := x*width/outWidth, (x+1)*width/outWidth
+
+			var r, g, b, count int
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					i := source.PixOffset(sx, sy)
+					r += int(source.Pix[i])
+					g += int(source.Pix[i+1])
+					b += int(source.Pix[i+2])
+					count++
+				}
+			}
+
+			o := out.PixOffset(x, y)
+			out.Pix[o] = uint8(r / count)
+			out.Pix[o+1] = uint8(g / count)
+			out.Pix[o+2] = uint8(b / count)
+			out.Pix[o+3] = 0xFF
+		}
+	}
+	return out
+}
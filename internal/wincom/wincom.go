@@ -0,0 +1,130 @@
+// Package wincom is the minimal COM plumbing shared by the Windows audio
+// features: creating objects and calling their vtable methods.
+package wincom
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	COINIT_MULTITHREADED = 0x0
+	CLSCTX_ALL           = 0x17
+)
+
+// Core Audio data flows and roles
+const (
+	ERender     = 0
+	ECapture    = 1
+	EMultimedia = 1
+)
+
+// GUID is a COM GUID
+type GUID struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// NewGUID builds a GUID from its four fields
+func NewGUID(data1 uint32, data2, data3 uint16, data4 [8]byte) GUID {
+	return GUID{Data1: data1, Data2: data2, Data3: data3, Data4: data4}
+}
+
+var (
+	CLSID_MMDeviceEnumerator = NewGUID(0xBCDE0395, 0xE52F, 0x467C, [8]byte{0x8E, 0x3D, 0xC4, 0x57, 0x92, 0x91, 0x69, 0x2E})
+	IID_IMMDeviceEnumerator  = NewGUID(0xA95664D2, 0x9614, 0x4F35, [8]byte{0xA7, 0x46, 0xDE, 0x8D, 0xB6, 0x36, 0x17, 0xE6})
+)
+
+var (
+	ole32            = syscall.NewLazyDLL("ole32.dll")
+	coInitializeEx   = ole32.NewProc("CoInitializeEx")
+	coUninitialize   = ole32.NewProc("CoUninitialize")
+	coCreateInstance = ole32.NewProc("CoCreateInstance")
+	coTaskMemFree    = ole32.NewProc("CoTaskMemFree")
+)
+
+// Vtable slots (IUnknown takes slots 0-2)
+const (
+	methodQueryInterface = 0
+	methodRelease        = 2
+
+	methodGetDefaultAudioEndpoint = 4 // IMMDeviceEnumerator
+)
+
+// Object is a COM interface pointer; only its vtable is accessed
+type Object struct {
+	vtbl *[32]uintptr
+}
+
+// Call invokes a vtable method and returns its HRESULT
+func (o *Object) Call(method int, args ...uintptr) uintptr {
+	ret, _, _ := syscall.SyscallN(o.vtbl[method], append([]uintptr{uintptr(unsafe.Pointer(o))}, args...)...)
+	return ret
+}
+
+// Release drops the reference to the object
+func (o *Object) Release() {
+	o.Call(methodRelease)
+}
+
+// Query returns another interface of the same object
+func (o *Object) Query(iid *GUID) (*Object, error) {
+	var out *Object
+	hr := o.Call(methodQueryInterface, uintptr(unsafe.Pointer(iid)), uintptr(unsafe.Pointer(&out)))
+	if Failed(hr) {
+		return nil, fmt.Errorf("QueryInterface failed: 0x%08X", uint32(hr))
+	}
+	return out, nil
+}
+
+// Failed reports whether an HRESULT is an error
+func Failed(hr uintptr) bool {
+	return int32(hr) < 0
+}
+
+// Initialize prepares COM on the calling thread, which must stay locked
+// with runtime.LockOSThread until the returned function is called
+func Initialize() (func(), error) {
+	hr, _, _ := coInitializeEx.Call(0, COINIT_MULTITHREADED)
+	if Failed(hr) {
+		return nil, fmt.Errorf("CoInitializeEx failed: 0x%08X", uint32(hr))
+	}
+	return func() { coUninitialize.Call() }, nil
+}
+
+// CreateInstance creates a COM object and returns the requested interface
+func CreateInstance(clsid, iid *GUID) (*Object, error) {
+	var out *Object
+	hr, _, _ := coCreateInstance.Call(
+		uintptr(unsafe.Pointer(clsid)), 0, CLSCTX_ALL,
+		uintptr(unsafe.Pointer(iid)), uintptr(unsafe.Pointer(&out)))
+	if Failed(hr) {
+		return nil, fmt.Errorf("CoCreateInstance failed: 0x%08X", uint32(hr))
+	}
+	return out, nil
+}
+
+// DefaultAudioEndpoint returns the default IMMDevice for a data flow
+// (ERender or ECapture)
+func DefaultAudioEndpoint(flow uintptr) (*Object, error) {
+	enumerator, err := CreateInstance(&CLSID_MMDeviceEnumerator, &IID_IMMDeviceEnumerator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create device enumerator: %v", err)
+	}
+	defer enumerator.Release()
+
+	var device *Object
+	hr := enumerator.Call(methodGetDefaultAudioEndpoint, flow, EMultimedia, uintptr(unsafe.Pointer(&device)))
+	if Failed(hr) {
+		return nil, fmt.Errorf("no default audio device: 0x%08X", uint32(hr))
+	}
+	return device, nil
+}
+
+// TaskMemFree releases memory a COM method allocated for the caller
+func TaskMemFree(ptr uintptr) {
+	coTaskMemFree.Call(ptr)
+}
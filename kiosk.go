@@ -0,0 +1,75 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	kioskMode       bool
+	lastInteraction time.Time
+	interactionLock sync.Mutex
+)
+
+// applyKioskMode fixes the persona and starts the idle session reset.
+// Settings, feature toggles and quit hotkeys are locked in onReady/onCtrlQPressed.
+func applyKioskMode() {
+	log.Printf("🔒 Kiosk mode enabled - settings and quit hotkeys are locked")
+	applyKioskPrompt()
+
+	timeout := time.Duration(appConfig.Kiosk.SessionTimeoutMinutes) * time.Minute
+	if timeout > 0 {
+		go resetIdleKioskSessions(timeout)
+	}
+}
+
+// applyKioskPrompt gives the answering client the kiosk system prompt
+func applyKioskPrompt() {
+	if appConfig.Kiosk.SystemPrompt == "" {
+		return
+	}
+	if claudeClient != nil {
+		claudeClient.SetSystemPrompt(appConfig.Kiosk.SystemPrompt)
+	}
+	if llmClient != nil {
+		llmClient.SetSystemPrompt(appConfig.Kiosk.SystemPrompt)
+	}
+}
+
+// markInteraction records user activity for the kiosk idle timer
+func markInteraction() {
+	interactionLock.Lock()
+	defer interactionLock.Unlock()
+	lastInteraction = time.Now()
+}
+
+// resetIdleKioskSessions starts a fresh conversation once nobody has used
+// the assistant for timeout, so the next visitor doesn't see the last one's chat
+func resetIdleKioskSessions(timeout time.Duration) {
+	ticker := time.NewTicker(timeout / 4)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		interactionLock.Lock()
+		idle := !lastInteraction.IsZero() && time.Since(lastInteraction) > timeout
+		if idle {
+			lastInteraction = time.Time{}
+		}
+		interactionLock.Unlock()
+
+		if idle {
+			log.Printf("🔄 Kiosk session idle for %v - starting a new conversation", timeout)
+			if claudeClient != nil {
+				claudeClient.ResetConversation()
+			}
+			if llmClient != nil {
+				llmClient.ResetConversation()
+			}
+			// Nor can they copy or replay what the last one heard
+			rememberTranscript("")
+			rememberResponse("")
+			forgetSpoken()
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/speech"
+	"voice-assistant/internal/tts"
+)
+
+// Recognition languages offered in the tray, besides any configured ones
+var trayLanguages = []string{"en-US", "en-GB", "es-MX", "es-ES", "pt-BR", "fr-FR", "de-DE", "it-IT", "ja-JP", "zh-CN"}
+
+var (
+	spokenLanguage      string // Language of the user's last utterance
+	spokenLanguageMutex sync.Mutex
+)
+
+// rememberSpokenLanguage records the language the user last spoke in so
+// the reply can be voiced in it
+func rememberSpokenLanguage(language string) {
+	spokenLanguageMutex.Lock()
+	defer spokenLanguageMutex.Unlock()
+
+	if language != spokenLanguage && spokenLanguage != "" {
+		log.Printf("🌍 Language switched to %s", language)
+	}
+	spokenLanguage = language
+}
+
+// replyLanguage returns the language of the user's last utterance, or empty
+// when language detection is off and replies use the configured voice
+func replyLanguage() string {
+	if len(appConfig.Azure.CandidateLanguages()) == 0 {
+		return ""
+	}
+
+	spokenLanguageMutex.Lock()
+	defer spokenLanguageMutex.Unlock()
+	return spokenLanguage
+}
+
+// languageContext tells Claude which language to answer in when it was
+// auto-detected, so replies follow the user between languages
+func languageContext(language string) string {
+	if len(appConfig.Azure.CandidateLanguages()) == 0 || language == "" {
+		return ""
+	}
+	return fmt.Sprintf("The user is speaking %s. Reply in that language.", language)
+}
+
+// joinContext combines optional context lines for a request
+func joinContext(lines ...string) string {
+	var context string
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if context != "" {
+			context += "\n"
+		}
+		context += line
+	}
+	return context
+}
+
+// addLanguageMenu adds the "Language" submenu for switching the
+// recognition language without restarting
+func addLanguageMenu() *systray.MenuItem {
+	mLanguage := systray.AddMenuItem("Language", "Language you speak to the assistant in")
+
+	configured := append([]string{appConfig.Azure.Language}, appConfig.Azure.Languages...)
+	languages := mergeLanguages(configured, trayLanguages)
+	items := make([]*systray.MenuItem, len(languages))
+	for i, language := range languages {
+		items[i] = mLanguage.AddSubMenuItemCheckbox(language, "Recognize "+language, language == appConfig.Azure.Language)
+	}
+	for i := range items {
+		go func(index int) {
+			for range items[index].ClickedCh {
+				selectLanguage(languages[index])
+				checkOnly(items, index)
+			}
+		}(i)
+	}
+
+	if azureSpeechWebSocket == nil {
+		mLanguage.Disable()
+	}
+	return mLanguage
+}
+
+// selectLanguage switches recognition, and the Azure voice, to a language
+// and remembers it
+func selectLanguage(language string) {
+	azure := appConfig.Azure
+	azure.Language = language
+	err := appConfig.UpdateAzureConfig(azure)
+	if err != nil {
+		log.Printf("Failed to save language: %v", err)
+	}
+	applyLanguage()
+}
+
+// applyLanguage pushes the configured languages to recognition and the Azure voice
+func applyLanguage() {
+	if azureSpeechWebSocket != nil {
+		azureSpeechWebSocket.SetLanguage(appConfig.Azure.Language)
+		azureSpeechWebSocket.SetCandidateLanguages(appConfig.Azure.CandidateLanguages())
+	}
+	if provider, ok := ttsProvider.(*tts.AzureProvider); ok {
+		provider.SetLanguage(appConfig.Azure.Language)
+	}
+}
+
+// mergeLanguages lists languages once each, keeping their order
+func mergeLanguages(primary, extra []string) []string {
+	var languages []string
+	seen := make(map[string]bool)
+	for _, language := range append(primary, extra...) {
+		if language == "" || seen[language] {
+			continue
+		}
+		seen[language] = true
+		languages = append(languages, language)
+	}
+	return languages
+}
+
+// setRecognitionMode switches to the configured recognition mode, or the
+// one that suits the use case when none is configured
+func setRecognitionMode(useCase string) {
+	mode := appConfig.Azure.Mode
+	if mode == "" {
+		mode = speech.ModeFor(useCase)
+	}
+
+	err := azureSpeechWebSocket.SetMode(mode)
+	if err != nil {
+		log.Printf("⚠️  %v, using %s", err, speech.ModeFor(useCase))
+		azureSpeechWebSocket.SetMode(speech.ModeFor(useCase))
+	}
+}
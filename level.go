@@ -0,0 +1,73 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/events"
+	"voice-assistant/internal/gui"
+)
+
+// Level display tuning
+const (
+	levelInterval = 120 * time.Millisecond
+	levelBarWidth = 10
+)
+
+var (
+	// inputMeter measures whatever recognition is listening to
+	inputMeter = audio.NewLevelMeter()
+
+	levelDisplayActive bool
+	levelDisplayMutex  sync.Mutex
+)
+
+// setupLevelMeter shows the input level in the tray tooltip, and on the
+// overlay when it is on, for as long as the assistant is listening
+func setupLevelMeter() {
+	events.On(eventBus, func(e events.StateChanged) {
+		if e.To == app.Listening {
+			startLevelDisplay()
+		}
+	})
+}
+
+// startLevelDisplay refreshes the level until listening stops
+func startLevelDisplay() {
+	levelDisplayMutex.Lock()
+	defer levelDisplayMutex.Unlock()
+	if levelDisplayActive {
+		return
+	}
+	levelDisplayActive = true
+
+	go func() {
+		ticker := time.NewTicker(levelInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if stateMachine.Is(app.Listening) {
+				level := inputMeter.Level()
+				gui.SetInputLevel(audio.LevelBar(level, levelBarWidth))
+				if appConfig.Features.Overlay {
+					gui.SetOverlayLevel(level)
+				}
+				continue
+			}
+
+			// Checked again under the lock so a session starting now
+			// isn't left without a display
+			levelDisplayMutex.Lock()
+			if !stateMachine.Is(app.Listening) {
+				gui.SetInputLevel("")
+				gui.SetOverlayLevel(0)
+				levelDisplayActive = false
+				levelDisplayMutex.Unlock()
+				return
+			}
+			levelDisplayMutex.Unlock()
+		}
+	}()
+}
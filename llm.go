@@ -0,0 +1,80 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"voice-assistant/internal/llm"
+)
+
+// llmClient answers instead of Claude when another provider is chosen;
+// Claude-only features such as tools and named conversations are then off
+var llmClient *llm.Client
+
+// setupLLM creates the client for a provider other than Claude and
+// reports whether one is configured
+func setupLLM() bool {
+	vendor := appConfig.LLM.Vendor()
+	if vendor == nil {
+		return false
+	}
+	if vendor.APIKey == "" {
+		log.Printf("⚠️  %s is the chosen provider but has no api_key", appConfig.LLM.Provider)
+		return true
+	}
+
+	client, err := llm.New(appConfig.LLM.Provider, llm.Config{
+		APIKey:          vendor.APIKey,
+		Model:           vendor.Model,
+		MaxTokens:       appConfig.Claude.MaxTokens,
+		Temperature:     appConfig.Claude.Temperature,
+		ConnectTimeout:  time.Duration(appConfig.Claude.ConnectTimeoutSeconds) * time.Second,
+		ResponseTimeout: time.Duration(appConfig.Claude.ResponseTimeoutSeconds) * time.Second,
+	})
+	if err != nil {
+		log.Printf("❌ %v", err)
+		return true
+	}
+	llmClient = client
+	llmClient.SetHistoryEnabled(appConfig.Features.Memory)
+	llmClient.SetUsageCallback(recordUsage)
+	log.Printf("✅ Answers from %s (model %s)", llmClient.Name(), llmClient.Model())
+	applyPersona()
+	return true
+}
+
+// switchProvider rebuilds the answering client after the provider or its
+// key changed, e.g. with the profile. The conversation starts afresh.
+func switchProvider() {
+	llmClient = nil
+	if setupLLM() {
+		// Claude-only features check for claudeClient
+		claudeClient = nil
+	} else if claudeClient == nil && appConfig.Claude.IsConfigured() {
+		setupClaude()
+		claudeClient.SetToolRegistry(toolRegistry)
+		claudeClient.SetUsageCallback(recordUsage)
+		applyFeatures()
+	}
+	if kioskMode {
+		applyKioskPrompt()
+	}
+
+	if assistant != nil {
+		assistant.SetResponder(responder())
+	}
+	refreshModelMenu()
+}
+
+// responder returns whichever client answers questions, or nil if none
+// is configured
+func responder() Responder {
+	// A nil *claude.Client must stay a nil interface
+	if llmClient != nil {
+		return llmClient
+	}
+	if claudeClient != nil {
+		return claudeClient
+	}
+	return nil
+}
@@ -1,39 +1,141 @@
 package main
 
 import (
-	_ "fmt"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 	"voice-assistant/internal/claude"
 
-	"github.com/gen2brain/beeep"
 	"github.com/getlantern/systray"
 	"github.com/getlantern/systray/example/icon"
 
 	"voice-assistant/config"
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/events"
+	"voice-assistant/internal/gui"
 	"voice-assistant/internal/hotkey"
+	"voice-assistant/internal/network"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/proxy"
 	"voice-assistant/internal/speech"
 )
 
 var (
 	hotkeyListener       *hotkey.Listener
-	azureSpeechWebSocket *speech.AzureWebSocketSpeechService
+	networkMonitor       *network.Monitor
+	speechService        speech.Provider
+	azureSpeechWebSocket *speech.AzureWebSocketSpeechService // Set when Azure is the speech provider
 	appConfig            *config.Config
 	claudeClient         *claude.Client
-	currentStatus        = "Ready"
-	isRecording          = false
+	echoGate             *audio.EchoGate
+	notifications        *notify.Notifier
+	stateMachine         = app.NewMachine()
 )
 
+// settingFlags collects repeated -set path=value flags
+type settingFlags []string
+
+// Command-line overrides, reapplied when params.json is reloaded
+var (
+	configOverrides settingFlags // -set flags
+	profileOverride string       // -profile flag
+)
+
+// loadAppConfig reads params.json, then applies the active profile and the
+// environment and flag overrides on top
+func loadAppConfig() (*config.Config, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.ApplyProfile(profileOverride)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.ApplyOverrides(os.Environ(), configOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("invalid override: %v", err)
+	}
+	return cfg, nil
+}
+
+func (s *settingFlags) String() string {
+	return strings.Join(*s, ", ")
+}
+
+func (s *settingFlags) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
 func main() {
+	// Keep recent log output for "Report a problem"
+	log.SetOutput(io.MultiWriter(os.Stderr, logBuffer))
+
+	kioskFlag := flag.Bool("kiosk", false, "Run in locked-down kiosk/demo mode")
+	flag.StringVar(&profileOverride, "profile", "", "Use a profile from params.json for this run")
+	flag.BoolVar(&noTray, "no-tray", false, "Run as a console process without the tray icon")
+	replayFlag := flag.String("replay", "", "Recognize a WAV recording instead of the microphone, for reproducing problems")
+	flag.Var(&configOverrides, "set", "Override a setting for this run, e.g. -set claude.model=claude-sonnet-4-5 (repeatable)")
+	flag.Parse()
+
+	// Windows runs "voice-assistant notify-action <url>" when a toast button
+	// is clicked; pass it on to the running assistant and exit
+	if flag.Arg(0) == "notify-action" {
+		err := notify.Activate(flag.Arg(1))
+		if err != nil {
+			log.Printf("❌ Notification action failed: %v", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// -replay is shorthand for switching capture to the file source
+	if *replayFlag != "" {
+		configOverrides = append(configOverrides, "audio.capture_source="+config.CaptureFile, "audio.replay_file="+*replayFlag)
+	}
+
 	// Load configuration from params.json
 	var err error
-	appConfig, err = config.LoadConfig()
+	appConfig, err = loadAppConfig()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	notifications = notify.New(appConfig.Notifications)
+	applyProxy()
+
+	// Move keys left in plain text once encryption or the keychain has been turned on
+	if appConfig.ProtectsSecrets() && appConfig.HasPlaintextSecrets() {
+		err = appConfig.Save()
+		if err != nil {
+			log.Printf("⚠️  Failed to protect credentials: %v", err)
+		} else {
+			log.Printf("🔐 Credentials are no longer stored in plain text in params.json")
+		}
+	}
+
+	// "voice-assistant bench [dir]" compares providers and exits
+	if flag.Arg(0) == "bench" {
+		os.Exit(runBench(flag.Arg(1)))
+	}
+
+	// "voice-assistant doctor" checks every component and exits
+	if flag.Arg(0) == "doctor" {
+		os.Exit(runDoctor())
+	}
+
+	// "voice-assistant transcribe file.wav" writes a transcript and exits
+	if flag.Arg(0) == "transcribe" {
+		os.Exit(runTranscribe(flag.Args()[1:]))
+	}
 
 	// Display config status
 	log.Printf("📁 Config file: %s", config.GetConfigPath())
@@ -46,51 +148,63 @@ func main() {
 		log.Printf("✅ Azure Speech Services configured (Region: %s)", appConfig.Azure.Region)
 	}
 
-	// Check Claude configuration
-	if !appConfig.Claude.IsConfigured() {
+	// Check Claude configuration, unless another provider answers
+	if setupLLM() {
+		log.Printf("   Claude is not used while llm.provider is %q", appConfig.LLM.Provider)
+	} else if !appConfig.Claude.IsConfigured() {
 		log.Printf("⚠️  Claude API not configured")
 		log.Printf("   Add your api_key to: %s", config.GetConfigPath())
 	} else {
-		claudeClient = claude.NewClientFromConfig(appConfig)
+		setupClaude()
+	}
 
-		// Test connection
-		err = claudeClient.TestConnection()
-		if err != nil {
-			log.Printf("❌ Claude connection test failed: %v", err)
-		} else {
-			log.Println("✅ Claude API connection successful!")
-		}
+	kioskMode = *kioskFlag || appConfig.Kiosk.Enabled
+	if kioskMode {
+		applyKioskMode()
 	}
+	setupEvents()
+	setupTools()
+
+	setupIntents()
+	setupTTS()
+	setupDucking()
+	setupEarcons()
+	setupOverlay()
+	setupLevelMeter()
+	setupCommandRouter()
+	setupUsageTracking()
+	setupHooks()
+	setupTodo()
+	setupOffline()
+	setupBriefing()
+	setupRemoteMic()
+	setupSpeech()
+	setupAudioRetention()
+	setupHistory()
+	setupConversations()
+	setupIdleReset()
+	setupConfigReload()
+	setupPerformance()
 
 	// Set up graceful shutdown
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
-	// Initialize Azure WebSocket Speech Service
-	if appConfig.Azure.IsConfigured() {
-		azureSpeechWebSocket, err = speech.NewAzureWebSocketSpeechService(
-			appConfig.Azure.SubscriptionKey,
-			appConfig.Azure.Region,
-			appConfig.Azure.Language,
-		)
-		if err != nil {
-			log.Printf("❌ Failed to initialize Azure WebSocket Speech: %v", err)
-		} else {
-			// Set callbacks for speech recognition
-			azureSpeechWebSocket.SetCallbacks(onSpeechRecognized, onSpeechError)
+	assistant = newDefaultApp()
 
-			// Test connection
-			err = azureSpeechWebSocket.TestConnection()
-			if err != nil {
-				log.Printf("❌ Azure WebSocket connection test failed: %v", err)
-			} else {
-				log.Println("✅ Azure WebSocket Speech Service connection successful!")
-			}
-		}
-	}
+	// Test connections to the configured services
+	runHealthChecks()
+
+	// Watch for Wi-Fi switches, VPN connects, etc.
+	networkMonitor = network.NewMonitor(onNetworkChanged)
+	networkMonitor.Start()
 
 	// Initialize hotkey listener
 	hotkeyListener = hotkey.NewListener(onF12Pressed, onCtrlQPressed)
+	bindCopyHotkeys(hotkeyListener)
+	bindScreenHotkey(hotkeyListener)
+	bindCancelHotkey(hotkeyListener)
+	bindReplayHotkey(hotkeyListener)
 
 	// Start hotkey listener
 	hotkeyListener.Start()
@@ -98,159 +212,191 @@ func main() {
 	// Handle shutdown
 	go func() {
 		<-c
-		log.Println("Shutting down...")
-		if hotkeyListener != nil {
-			hotkeyListener.Stop()
-		}
-		if azureSpeechWebSocket != nil {
-			azureSpeechWebSocket.Close()
-		}
-		systray.Quit()
+		shutdown()
+		quit()
 	}()
 
 	// Start the system tray
+	if noTray {
+		runHeadless()
+		return
+	}
 	systray.Run(onReady, onExit)
 }
 
-// onCtrlQPressed handles Ctrl+Q key combination for graceful exit
-func onCtrlQPressed() {
-	err := beeep.Notify("AI Assistant", "👋 Shutting down...", "")
-	if err != nil {
-		log.Printf("Failed to show notification: %v", err)
-	}
-
-	// Give time for notification to show
-	go func() {
-		// Small delay to show the notification
-		time.Sleep(500 * time.Millisecond)
-		systray.Quit()
-	}()
+// setupClaude creates the Claude client
+func setupClaude() {
+	claudeClient = claude.NewClientFromConfig(appConfig)
+	claudeClient.SetHistoryEnabled(appConfig.Features.Memory)
+	claudeClient.SetVoiceMode(appConfig.Features.TTS)
+	checkClaudeModel()
+	applyPersona()
 }
 
-// onF12Pressed handles F12 key press events
-func onF12Pressed() {
-	log.Printf("🔑 F12 KEY PRESSED - Current recording state: %v", isRecording)
-
-	if azureSpeechWebSocket == nil {
-		log.Printf("❌ Azure WebSocket Speech not available")
-		beeep.Notify("AI Assistant", "❌ Azure Speech Services not configured", "")
+// checkClaudeModel verifies the configured model still exists and suggests
+// a replacement if it has been retired
+func checkClaudeModel() {
+	err := claudeClient.ValidateModel(appContext)
+	if err == nil {
+		log.Printf("✅ Claude model '%s' is available", appConfig.Claude.Model)
 		return
 	}
 
-	if isRecording {
-		// Stop recording
-		log.Printf("🛑 USER REQUESTED STOP")
-		updateStatus("Processing")
-		err := beeep.Notify("AI Assistant", "🔴 Stopping recognition...", "")
-		if err != nil {
-			log.Printf("Failed to show notification: %v", err)
+	var notFound *claude.ModelNotFoundError
+	if errors.As(err, &notFound) {
+		log.Printf("❌ %v", notFound)
+		log.Printf("   Update the model in: %s", config.GetConfigPath())
+		message := fmt.Sprintf("⚠️ Model '%s' no longer exists", notFound.Model)
+		if notFound.Suggestion != "" {
+			message += fmt.Sprintf("\nTry '%s'", notFound.Suggestion)
 		}
+		notifications.Notify(notify.Error, message)
+		return
+	}
 
-		err = azureSpeechWebSocket.StopContinuousRecognition()
+	log.Printf("⚠️  Could not verify Claude model: %v", err)
+}
+
+// runHealthChecks tests the connection to each configured service
+func runHealthChecks() {
+	if claudeClient != nil {
+		err := claudeClient.TestConnection(appContext)
 		if err != nil {
-			log.Printf("❌ Failed to stop recognition: %v", err)
-			updateStatus("Error")
-			beeep.Notify("AI Assistant", "❌ Failed to stop recognition", "")
+			log.Printf("❌ Claude connection test failed: %v", err)
 		} else {
-			isRecording = false
-			updateStatus("Ready")
-			log.Printf("✅ Recording stopped successfully")
+			log.Println("✅ Claude API connection successful!")
 		}
-	} else {
-		// Start recording
-		log.Printf("🎤 USER REQUESTED START")
-		updateStatus("Listening")
-		err := beeep.Notify("AI Assistant", "🎤 Streaming live... Press F12 to stop.", "")
+	}
+
+	// Skip the speech probe mid-session; the live connection is the test
+	if speechService != nil && !speechService.IsListening() {
+		err := speechService.TestConnection(appContext)
 		if err != nil {
-			log.Printf("Failed to show notification: %v", err)
+			log.Printf("❌ %s connection test failed: %v", speechService.Name(), err)
+		} else {
+			log.Printf("✅ %s connection successful!", speechService.Name())
 		}
+	}
+}
+
+// onNetworkChanged reconnects the speech session and re-runs health checks
+// when the active network interface changes
+func onNetworkChanged(description string) {
+	log.Printf("🌐 Network changed (%s) - refreshing connections", description)
 
-		err = azureSpeechWebSocket.StartContinuousRecognition()
+	if speechService != nil && speechService.IsListening() {
+		err := speechService.Reconnect(appContext)
 		if err != nil {
-			log.Printf("❌ Failed to start recognition: %v", err)
-			updateStatus("Error")
-			beeep.Notify("AI Assistant", "❌ Failed to start recognition", "")
-		} else {
-			isRecording = true
-			log.Printf("✅ Live streaming started successfully")
-			log.Printf("💡 Now speak clearly - audio is streaming to Azure in real-time!")
+			log.Printf("❌ Failed to reconnect speech session: %v", err)
+			setState(app.Error, "reconnect failed")
+			notifications.Notify(notify.Error, "❌ Lost speech connection after network change")
 		}
 	}
-	err := azureSpeechWebSocket.StartContinuousRecognition()
+
+	runHealthChecks()
+	go flushTodoQueue()
+	go flushOfflineQueue()
+}
+
+// applyProxy points Claude and Azure traffic at the configured proxy, or
+// back at the environment's
+func applyProxy() {
+	proxyURL, err := appConfig.Proxy.ProxyURL()
 	if err != nil {
-		log.Printf("❌ Failed to start recognition: %v", err)
-		updateStatus("Error")
-		beeep.Notify("AI Assistant", "❌ Failed to start recognition", "")
-	} else {
-		isRecording = true
-		log.Printf("✅ Live streaming started successfully")
-		log.Printf("💡 Now speak clearly - audio is streaming to Azure in real-time!")
+		log.Printf("⚠️  Ignoring proxy setting: %v", err)
+	}
+	proxy.Set(proxyURL)
+	if proxyURL != nil {
+		log.Printf("🌐 Using proxy %s", proxyURL.Redacted())
 	}
 }
 
-// Speech recognition callbacks
-func onSpeechRecognized(text string) {
-	log.Printf("🎉 SPEECH CALLBACK TRIGGERED")
-	log.Printf("   📝 Recognized text: '%s'", text)
-	log.Printf("   📏 Text length: %d characters", len(text))
-	updateStatus("Processing")
-
-	// Send transcription to Claude API
-	if claudeClient != nil {
-		updateStatus("Thinking")
-
-		claudeResponse, err := claudeClient.SendMessage(text)
-		if err != nil {
-			log.Printf("Claude API failed: %v", err)
-			updateStatus("Error")
-			beeep.Notify("AI Assistant", "❌ Claude API failed", "")
-		} else {
-			log.Printf("Claude response: %s", claudeResponse)
-			updateStatus("Ready")
+// onCtrlQPressed handles Ctrl+Q key combination for graceful exit
+func onCtrlQPressed() {
+	if kioskMode {
+		log.Printf("🔒 Ctrl+Q ignored in kiosk mode")
+		return
+	}
 
-			// TODO: Convert Claude's response to speech using TTS
-			log.Printf("Converting to speech...")
-		}
-	} else {
-		log.Println("Claude not configured - skipping AI processing")
-		beeep.Notify("AI Assistant", "⚠️ Claude API not configured", "")
+	err := notifications.Notify(notify.Status, "👋 Shutting down...")
+	if err != nil {
+		log.Printf("Failed to show notification: %v", err)
 	}
 
-	// Auto-stop after recognition for now
+	// Give time for notification to show
 	go func() {
-		log.Printf("⏰ Auto-stopping in 3 seconds...")
-		time.Sleep(3 * time.Second)
-		if isRecording {
-			log.Printf("🔄 Auto-stopping recognition...")
-			azureSpeechWebSocket.StopContinuousRecognition()
-			isRecording = false
-			updateStatus("Ready")
-			log.Printf("✅ Auto-stop completed")
-		}
+		// Small delay to show the notification
+		time.Sleep(500 * time.Millisecond)
+		quit()
 	}()
 }
 
+// onF12Pressed handles F12 key press events
+func onF12Pressed() {
+	assistant.ToggleRecording()
+}
+
+// Speech recognition callbacks
+func onSpeechRecognized(result speech.RecognitionResult) {
+	assistant.HandleTranscript(result)
+}
+
 func onSpeechError(err error) {
-	log.Printf("🚨 SPEECH ERROR CALLBACK TRIGGERED")
-	log.Printf("   ❌ Error details: %v", err)
-	log.Printf("   💡 Check your microphone, internet connection, and Azure credentials")
-	updateStatus("Error")
-	beeep.Notify("AI Assistant", "❌ Speech recognition error", "")
-	isRecording = false
+	assistant.HandleSpeechError(err)
 }
 
 func onReady() {
 	// Set the system tray icon and tooltip
 	systray.SetIcon(icon.Data) // Using example icon for now
-	systray.SetTitle("AI Assistant")
-	systray.SetTooltip("AI Desktop Assistant - Press F12 to start")
+	systray.SetTitle(gui.DefaultTitle)
+	systray.SetTooltip(gui.DefaultTooltip)
 
 	// Create menu items
-	mStatus := systray.AddMenuItem("Status: "+currentStatus, "Current assistant status")
+	mStatus := systray.AddMenuItem("Status: "+string(stateMachine.State()), "Current assistant status")
 	mStatus.Disable() // Make it non-clickable, just for display
+	events.On(eventBus, func(e events.StateChanged) {
+		mStatus.SetTitle("Status: " + string(e.To))
+	})
 
 	systray.AddSeparator()
 
+	mHandsFree := systray.AddMenuItemCheckbox("Hands-free mode", "Keep listening after each response until a stop word", appConfig.Conversation.HandsFree)
+	mFeatures := systray.AddMenuItem("Features", "Enable or disable individual subsystems")
+	addFeatureToggle(mFeatures, "Text-to-speech", "Speak responses aloud", &appConfig.Features.TTS)
+	addFeatureToggle(mFeatures, "Tools", "Let Claude use tools", &appConfig.Features.Tools)
+	addFeatureToggle(mFeatures, "Memory", "Keep conversation history between turns", &appConfig.Features.Memory)
+	addFeatureToggle(mFeatures, "Live captions overlay", "Show what you say and the answer on screen", &appConfig.Features.Overlay)
+	addFeatureToggle(mFeatures, "Extended thinking", "Let Claude think before answering - slower, better on hard questions", &appConfig.Features.Thinking)
+	// Planned subsystems show greyed out; there are no settings behind them yet
+	mFeatures.AddSubMenuItem("Wake word (not available yet)", "Listening for a wake word isn't built yet").Disable()
+	mFeatures.AddSubMenuItem("Local API (not available yet)", "The local control API isn't built yet").Disable()
+
+	mModel := addModelMenu()
+	mPersona := addPersonaMenu()
+	mConversations := addConversationMenu()
+	mProfile := addProfileMenu()
+	mDevice, mVolume := addOutputMenu()
+	mLanguage := addLanguageMenu()
+	mCapture := addCaptureMenu()
+	mPair := addPairMenu()
+	mCalendar := addCalendarMenu()
+	mTodo := addTodoMenu()
+	mBriefing := addBriefingMenu()
+	mCommands = systray.AddMenuItem("Commands", "Custom voice commands")
+	updateCommandsMenu()
+	mCopyTranscript, mCopyResponse := addCopyMenu()
+	mReplay, mReplaySlower := addReplayMenu()
+	mTranscribe := addTranscribeMenu()
+	mMeeting := addMeetingMenu()
+	mSessions := addSessionsMenu()
+	mHistory := addHistoryMenu()
+	mDeleteData := addDeleteDataMenu()
+	mHandoff := systray.AddMenuItem("Continue in browser", "Open this conversation in a web chat")
+	mUsage := addUsageMenu()
+	mKeyUsage := systray.AddMenuItem("Key usage", "Show per-key API usage")
+	mPerformance := addPerformanceMenu()
+	mSupport := addSupportMenu()
+
 	mSettings := systray.AddMenuItem("Settings", "Configure the assistant")
 	mAbout := systray.AddMenuItem("About", "About AI Assistant")
 
@@ -259,23 +405,56 @@ func onReady() {
 	mQuit := systray.AddMenuItem("Quit", "Quit the assistant")
 
 	// Show startup notification
-	err := beeep.Notify("AI Assistant", "Assistant is ready!\nF12: Start/Stop recording\nCtrl+Q: Exit", "")
+	err := notifications.Notify(notify.Info, "Assistant is ready!\nF12: Start/Stop recording\nCtrl+Alt+X: Cancel\nCtrl+Alt+P: Replay answer\nCtrl+Alt+C/R: Copy transcript/response\nCtrl+Q: Exit")
 	if err != nil {
 		log.Printf("Failed to show notification: %v", err)
 	}
 
+	// Lock down shared demo machines: only status and About stay, since
+	// everything else changes settings or shows earlier visitors' data
+	if kioskMode {
+		for _, item := range []*systray.MenuItem{
+			mHandsFree, mFeatures, mModel, mPersona, mConversations, mProfile,
+			mDevice, mVolume, mLanguage, mCapture, mPair,
+			mCalendar, mTodo, mBriefing, mCommands,
+			mCopyTranscript, mCopyResponse, mReplay, mReplaySlower,
+			mTranscribe, mMeeting, mSessions, mHistory, mDeleteData, mHandoff,
+			mUsage, mKeyUsage, mPerformance, mSupport,
+			mSettings, mQuit,
+		} {
+			item.Hide()
+		}
+	}
+
 	// Handle menu clicks
 	go func() {
 		for {
 			select {
 			case <-mSettings.ClickedCh:
-				err := beeep.Notify("Settings", "Settings panel would open here", "")
+				err := notifications.Show(notify.Info, "Settings", "Settings panel would open here")
 				if err != nil {
 					log.Printf("Failed to show notification: %v", err)
 				}
 
+			case <-mHandsFree.ClickedCh:
+				if mHandsFree.Checked() {
+					mHandsFree.Uncheck()
+				} else {
+					mHandsFree.Check()
+				}
+				toggleHandsFree(mHandsFree.Checked())
+
+			case <-mCommands.ClickedCh:
+				showCommandProblems()
+
+			case <-mHandoff.ClickedCh:
+				handoffConversation()
+
+			case <-mKeyUsage.ClickedCh:
+				showKeyUsage()
+
 			case <-mAbout.ClickedCh:
-				err := beeep.Notify("About", "AI Desktop Assistant v1.0\nBuilt with Go + Azure WebSocket Speech", "")
+				err := notifications.Show(notify.Info, "About", "AI Desktop Assistant v"+appVersion+"\nBuilt with Go + Azure WebSocket Speech")
 				if err != nil {
 					log.Printf("Failed to show notification: %v", err)
 				}
@@ -288,14 +467,77 @@ func onReady() {
 	}()
 }
 
+// showKeyUsage logs and displays per-key usage for each provider
+func showKeyUsage() {
+	var summaries []string
+	if azureSpeechWebSocket != nil {
+		summaries = append(summaries, azureSpeechWebSocket.KeyUsage())
+	}
+	if claudeClient != nil {
+		summaries = append(summaries, claudeClient.KeyUsage())
+	}
+	if len(summaries) == 0 {
+		summaries = append(summaries, "No providers configured")
+	}
+
+	summary := strings.Join(summaries, "\n")
+	log.Printf("🔑 Key usage:\n%s", summary)
+	err := notifications.Show(notify.Info, "Key usage", summary)
+	if err != nil {
+		log.Printf("Failed to show notification: %v", err)
+	}
+}
+
+// addFeatureToggle adds a checkbox under parent bound to a feature flag,
+// persisting the new state to params.json on every click
+func addFeatureToggle(parent *systray.MenuItem, title, tooltip string, enabled *bool) {
+	item := parent.AddSubMenuItemCheckbox(title, tooltip, *enabled)
+
+	go func() {
+		for range item.ClickedCh {
+			*enabled = !*enabled
+			if *enabled {
+				item.Check()
+			} else {
+				item.Uncheck()
+			}
+			log.Printf("Feature '%s' enabled: %v", title, *enabled)
+
+			applyFeatures()
+			err := appConfig.UpdateFeaturesConfig(appConfig.Features)
+			if err != nil {
+				log.Printf("Failed to save feature settings: %v", err)
+			}
+		}
+	}()
+}
+
+// applyFeatures pushes the current feature toggles to the running subsystems
+func applyFeatures() {
+	if claudeClient != nil {
+		claudeClient.SetHistoryEnabled(appConfig.Features.Memory)
+		claudeClient.SetVoiceMode(appConfig.Features.TTS)
+		claudeClient.SetToolsEnabled(appConfig.Features.Tools)
+		claudeClient.SetThinking(appConfig.Features.Thinking)
+	}
+	if llmClient != nil {
+		llmClient.SetHistoryEnabled(appConfig.Features.Memory)
+	}
+	if !appConfig.Features.Overlay {
+		gui.HideOverlay()
+	}
+}
+
 func onExit() {
-	// Cleanup when the application exits
+	// Ctrl+Q and the tray's Quit end up here
 	log.Println("AI Assistant shutting down...")
+	shutdown()
 }
 
-// Helper function to update status
-func updateStatus(status string) {
-	currentStatus = status
-	log.Printf("Status: %s", status)
-	// TODO: Update the menu item text dynamically
+// setState moves the assistant to a new state, logging rejected transitions
+func setState(state app.State, reason string) {
+	err := stateMachine.Transition(state, reason)
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+	}
 }
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/speech"
+)
+
+// Speaker labels used when both sides of a call are transcribed
+const (
+	speakerMe   = "Me"
+	speakerThem = "Them"
+)
+
+// Meeting transcription state
+var (
+	meetingActive     bool
+	meetingTranscript *os.File
+	meetingOthers     speech.Provider // Recognizes system audio in dual-source meetings
+	meetingMutex      sync.Mutex
+)
+
+// addMeetingMenu adds the "Meeting transcription" tray toggle
+func addMeetingMenu() *systray.MenuItem {
+	mMeeting := systray.AddMenuItemCheckbox("Meeting transcription", "Transcribe everything said until stopped", false)
+	if speechService == nil {
+		mMeeting.Disable()
+	}
+
+	go func() {
+		for range mMeeting.ClickedCh {
+			if isMeetingActive() {
+				stopMeeting()
+				mMeeting.Uncheck()
+				continue
+			}
+			err := startMeeting()
+			if err != nil {
+				log.Printf("❌ Failed to start meeting transcription: %v", err)
+				continue
+			}
+			mMeeting.Check()
+		}
+	}()
+	return mMeeting
+}
+
+// isMeetingActive reports whether a meeting is being transcribed
+func isMeetingActive() bool {
+	meetingMutex.Lock()
+	defer meetingMutex.Unlock()
+	return meetingActive
+}
+
+// startMeeting opens a transcript file and listens without a time limit,
+// writing every recognized phrase instead of sending it to Claude
+func startMeeting() error {
+	if !stateMachine.Is(app.Idle, app.Error) {
+		return fmt.Errorf("the assistant is busy")
+	}
+
+	dualSource := appConfig.Meeting.DualSource
+	if dualSource && appConfig.Audio.UsesLoopback() {
+		return fmt.Errorf("dual-source meetings need \"Listen to\" set to the microphone")
+	}
+
+	err := beginRecordingSession("meeting transcription", dualSource || appConfig.Audio.UsesLoopback())
+	if err != nil {
+		return err
+	}
+
+	dir := appConfig.Meeting.TranscriptDir()
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		endRecordingSession()
+		return fmt.Errorf("failed to create transcript folder: %v", err)
+	}
+
+	started := time.Now()
+	path := filepath.Join(dir, fmt.Sprintf("meeting_%s.txt", started.Format("20060102_150405")))
+	file, err := os.Create(path)
+	if err != nil {
+		endRecordingSession()
+		return fmt.Errorf("failed to create transcript: %v", err)
+	}
+	fmt.Fprintf(file, "Meeting transcript - %s\n\n", started.Format("Monday, 2 January 2006 15:04"))
+
+	meetingMutex.Lock()
+	meetingActive = true
+	meetingTranscript = file
+	meetingMutex.Unlock()
+
+	if assistant != nil {
+		assistant.setHandsFree(false)
+	}
+	speechService.SetMaxDuration(0)
+	err = speechService.StartContinuousRecognition(appContext)
+	if err != nil {
+		stopMeeting()
+		return fmt.Errorf("failed to start recognition: %v", err)
+	}
+
+	if dualSource {
+		err = startOthers()
+		if err != nil {
+			stopMeeting()
+			return fmt.Errorf("failed to start system audio recognition: %v", err)
+		}
+	}
+
+	setState(app.Listening, "meeting started")
+	log.Printf("📝 Meeting transcript: %s", path)
+	notifications.Notify(notify.Status, "🔴 Meeting transcription started")
+	return nil
+}
+
+// stopMeeting ends the session and closes the transcript
+func stopMeeting() {
+	meetingMutex.Lock()
+	file := meetingTranscript
+	others := meetingOthers
+	meetingActive = false
+	meetingTranscript = nil
+	meetingOthers = nil
+	meetingMutex.Unlock()
+
+	if others != nil {
+		others.Close()
+	}
+
+	err := speechService.StopContinuousRecognition()
+	if err != nil {
+		log.Printf("❌ Failed to stop recognition: %v", err)
+	}
+	speechService.SetMaxDuration(listenLimit())
+	endRecordingSession()
+
+	if file != nil {
+		fmt.Fprintf(file, "\nEnded %s\n", time.Now().Format("15:04"))
+		file.Close()
+		log.Printf("📝 Meeting transcript saved: %s", file.Name())
+		notifications.Notify(notify.Info, "📝 Meeting transcript saved to "+file.Name())
+	}
+	setState(app.Idle, "meeting stopped")
+}
+
+// startOthers runs a second recognizer on system audio, so the other side
+// of a call is transcribed alongside the microphone
+func startOthers() error {
+	others, err := newSpeechProvider()
+	if err != nil {
+		return err
+	}
+	if others == nil {
+		return fmt.Errorf("speech recognition is not configured")
+	}
+
+	err = others.SetCaptureSource(audio.NewLoopbackSource())
+	if err != nil {
+		others.Close()
+		return err
+	}
+	others.SetCallbacks(func(result speech.RecognitionResult) {
+		appendMeetingPhrase(speakerThem, result)
+	}, func(err error) {
+		log.Printf("❌ System audio recognition error: %v", err)
+	})
+	others.SetTurnEndCallback(func() {
+		if isMeetingActive() {
+			continueMeeting(others)
+		}
+	})
+	others.SetProfanityFilter(speech.NewProfanityFilter(appConfig.Azure.Profanity, appConfig.Azure.ProfanityWords))
+	others.SetMaxDuration(0)
+
+	err = others.StartContinuousRecognition(appContext)
+	if err != nil {
+		others.Close()
+		return err
+	}
+
+	meetingMutex.Lock()
+	meetingOthers = others
+	meetingMutex.Unlock()
+	log.Printf("🔊 Transcribing system audio as %q", speakerThem)
+	return nil
+}
+
+// meetingSpeaker labels phrases from the main recognizer; it is only
+// needed when the other side is transcribed too
+func meetingSpeaker() string {
+	meetingMutex.Lock()
+	defer meetingMutex.Unlock()
+	if meetingOthers == nil {
+		return ""
+	}
+	return speakerMe
+}
+
+// appendMeetingPhrase writes a recognized phrase to the transcript with
+// the time it was heard and, when known, who said it
+func appendMeetingPhrase(speaker string, result speech.RecognitionResult) {
+	meetingMutex.Lock()
+	defer meetingMutex.Unlock()
+
+	if meetingTranscript == nil {
+		return
+	}
+	line := result.Text
+	if speaker != "" {
+		line = speaker + ": " + line
+	}
+	_, err := fmt.Fprintf(meetingTranscript, "[%s] %s\n", time.Now().Format("15:04:05"), line)
+	if err != nil {
+		log.Printf("⚠️  Failed to write to meeting transcript: %v", err)
+	}
+}
+
+// continueMeeting starts a new turn when a recognizer ends one, so a
+// meeting keeps being transcribed across pauses
+func continueMeeting(provider speech.Provider) {
+	log.Printf("🔁 Meeting: starting a new turn")
+	if continuer, ok := provider.(speech.TurnContinuer); ok {
+		err := continuer.NextTurn()
+		if err == nil {
+			return
+		}
+		log.Printf("🔄 Meeting: reconnecting (%v)", err)
+	}
+
+	err := provider.StopContinuousRecognition()
+	if err != nil {
+		log.Printf("❌ Failed to stop recognition: %v", err)
+	}
+	err = provider.StartContinuousRecognition(appContext)
+	if err != nil {
+		log.Printf("❌ Failed to restart meeting recognition: %v", err)
+		notifications.Notify(notify.Error, "❌ Meeting transcription stopped unexpectedly")
+		go stopMeeting()
+	}
+}
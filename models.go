@@ -0,0 +1,123 @@
+package main
+
+import (
+	"log"
+	"sync"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/notify"
+)
+
+// Most models the tray menu lists
+const modelTrayItems = 8
+
+var (
+	mModel     *systray.MenuItem
+	modelItems []*systray.MenuItem
+	modelNames []string // What each model menu item currently shows
+	modelMutex sync.Mutex
+)
+
+// addModelMenu adds the "Model" submenu for switching models at runtime.
+// It lists the models of whichever provider is answering.
+func addModelMenu() *systray.MenuItem {
+	mModel = systray.AddMenuItem("Model", "Choose the model that answers")
+
+	modelItems = make([]*systray.MenuItem, modelTrayItems)
+	for i := range modelItems {
+		modelItems[i] = mModel.AddSubMenuItemCheckbox("", "Use this model", false)
+		modelItems[i].Hide()
+	}
+	refreshModelMenu()
+
+	for i := range modelItems {
+		go func(index int) {
+			for range modelItems[index].ClickedCh {
+				modelMutex.Lock()
+				var model string
+				if index < len(modelNames) {
+					model = modelNames[index]
+				}
+				modelMutex.Unlock()
+				selectModel(model)
+				refreshModelMenu()
+			}
+		}(i)
+	}
+	return mModel
+}
+
+// modelChoices returns the active provider's models and the one in use
+func modelChoices() ([]string, string) {
+	if vendor := appConfig.LLM.Vendor(); vendor != nil {
+		return vendor.ModelChoices(), vendor.Model
+	}
+	return appConfig.Claude.ModelChoices(), appConfig.Claude.Model
+}
+
+// refreshModelMenu lists the active provider's models, checking the one
+// in use
+func refreshModelMenu() {
+	if mModel == nil {
+		return
+	}
+	modelMutex.Lock()
+	defer modelMutex.Unlock()
+
+	choices, current := modelChoices()
+	modelNames = modelNames[:0]
+	for i, item := range modelItems {
+		if i >= len(choices) {
+			item.Hide()
+			continue
+		}
+		modelNames = append(modelNames, choices[i])
+		item.SetTitle(choices[i])
+		item.SetTooltip("Use " + choices[i])
+		if choices[i] == current {
+			item.Check()
+		} else {
+			item.Uncheck()
+		}
+		item.Show()
+	}
+
+	if responder() == nil {
+		mModel.Disable()
+	} else {
+		mModel.Enable()
+	}
+}
+
+// selectModel switches the active provider's model and remembers the choice
+func selectModel(model string) {
+	if _, current := modelChoices(); model == "" || model == current {
+		return
+	}
+
+	var err error
+	if appConfig.LLM.Vendor() != nil {
+		if llmClient == nil {
+			return
+		}
+		llmClient.SetModel(model)
+		llmConfig := appConfig.LLM
+		llmConfig.Vendor().Model = model
+		err = appConfig.UpdateLLMConfig(llmConfig)
+	} else {
+		if claudeClient == nil {
+			return
+		}
+		claudeClient.SetModel(model)
+		claudeConfig := appConfig.Claude
+		claudeConfig.Model = model
+		err = appConfig.UpdateClaudeConfig(claudeConfig)
+	}
+	if err != nil {
+		log.Printf("Failed to save model selection: %v", err)
+	}
+
+	log.Printf("🧠 Switched model to %s", model)
+	notifications.Notify(notify.Info, "🧠 Now using "+model)
+}
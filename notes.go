@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/notify"
+)
+
+// Prompt used when notes are cleaned up before saving
+const noteCleanUpPrompt = "Clean up this dictated note: fix punctuation and capitalization and " +
+	"remove filler words, but keep the wording and meaning. Reply with the note only.\n\n"
+
+// takeNote runs the "note to self" command, appending text to the notes file
+func takeNote(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		notifications.Notify(notify.Info, "📝 Nothing to note - say \"note to self\" followed by the note")
+		return
+	}
+
+	if appConfig.Notes.CleanUp && claudeClient != nil {
+		cleaned, err := claudeClient.SendConversation(appContext, []claude.Message{claude.TextMessage("user", noteCleanUpPrompt+text)})
+		if err != nil {
+			log.Printf("⚠️  Failed to clean up note, saving it as spoken: %v", err)
+		} else {
+			text = strings.TrimSpace(cleaned)
+		}
+	}
+
+	path, err := appendNote(text, time.Now())
+	if err != nil {
+		log.Printf("❌ Failed to save note: %v", err)
+		notifications.Notify(notify.Error, "❌ Failed to save note")
+		return
+	}
+	log.Printf("📝 Note saved to %s", path)
+	notifications.Notify(notify.Info, "📝 Noted: "+text)
+}
+
+// appendNote writes a note to the file the notes config points at today
+func appendNote(text string, now time.Time) (string, error) {
+	path := expandNoteTemplate(appConfig.Notes.NotesPath(), "", now)
+	path = os.ExpandEnv(path)
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return "", fmt.Errorf("failed to create notes folder: %v", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	line := strings.TrimSpace(expandNoteTemplate(appConfig.Notes.Template, text, now))
+	_, err = file.WriteString(line + "\n")
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// expandNoteTemplate fills in {date}, {time}, {text} and {tags}
+func expandNoteTemplate(template, text string, now time.Time) string {
+	return strings.NewReplacer(
+		"{date}", now.Format("2006-01-02"),
+		"{time}", now.Format("15:04"),
+		"{text}", text,
+		"{tags}", strings.Join(appConfig.Notes.Tags, " "),
+	).Replace(template)
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/events"
+	"voice-assistant/internal/history"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/offline"
+)
+
+// offlineRetryDelay spaces out flushes on going idle while still offline;
+// a network change always flushes
+const offlineRetryDelay = time.Minute
+
+var (
+	offlineQueue    *offline.Queue
+	offlineRecorder *audio.Recorder // Recording the microphone while speech recognition can't connect
+	offlineFailed   time.Time       // When a flush last found the network still down
+	offlineMutex    sync.Mutex
+)
+
+// setupOffline loads requests left from an earlier offline spell and sends
+// them whenever the network comes back or the assistant goes idle
+func setupOffline() {
+	queue := offline.NewQueue(config.OfflineQueuePath(), appConfig.Offline.MaxItems)
+	if appConfig.Privacy.EncryptAtRest {
+		cipher, err := config.Cipher()
+		if err == nil {
+			err = queue.SetCipher(cipher)
+		}
+		if err != nil {
+			// Never fall back to writing plain text when encryption was asked for
+			log.Printf("⚠️  Offline queue unavailable, encryption failed: %v", err)
+			return
+		}
+	}
+	offlineQueue = queue
+
+	events.On(eventBus, func(e events.StateChanged) {
+		offlineMutex.Lock()
+		retry := time.Since(offlineFailed) >= offlineRetryDelay
+		offlineMutex.Unlock()
+		if e.To == app.Idle && retry && offlineQueue.Len() > 0 {
+			go flushOfflineQueue()
+		}
+	})
+	go flushOfflineQueue()
+}
+
+// deferTranscript queues a question Claude couldn't be reached for and
+// reports whether it did
+func deferTranscript(text string) bool {
+	if !appConfig.Offline.Enabled || offlineQueue == nil {
+		return false
+	}
+
+	err := offlineQueue.Add(offline.Item{Text: text, Added: time.Now()})
+	if err != nil {
+		log.Printf("❌ %v", err)
+		return false
+	}
+	log.Printf("📥 Offline - queued: %s", text)
+	notifications.Notify(notify.Info, "📥 You're offline - I'll ask once the network is back:\n"+text)
+	return true
+}
+
+// startOfflineRecording records the microphone to a file when speech
+// recognition can't connect, and reports whether it started
+func startOfflineRecording() bool {
+	if !appConfig.Offline.Enabled || !appConfig.Offline.RecordAudio || offlineQueue == nil {
+		return false
+	}
+
+	recorder := audio.NewRecorder()
+	if limit := listenLimit(); limit > 0 {
+		recorder.SetMaxDuration(limit)
+	}
+	recorder.SetCallbacks(queueRecording, func(err error) {
+		log.Printf("⚠️  Offline recording: %v", err)
+	})
+	err := recorder.StartRecording()
+	if err != nil {
+		log.Printf("❌ Failed to record offline: %v", err)
+		return false
+	}
+
+	offlineMutex.Lock()
+	offlineRecorder = recorder
+	offlineMutex.Unlock()
+	log.Printf("📴 Speech recognition unreachable - recording until the network is back")
+	notifications.Notify(notify.Status, "📴 Offline - recording. Press F12 to stop; I'll answer once the network is back.")
+	return true
+}
+
+// stopOfflineRecording stops an offline recording, reporting whether one
+// was running
+func stopOfflineRecording() bool {
+	offlineMutex.Lock()
+	recorder := offlineRecorder
+	offlineRecorder = nil
+	offlineMutex.Unlock()
+
+	if recorder == nil {
+		return false
+	}
+	err := recorder.StopRecording()
+	if err != nil {
+		log.Printf("❌ Failed to save offline recording: %v", err)
+	}
+	return true
+}
+
+// queueRecording moves a finished offline recording into the queue
+func queueRecording(path string, duration time.Duration) {
+	// The recording limit stops the recorder without the hotkey
+	offlineMutex.Lock()
+	timedOut := offlineRecorder != nil
+	offlineRecorder = nil
+	offlineMutex.Unlock()
+	if timedOut && stateMachine.Is(app.Listening) {
+		setState(app.Idle, "offline recording limit reached")
+	}
+
+	saved, err := moveFile(path, filepath.Join(config.OfflineAudioDir(), filepath.Base(path)))
+	if err == nil {
+		err = offlineQueue.Add(offline.Item{Audio: saved, Added: time.Now()})
+	}
+	if err != nil {
+		log.Printf("❌ Failed to queue offline recording: %v", err)
+		notifications.Notify(notify.Error, "❌ Failed to save the recording")
+		return
+	}
+	log.Printf("📥 Offline - queued %s recording", duration.Round(time.Second))
+	notifications.Notify(notify.Info, "📥 Recording saved - I'll transcribe and answer it once the network is back")
+}
+
+// moveFile moves a file into place, copying when it is on another drive
+func moveFile(from, to string) (string, error) {
+	err := os.MkdirAll(filepath.Dir(to), 0700)
+	if err != nil {
+		return "", err
+	}
+	if os.Rename(from, to) == nil {
+		return to, nil
+	}
+
+	in, err := os.Open(from)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+	out, err := os.Create(to)
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(out, in)
+	out.Close()
+	if err != nil {
+		os.Remove(to)
+		return "", err
+	}
+	in.Close()
+	os.Remove(from)
+	return to, nil
+}
+
+// flushOfflineQueue sends requests queued while offline, when nothing else
+// is going on
+func flushOfflineQueue() {
+	if offlineQueue == nil || offlineQueue.Len() == 0 || !stateMachine.Is(app.Idle, app.Error) {
+		return
+	}
+
+	log.Printf("📤 Sending %d request(s) made while offline", offlineQueue.Len())
+	sent, err := offlineQueue.Flush(appContext, sendDeferred)
+	if err != nil {
+		log.Printf("⚠️  Offline queue: %v", err)
+	}
+	if offline.IsOffline(err) {
+		offlineMutex.Lock()
+		offlineFailed = time.Now()
+		offlineMutex.Unlock()
+	}
+	if sent > 0 {
+		log.Printf("✅ Answered %d request(s) made while offline", sent)
+	}
+}
+
+// sendDeferred transcribes a queued recording if need be, asks Claude and
+// shows the answer, since speaking up unprompted would be a surprise
+func sendDeferred(ctx context.Context, item *offline.Item) error {
+	if item.Text == "" {
+		if speechService == nil {
+			return fmt.Errorf("speech recognition is not configured")
+		}
+		data, err := offlineQueue.ReadAudio(item)
+		if err != nil {
+			return fmt.Errorf("failed to read recording: %v", err)
+		}
+		samples, sampleRate, channels, err := audio.DecodeWAV(data, filepath.Base(item.Audio))
+		if err != nil {
+			return fmt.Errorf("failed to read recording: %v", err)
+		}
+		samples = audio.Resample(audio.ToMono(samples, channels), sampleRate, audio.SampleRate)
+		text, err := speechService.TranscribePCM(ctx, samples)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("no speech in the recording")
+		}
+		item.Text = text
+	}
+
+	client := responder()
+	if client == nil {
+		return fmt.Errorf("no LLM is configured")
+	}
+	response, err := client.SendMessageWithOptions(ctx, item.Text, claude.RequestOptions{})
+	if err != nil {
+		return err
+	}
+
+	err = recordTurn(history.Turn{Transcript: item.Text, Response: response, Model: client.Model()})
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	eventBus.Publish(events.LLMResponse{Prompt: item.Text, Text: response, Model: client.Model()})
+	message := fmt.Sprintf("📤 You asked at %s: %s\n\n%s", item.Added.Format("15:04"), item.Text, response)
+	notifications.Notify(notify.Response, message, notify.Action{
+		Label: "Copy response",
+		Run:   func() { copyToClipboard("response", response) },
+	})
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/events"
+	"voice-assistant/internal/gui"
+)
+
+// overlayMaxChars keeps the end of a long transcript, which is what the
+// speaker is looking for, and the start of a long answer
+const overlayMaxChars = 160
+
+var (
+	overlayHideTimer *time.Timer
+	overlayMutex     sync.Mutex
+)
+
+// setupOverlay mirrors the turn in the on-screen bar while the overlay
+// feature is on: the live transcript while listening, then the answer
+func setupOverlay() {
+	events.On(eventBus, func(e events.StateChanged) {
+		switch e.To {
+		case app.Listening:
+			if e.From != app.Listening {
+				showOverlay("🎤 Listening…")
+			}
+		case app.Idle, app.Error:
+			hideOverlayLater()
+		}
+	})
+	events.On(eventBus, func(e events.TranscriptPartial) {
+		showOverlay("🎤 " + overlayTail(e.Text))
+	})
+	events.On(eventBus, func(e events.TranscriptFinal) {
+		if !isMeetingActive() {
+			showOverlay("💬 " + overlayTail(e.Text))
+		}
+	})
+	events.On(eventBus, func(e events.LLMPartial) {
+		if appConfig.Overlay.ShowResponse {
+			showOverlay("🤖 " + overlayHead(speakableResponse(e.Text)))
+		}
+	})
+	events.On(eventBus, func(e events.LLMResponse) {
+		if appConfig.Overlay.ShowResponse {
+			showOverlay("🤖 " + overlayHead(speakableResponse(e.Text)))
+		}
+	})
+}
+
+// showOverlay updates the bar and cancels any pending hide
+func showOverlay(text string) {
+	if !appConfig.Features.Overlay {
+		return
+	}
+
+	overlayMutex.Lock()
+	if overlayHideTimer != nil {
+		overlayHideTimer.Stop()
+		overlayHideTimer = nil
+	}
+	overlayMutex.Unlock()
+
+	gui.ShowOverlay(text, appConfig.Overlay.Position)
+}
+
+// hideOverlayLater leaves the last text up long enough to read
+func hideOverlayLater() {
+	overlayMutex.Lock()
+	defer overlayMutex.Unlock()
+
+	if overlayHideTimer != nil {
+		overlayHideTimer.Stop()
+	}
+	overlayHideTimer = time.AfterFunc(time.Duration(appConfig.Overlay.HideSeconds)*time.Second, gui.HideOverlay)
+}
+
+// overlayTail returns the last overlayMaxChars of text on one line
+func overlayTail(text string) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) > overlayMaxChars {
+		return "…" + string(runes[len(runes)-overlayMaxChars:])
+	}
+	return string(runes)
+}
+
+// overlayHead returns the first overlayMaxChars of text on one line
+func overlayHead(text string) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) > overlayMaxChars {
+		return string(runes[:overlayMaxChars]) + "…"
+	}
+	return string(runes)
+}
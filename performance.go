@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/events"
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/metrics"
+)
+
+// setupPerformance closes interactions that end without speaking and
+// starts the Prometheus endpoint when configured
+func setupPerformance() {
+	events.On(eventBus, func(e events.StateChanged) {
+		if e.To == app.Idle || e.To == app.Error {
+			metrics.EndInteraction()
+		}
+	})
+
+	addr := appConfig.Metrics.PrometheusAddr
+	if addr == "" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	go func() {
+		log.Printf("📈 Serving metrics on http://%s/metrics", addr)
+		err := http.ListenAndServe(addr, mux)
+		if err != nil {
+			log.Printf("❌ Metrics endpoint stopped: %v", err)
+		}
+	}()
+}
+
+// addPerformanceMenu adds the "Performance" tray item
+func addPerformanceMenu() *systray.MenuItem {
+	mPerformance := systray.AddMenuItem("Performance", "Latency of each stage over recent interactions")
+	go func() {
+		for range mPerformance.ClickedCh {
+			gui.Info("AI Assistant - Performance", performanceReport())
+		}
+	}()
+	return mPerformance
+}
+
+// performanceReport formats the stage latencies of recent interactions
+func performanceReport() string {
+	recent := metrics.Interactions()
+	if len(recent) == 0 {
+		return "No interactions measured yet."
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Last %d interactions (median / 95th percentile):\n\n", len(recent))
+	for _, summary := range metrics.Summarize() {
+		if summary.Count == 0 {
+			fmt.Fprintf(&b, "%s: no data\n", summary.Stage.Label)
+			continue
+		}
+		fmt.Fprintf(&b, "%s: %s / %s\n", summary.Stage.Label, formatLatency(summary.P50), formatLatency(summary.P95))
+	}
+
+	last := recent[len(recent)-1]
+	fmt.Fprintf(&b, "\nLast interaction (%s):\n", last.Started.Format("15:04:05"))
+	for _, stage := range metrics.Stages {
+		if d, ok := last.Durations[stage.Name]; ok {
+			fmt.Fprintf(&b, "%s: %s\n", stage.Label, formatLatency(d))
+		}
+	}
+	return b.String()
+}
+
+// formatLatency rounds a duration for display
+func formatLatency(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
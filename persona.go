@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/commands"
+	"voice-assistant/internal/notify"
+)
+
+// personaPhrase matches "switch to translator mode", "change to the coder persona", ...
+var personaPhrase = regexp.MustCompile(`^(?:switch|change) to (?:the )?(.+?)(?: mode| persona)?$`)
+
+var personaItems = map[string]*systray.MenuItem{}
+
+// applyPersona sets the active persona's system prompt on the Claude client
+func applyPersona() {
+	persona := appConfig.ActivePersona()
+	if llmClient != nil {
+		llmClient.SetSystemPrompt(persona.SystemPrompt)
+	} else if claudeClient != nil {
+		claudeClient.SetSystemPrompt(persona.SystemPrompt)
+	} else {
+		return
+	}
+	log.Printf("🎭 Persona: %s", persona.Name)
+}
+
+// addPersonaMenu adds the "Persona" submenu for switching presets
+func addPersonaMenu() *systray.MenuItem {
+	mPersona := systray.AddMenuItem("Persona", "Switch the assistant's persona")
+
+	for _, persona := range appConfig.Personas {
+		item := mPersona.AddSubMenuItemCheckbox(persona.Name, "Switch to "+persona.Name, strings.EqualFold(persona.Name, appConfig.Persona))
+		personaItems[persona.Name] = item
+
+		go func(name string, item *systray.MenuItem) {
+			for range item.ClickedCh {
+				err := switchPersona(name)
+				if err != nil {
+					log.Printf("❌ %v", err)
+				}
+			}
+		}(persona.Name, item)
+	}
+
+	if responder() == nil {
+		mPersona.Disable()
+	}
+	return mPersona
+}
+
+// switchPersona activates a persona and starts a fresh conversation so the
+// previous persona's answers don't bleed into the new one
+func switchPersona(name string) error {
+	if kioskMode {
+		return fmt.Errorf("personas are fixed in kiosk mode")
+	}
+	persona, ok := appConfig.FindPersona(name)
+	if !ok {
+		return fmt.Errorf("unknown persona %q", name)
+	}
+
+	err := appConfig.UpdatePersona(persona.Name)
+	if err != nil {
+		log.Printf("Failed to save persona selection: %v", err)
+	}
+	applyPersona()
+	if claudeClient != nil {
+		claudeClient.ResetConversation()
+		saveConversation()
+	}
+	if llmClient != nil {
+		llmClient.ResetConversation()
+	}
+	checkPersonaItem(persona.Name)
+
+	notifications.Notify(notify.Info, "🎭 Switched to "+persona.Name)
+	return nil
+}
+
+// checkPersonaItem ticks the named persona in the tray
+func checkPersonaItem(name string) {
+	for itemName, item := range personaItems {
+		if strings.EqualFold(itemName, name) {
+			item.Check()
+		} else {
+			item.Uncheck()
+		}
+	}
+}
+
+// switchPersonaAction handles "persona" actions from the command grammar
+func switchPersonaAction(action commands.Action, slots map[string]string) error {
+	return switchPersona(commands.Fill(action.Target, slots))
+}
+
+// handlePersonaCommand switches persona when asked by voice; it reports
+// whether the transcript was handled
+func handlePersonaCommand(text string) bool {
+	match := personaPhrase.FindStringSubmatch(commands.Normalize(text))
+	if match == nil {
+		return false
+	}
+	if _, ok := appConfig.FindPersona(match[1]); !ok {
+		return false // Not a persona, let Claude answer
+	}
+
+	err := switchPersona(match[1])
+	if err != nil {
+		log.Printf("❌ %v", err)
+		notifications.Notify(notify.Error, "❌ "+err.Error())
+	}
+	return true
+}
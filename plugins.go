@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/plugin"
+)
+
+// pluginHost runs third-party plugins; nil when plugins are disabled
+var pluginHost *plugin.Host
+
+// setupPlugins starts the plugins and registers their tools
+func setupPlugins() {
+	cfg := appConfig.Plugins
+	if !cfg.Enabled {
+		return
+	}
+	pluginHost = plugin.Load(cfg.PluginsDir(), cfg.Disabled, time.Duration(cfg.TimeoutSeconds)*time.Second)
+
+	builtIn := make(map[string]bool)
+	for _, tool := range toolRegistry.Definitions() {
+		builtIn[tool.Name] = true
+	}
+	for _, tool := range pluginHost.Tools() {
+		if builtIn[tool.Name] {
+			log.Printf("⚠️  Plugin tool %s clashes with a built-in tool and was skipped", tool.Name)
+			continue
+		}
+		tool := tool
+		registerTool(claude.Tool{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: tool.InputSchema,
+		}, func(input json.RawMessage) (string, error) {
+			return tool.Call(appContext, input)
+		})
+	}
+}
+
+// pluginIntentPhrases returns the configured intent phrases plus those the
+// plugins add
+func pluginIntentPhrases(phrases map[string][]string) map[string][]string {
+	if pluginHost == nil {
+		return phrases
+	}
+
+	merged := make(map[string][]string, len(phrases))
+	for name, list := range phrases {
+		merged[name] = list
+	}
+	for name, list := range pluginHost.Intents() {
+		merged[name] = list
+	}
+	return merged
+}
+
+// handlePluginIntent runs an intent that belongs to a plugin, reporting
+// whether it was one
+func handlePluginIntent(name, target, text string) bool {
+	if pluginHost == nil {
+		return false
+	}
+
+	speak, ok, err := pluginHost.HandleIntent(appContext, name, target, text)
+	if !ok {
+		return false
+	}
+	if err != nil {
+		log.Printf("❌ %v", err)
+		notifications.Notify(notify.Error, "❌ The plugin couldn't do that")
+		return true
+	}
+	if speak != "" {
+		rememberResponse(speak)
+		speakResponse(speak)
+	}
+	return true
+}
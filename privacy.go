@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/retention"
+)
+
+// addDeleteDataMenu adds the "Delete all my data" tray item
+func addDeleteDataMenu() *systray.MenuItem {
+	mDelete := systray.AddMenuItem("Delete all my data…", "Wipe history, recordings and transcripts")
+	go func() {
+		for range mDelete.ClickedCh {
+			deleteAllData()
+		}
+	}()
+	return mDelete
+}
+
+// deleteAllData wipes everything the assistant has stored about the user
+// after confirmation, and reports what was deleted
+func deleteAllData() {
+	if isMeetingActive() {
+		notifications.Notify(notify.Error, "⚠️ Stop the meeting transcription before deleting data")
+		return
+	}
+	if !gui.Confirm("AI Assistant - Delete all data",
+		"Delete, for every profile:\n"+
+			"• conversation history\n"+
+			"• saved conversations\n"+
+			"• questions and recordings waiting for the network\n"+
+			"• tasks waiting to be sent to your todo list\n"+
+			"• recorded audio and archived sessions\n"+
+			"• meeting transcripts\n"+
+			"• support bundles and logs\n\n"+
+			"This can't be undone.") {
+		return
+	}
+
+	log.Printf("🧹 DELETING ALL USER DATA...")
+	var deleted []string
+	report := func(what string, count int, err error) {
+		if err != nil {
+			log.Printf("❌ Failed to delete %s: %v", what, err)
+			deleted = append(deleted, fmt.Sprintf("%s: failed (%v)", what, err))
+			return
+		}
+		log.Printf("   🗑️  %s: %d", what, count)
+		deleted = append(deleted, fmt.Sprintf("%s: %d", what, count))
+	}
+
+	// Conversation in memory
+	if claudeClient != nil {
+		claudeClient.ResetConversation()
+	}
+	if llmClient != nil {
+		llmClient.ResetConversation()
+	}
+	rememberTranscript("")
+	rememberResponse("")
+	forgetSpoken()
+	deleted = append(deleted, "Current conversation: cleared")
+
+	count, err := clearConversations()
+	report("Saved conversations", count, err)
+	count, err = deleteMatching(filepath.Join(config.GetConfigDir(), "conversations*", "*.json"))
+	report("Other profiles' conversations", count, err)
+
+	// The open database is emptied; the rest, including those of other
+	// profiles or from when history was on, are removed outright
+	if historyStore != nil {
+		turns, err := historyStore.Clear()
+		report("History turns", int(turns), err)
+		refreshHistoryMenu()
+	}
+	count, err = deleteHistoryFiles()
+	report("Other history databases", count, err)
+	os.Remove(staleSearchPath)
+
+	// The recording folder is emptied too, for recordings left by a crash
+	if offlineQueue != nil {
+		count, err = offlineQueue.Clear()
+	} else {
+		count, err = deleteMatching(config.OfflineQueuePath())
+	}
+	report("Questions queued offline", count, err)
+	count, err = deleteMatching(filepath.Join(config.OfflineAudioDir(), "*"))
+	report("Leftover offline recordings", count, err)
+
+	if todoQueue != nil {
+		count, err = todoQueue.Clear()
+	} else {
+		count, err = deleteMatching(config.TodoQueuePath())
+	}
+	report("Queued tasks", count, err)
+
+	count, err = audioStore.DeleteAll()
+	report("Recorded turn audio", count, err)
+
+	// The archive is cleared even when archiving has since been turned off
+	archive := sessionArchive
+	if archive == nil {
+		archive = retention.NewArchive(filepath.Join(config.GetConfigDir(), "sessions"), retention.ArchivePolicy{})
+	}
+	count, err = archive.DeleteAll()
+	report("Archived sessions", count, err)
+
+	count, err = deleteMeetingTranscripts()
+	report("Meeting transcripts", count, err)
+
+	logBuffer.Clear()
+	count, err = deleteMatching(filepath.Join(config.GetConfigDir(), "support-*.zip"))
+	report("Support bundles", count, err)
+
+	summary := strings.Join(deleted, "\n")
+	log.Printf("✅ All user data deleted")
+	notifications.Notify(notify.Info, "🧹 All data deleted\n"+summary)
+}
+
+// deleteMeetingTranscripts removes the transcripts meeting mode wrote,
+// leaving anything else in the folder alone
+func deleteMeetingTranscripts() (int, error) {
+	return deleteMatching(filepath.Join(appConfig.Meeting.TranscriptDir(), "meeting_*.txt"))
+}
+
+// deleteHistoryFiles removes every history database but the open one,
+// along with SQLite's journal files
+func deleteHistoryFiles() (int, error) {
+	paths, err := filepath.Glob(filepath.Join(config.GetConfigDir(), "history*.db"))
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, path := range paths {
+		if historyStore != nil && path == historyPath() {
+			continue
+		}
+		for _, suffix := range []string{"-journal", "-wal", "-shm"} {
+			os.Remove(path + suffix)
+		}
+		err = os.Remove(path)
+		if err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// deleteMatching removes the files matching a glob pattern
+func deleteMatching(pattern string) (int, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, path := range paths {
+		err = os.Remove(path)
+		if err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
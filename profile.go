@@ -0,0 +1,99 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/config"
+)
+
+// profileItems are the "Profile" menu entries; the first is the base settings
+var (
+	profileItems []*systray.MenuItem
+	profileNames []string
+)
+
+// profileName is how a profile is shown, with the base settings as "Default"
+func profileName(name string) string {
+	if name == "" {
+		return "Default"
+	}
+	return name
+}
+
+// addProfileMenu adds the "Profile" submenu for switching between named
+// sets of keys, models, languages and prompts
+func addProfileMenu() *systray.MenuItem {
+	mProfile := systray.AddMenuItem("Profile", "Switch between work, home, ... settings")
+	if len(appConfig.Profiles) == 0 {
+		mProfile.Hide()
+		return mProfile
+	}
+
+	profileNames = []string{""}
+	for _, profile := range appConfig.Profiles {
+		profileNames = append(profileNames, profile.Name)
+	}
+
+	profileItems = make([]*systray.MenuItem, len(profileNames))
+	for i, name := range profileNames {
+		profileItems[i] = mProfile.AddSubMenuItemCheckbox(profileName(name), "Switch to "+profileName(name), strings.EqualFold(name, appConfig.Profile))
+	}
+	for i := range profileItems {
+		go func(index int) {
+			for range profileItems[index].ClickedCh {
+				selectProfile(profileNames[index])
+			}
+		}(i)
+	}
+	return mProfile
+}
+
+// selectProfile saves the chosen profile and reloads settings with it.
+// Choosing from the tray replaces a -profile flag for the rest of the run.
+func selectProfile(name string) {
+	if strings.EqualFold(name, appConfig.Profile) {
+		return
+	}
+
+	profileOverride = ""
+	saved, err := config.LoadConfig()
+	if err != nil {
+		log.Printf("❌ Failed to switch profile: %v", err)
+		return
+	}
+	saved.Profile = name
+	err = saved.Save()
+	if err != nil {
+		log.Printf("❌ Failed to switch profile: %v", err)
+		return
+	}
+	reloadConfig()
+}
+
+// applyProfile starts a fresh conversation and switches to the profile's
+// own history after the active profile changed
+func applyProfile() {
+	log.Printf("👤 Profile: %s", profileName(appConfig.Profile))
+
+	if claudeClient != nil {
+		claudeClient.ResetConversation()
+	}
+	if llmClient != nil {
+		llmClient.ResetConversation()
+	}
+	if historyStore != nil {
+		historyStore.Close()
+		historyStore = nil
+	}
+	setupHistory()
+	refreshHistoryMenu()
+
+	for i, name := range profileNames {
+		if strings.EqualFold(name, appConfig.Profile) {
+			checkOnly(profileItems, i)
+		}
+	}
+}
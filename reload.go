@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/notify"
+)
+
+var stopConfigWatch func()
+
+// setupConfigReload applies edits to params.json while the app is running
+func setupConfigReload() {
+	stop, err := config.Watch(reloadConfig)
+	if err != nil {
+		log.Printf("⚠️  Config changes will need a restart: %v", err)
+		return
+	}
+	stopConfigWatch = stop
+}
+
+// reloadConfig validates the edited params.json and applies what changed.
+// Saves made by the app itself reload as no changes.
+func reloadConfig() {
+	updated, err := loadAppConfig()
+	if err != nil {
+		log.Printf("❌ Ignoring config change: %v", err)
+		notifications.Notify(notify.Error, "❌ params.json has an error, changes not applied:\n"+err.Error())
+		return
+	}
+	if errs := newErrors(appConfig.ValidateAll(), updated.ValidateAll()); len(errs) > 0 {
+		log.Printf("❌ Ignoring invalid config change: %v", errs)
+		notifications.Notify(notify.Error, fmt.Sprintf("❌ params.json is invalid, changes not applied:\n%v", errs[0]))
+		return
+	}
+
+	previous := *appConfig
+	*appConfig = *updated
+
+	var applied, restart []string
+	changed := func(a, b interface{}) bool { return !reflect.DeepEqual(a, b) }
+
+	if changed(previous.Profile, updated.Profile) {
+		applyProfile()
+		applied = append(applied, "profile "+profileName(updated.Profile))
+	}
+	if changed(previous.Proxy, updated.Proxy) {
+		applyProxy()
+		applied = append(applied, "proxy")
+	}
+	if changed(previous.Claude.Keys(), updated.Claude.Keys()) && claudeClient != nil {
+		claudeClient.SetAPIKeys(updated.Claude.Keys(), updated.Claude.KeyRotation)
+		applied = append(applied, "Claude keys")
+	}
+	if changed(previous.Azure.Keys(), updated.Azure.Keys()) && azureSpeechWebSocket != nil {
+		azureSpeechWebSocket.SetSubscriptionKeys(updated.Azure.Keys(), updated.Azure.KeyRotation)
+		applied = append(applied, "Azure keys")
+	}
+	if changed(previous.Azure.Prewarm, updated.Azure.Prewarm) && azureSpeechWebSocket != nil {
+		azureSpeechWebSocket.SetPrewarm(updated.Azure.Prewarm)
+		applied = append(applied, "connection pre-warming")
+	}
+	if changed(previous.Azure.AudioFormat, updated.Azure.AudioFormat) && azureSpeechWebSocket != nil {
+		err := azureSpeechWebSocket.SetAudioFormat(updated.Azure.AudioFormat)
+		if err != nil {
+			log.Printf("⚠️  %v", err)
+		} else {
+			applied = append(applied, "audio format")
+		}
+	}
+	if changed(previous.Azure.Language, updated.Azure.Language) || changed(previous.Azure.Languages, updated.Azure.Languages) {
+		applyLanguage()
+		applied = append(applied, "language "+updated.Azure.Language)
+	}
+	if changed(previous.Claude.Model, updated.Claude.Model) && claudeClient != nil {
+		claudeClient.SetModel(updated.Claude.Model)
+		go checkClaudeModel() // A retired ID would otherwise fail mid-conversation
+		applied = append(applied, "model "+updated.Claude.Model)
+	}
+	vendor, old := updated.LLM.Vendor(), previous.LLM.Vendor()
+	if changed(previous.LLM.Provider, updated.LLM.Provider) || (vendor != nil && changed(old.APIKey, vendor.APIKey)) {
+		switchProvider()
+		applied = append(applied, "provider "+updated.LLM.Provider)
+	} else if vendor != nil && llmClient != nil && changed(old.Model, vendor.Model) {
+		llmClient.SetModel(vendor.Model)
+		applied = append(applied, "model "+vendor.Model)
+	}
+	if changed(previous.Claude.Models, updated.Claude.Models) || changed(previous.LLM, updated.LLM) || changed(previous.Claude.Model, updated.Claude.Model) {
+		refreshModelMenu()
+	}
+	if changed(previous.ActivePersona(), updated.ActivePersona()) && !kioskMode {
+		applyPersona()
+		applied = append(applied, "system prompt")
+	}
+	if changed(previous.Features, updated.Features) {
+		applyFeatures()
+		applied = append(applied, "features")
+	}
+	if changed(previous.TTS, updated.TTS) {
+		applied = append(applied, "voice")
+	}
+	if changed(previous.Audio.MaxListenSeconds, updated.Audio.MaxListenSeconds) && speechService != nil && !isMeetingActive() {
+		speechService.SetMaxDuration(listenLimit())
+		applied = append(applied, "listening limit")
+	}
+	if changed(preprocessOptions(previous.Audio), preprocessOptions(updated.Audio)) {
+		applyPreprocessing()
+		applied = append(applied, "gain control and noise gate")
+	}
+	if changed(previous.Overlay, updated.Overlay) {
+		applied = append(applied, "overlay")
+	}
+	if changed(previous.Notes, updated.Notes) {
+		applied = append(applied, "notes")
+	}
+	if changed(previous.Briefing, updated.Briefing) {
+		setupBriefing()
+		applied = append(applied, "daily briefing")
+	}
+	if changed(previous.Notifications, updated.Notifications) {
+		notifications.SetConfig(updated.Notifications)
+		applied = append(applied, "notifications")
+	}
+	if changed(previous.Earcons, updated.Earcons) {
+		setupEarcons()
+		applied = append(applied, "earcons")
+	}
+	if changed(previous.Hooks, updated.Hooks) {
+		setupHooks()
+		applied = append(applied, "hooks")
+	}
+	if changed(previous.Offline, updated.Offline) && offlineQueue != nil {
+		offlineQueue.SetMaxItems(updated.Offline.MaxItems)
+		applied = append(applied, "offline queue")
+	}
+	if changed(previous.Intents, updated.Intents) {
+		setupIntents()
+		applied = append(applied, "intent phrases")
+	}
+
+	// Clients and devices are built once at startup
+	if changed(previous.Azure.Region, updated.Azure.Region) || changed(previous.STT, updated.STT) ||
+		changed(previous.Azure.STTHostName(), updated.Azure.STTHostName()) || changed(previous.Azure.TTSHostName(), updated.Azure.TTSHostName()) ||
+		changed(previous.Azure.EndpointID, updated.Azure.EndpointID) ||
+		(changed(previous.Azure.Keys(), updated.Azure.Keys()) && azureSpeechWebSocket == nil) {
+		restart = append(restart, "speech provider")
+	}
+	if changed(previous.Claude.ConnectTimeoutSeconds, updated.Claude.ConnectTimeoutSeconds) ||
+		changed(previous.Claude.ResponseTimeoutSeconds, updated.Claude.ResponseTimeoutSeconds) {
+		restart = append(restart, "Claude timeouts")
+	}
+	if changed(previous.Claude.MaxConcurrent, updated.Claude.MaxConcurrent) ||
+		changed(previous.Claude.RequestsPerMinute, updated.Claude.RequestsPerMinute) {
+		restart = append(restart, "Claude request limits")
+	}
+	if changed(previous.Claude.ThinkingBudget, updated.Claude.ThinkingBudget) {
+		restart = append(restart, "Claude thinking budget")
+	}
+	if changed(previous.Calendar, updated.Calendar) {
+		restart = append(restart, "calendar")
+	}
+	if changed(previous.Email, updated.Email) {
+		restart = append(restart, "email")
+	}
+	if changed(previous.Plugins, updated.Plugins) {
+		restart = append(restart, "plugins")
+	}
+	if changed(previous.Shell, updated.Shell) {
+		restart = append(restart, "command tool")
+	}
+	if changed(previous.Todo, updated.Todo) {
+		restart = append(restart, "todo list")
+	}
+	if changed(devicesOnly(previous.Audio), devicesOnly(updated.Audio)) {
+		restart = append(restart, "audio devices")
+	}
+
+	if len(applied) == 0 && len(restart) == 0 {
+		return
+	}
+
+	message := "⚙️ Settings reloaded"
+	if len(applied) > 0 {
+		message += "\nApplied: " + strings.Join(applied, ", ")
+	}
+	if len(restart) > 0 {
+		message += "\nRestart to apply: " + strings.Join(restart, ", ")
+	}
+	log.Printf("%s", strings.ReplaceAll(message, "\n", " - "))
+	notifications.Notify(notify.Info, message)
+}
+
+// devicesOnly clears the audio settings that apply without a restart
+func devicesOnly(settings config.AudioConfig) config.AudioConfig {
+	settings.AutoGain, settings.AutoGainTargetDB, settings.AutoGainMaxDB = false, 0, 0
+	settings.NoiseGate, settings.NoiseGateMarginDB = false, 0
+	settings.MaxListenSeconds = 0
+	return settings
+}
+
+// newErrors returns validation errors the running config didn't already
+// have, so a service left unconfigured doesn't block every reload
+func newErrors(before, after []error) []error {
+	known := make(map[string]bool)
+	for _, err := range before {
+		known[err.Error()] = true
+	}
+
+	var errs []error
+	for _, err := range after {
+		if !known[err.Error()] {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/remote"
+)
+
+// remoteServer accepts audio from paired devices; nil unless enabled
+var remoteServer *remote.Server
+
+// setupRemoteMic starts the remote microphone server when enabled
+func setupRemoteMic() {
+	if !appConfig.Remote.Enabled {
+		return
+	}
+
+	server := remote.NewServer(appConfig.Remote.Addr, appConfig.Remote.Tokens)
+	server.SetPairedCallback(func(token, name string) {
+		err := appConfig.AddRemoteToken(token)
+		if err != nil {
+			log.Printf("Failed to save paired device: %v", err)
+		}
+		notifications.Notify(notify.Info, "📱 Paired "+name)
+	})
+
+	err := server.Start()
+	if err != nil {
+		log.Printf("⚠️  Remote microphone disabled: %v", err)
+		return
+	}
+	remoteServer = server
+}
+
+// remoteSource returns the capture source fed by paired devices
+func remoteSource() (audio.Source, error) {
+	if remoteServer == nil {
+		return nil, fmt.Errorf("the remote microphone server is not enabled")
+	}
+	return remoteServer.Source(), nil
+}
+
+// addPairMenu adds the "Pair a device" tray item
+func addPairMenu() *systray.MenuItem {
+	mPair := systray.AddMenuItem("Pair a device…", "Use a phone on this network as the microphone")
+	if remoteServer == nil {
+		mPair.Hide()
+		return mPair
+	}
+
+	go func() {
+		for range mPair.ClickedCh {
+			window := time.Duration(appConfig.Remote.PairingMinutes) * time.Minute
+			code, err := remoteServer.BeginPairing(window)
+			if err != nil {
+				log.Printf("❌ %v", err)
+				continue
+			}
+			gui.Info("AI Assistant - Pair a device", fmt.Sprintf(
+				"Enter this code in the companion app:\n\n%s\n\nServer: %s\nThe code expires in %d minutes.",
+				code, remoteServer.URL(), appConfig.Remote.PairingMinutes))
+		}
+	}()
+	return mPair
+}
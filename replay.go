@@ -0,0 +1,134 @@
+package main
+
+import (
+	"log"
+	"sync"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/hotkey"
+	"voice-assistant/internal/notify"
+)
+
+// spokenAnswer is a response as it was said, kept so it can be replayed
+// without synthesizing it again
+type spokenAnswer struct {
+	text       string
+	samples    []int16
+	sampleRate int
+}
+
+var (
+	spokenAnswers []spokenAnswer // Oldest first, at most TTS.ReplayCount
+	spokenMutex   sync.Mutex
+)
+
+// rememberSpoken keeps the audio of a response spoken at the normal rate
+func rememberSpoken(text string, samples []int16, sampleRate int) {
+	spokenMutex.Lock()
+	defer spokenMutex.Unlock()
+
+	keep := appConfig.TTS.ReplayCount
+	if keep <= 0 || len(samples) == 0 {
+		return
+	}
+	spokenAnswers = append(spokenAnswers, spokenAnswer{text: text, samples: samples, sampleRate: sampleRate})
+	if len(spokenAnswers) > keep {
+		spokenAnswers = append([]spokenAnswer(nil), spokenAnswers[len(spokenAnswers)-keep:]...)
+	}
+}
+
+// forgetSpoken drops the kept audio, e.g. when the user deletes their data
+func forgetSpoken() {
+	spokenMutex.Lock()
+	defer spokenMutex.Unlock()
+	spokenAnswers = nil
+}
+
+// teeSamples passes chunks from in to out, closing out when in closes, and
+// then delivers everything that went through
+func teeSamples(in <-chan []int16, out chan<- []int16) <-chan []int16 {
+	all := make(chan []int16, 1)
+	go func() {
+		var samples []int16
+		for chunk := range in {
+			samples = append(samples, chunk...)
+			out <- chunk
+		}
+		close(out)
+		all <- samples
+	}()
+	return all
+}
+
+// replayAnswer says a recent answer again, back answers before the last.
+// At the normal rate the kept audio is played as it was; otherwise the
+// text is synthesized again at the new rate.
+func replayAnswer(back int, rate float64) {
+	spokenMutex.Lock()
+	var answer *spokenAnswer
+	if back < len(spokenAnswers) {
+		answer = &spokenAnswers[len(spokenAnswers)-1-back]
+	}
+	spokenMutex.Unlock()
+
+	if answer == nil {
+		// Nothing kept, e.g. replay is off: fall back to the last response text
+		lastMutex.Lock()
+		response := lastResponse
+		lastMutex.Unlock()
+		if back > 0 || response == "" {
+			notifications.Notify(notify.Info, "Nothing to repeat yet")
+			return
+		}
+		speakResponseAt(claude.Speakable(response), rate)
+		return
+	}
+
+	if rate != 1.0 || audioPlayer == nil {
+		speakResponseAt(answer.text, rate)
+		return
+	}
+	log.Printf("🔁 Replaying: %s", answer.text)
+	playSpeech(func() error {
+		return audioPlayer.Play(answer.samples, answer.sampleRate)
+	})
+}
+
+// replayFromIdle replays outside a turn, from the hotkey or tray
+func replayFromIdle(rate float64) {
+	if !stateMachine.Is(app.Idle, app.Error) {
+		log.Printf("⚠️  Not replaying while the assistant is busy")
+		return
+	}
+	setState(app.Processing, "replaying answer")
+	replayAnswer(0, rate)
+	setState(app.Idle, "replay finished")
+}
+
+// bindReplayHotkey adds Ctrl+Alt+P for hearing the last answer again
+func bindReplayHotkey(listener *hotkey.Listener) {
+	listener.Bind("Ctrl+Alt+P", []int{hotkey.VK_CTRL, hotkey.VK_ALT, hotkey.VK_P}, func() {
+		go replayFromIdle(1.0)
+	})
+}
+
+// addReplayMenu adds the tray items for hearing the last answer again
+func addReplayMenu() (*systray.MenuItem, *systray.MenuItem) {
+	mReplay := systray.AddMenuItem("Replay last answer", "Say the last answer again (Ctrl+Alt+P)")
+	mSlower := systray.AddMenuItem("Replay last answer slower", "Say the last answer again, slowly")
+
+	go func() {
+		for {
+			select {
+			case <-mReplay.ClickedCh:
+				go replayFromIdle(1.0)
+			case <-mSlower.ClickedCh:
+				go replayFromIdle(slowSpeechRate)
+			}
+		}
+	}()
+	return mReplay, mSlower
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/retention"
+)
+
+var (
+	audioStore     *retention.Store
+	sessionArchive *retention.Archive // Nil unless the session archive is enabled
+
+	sessionTranscript []string // Phrases recognized in the session being recorded
+	sessionMutex      sync.Mutex
+)
+
+// setupAudioRetention keeps per-turn audio according to the retention policy
+// and archives sessions when enabled
+func setupAudioRetention() {
+	audioStore = retention.NewStore(filepath.Join(config.GetConfigDir(), "audio"), retention.Policy{
+		Mode: appConfig.Retention.Audio,
+		Days: appConfig.Retention.Days,
+	})
+	audioStore.StartJanitor()
+
+	if appConfig.Retention.ArchiveSessions {
+		sessionArchive = retention.NewArchive(filepath.Join(config.GetConfigDir(), "sessions"), retention.ArchivePolicy{
+			MaxDays: appConfig.Retention.ArchiveMaxDays,
+			MaxGB:   appConfig.Retention.ArchiveMaxGB,
+		})
+		sessionArchive.StartJanitor()
+	}
+
+	if speechService != nil && (audioStore.Enabled() || sessionArchive != nil) {
+		speechService.SetTurnAudioCallback(saveTurnAudio)
+	}
+}
+
+// saveTurnAudio stores the audio of a finished turn wherever policy allows
+func saveTurnAudio(samples []int16) {
+	err := audioStore.SaveTurn(samples)
+	if err != nil {
+		log.Printf("⚠️  Failed to save turn audio: %v", err)
+	}
+
+	if sessionArchive != nil {
+		sessionMutex.Lock()
+		transcript := strings.Join(sessionTranscript, "\n")
+		sessionTranscript = nil
+		sessionMutex.Unlock()
+
+		err = sessionArchive.SaveSession(samples, transcript)
+		if err != nil {
+			log.Printf("⚠️  Failed to archive session: %v", err)
+		}
+	}
+}
+
+// archivePhrase adds a recognized phrase to the session being recorded
+func archivePhrase(text string) {
+	if sessionArchive == nil {
+		return
+	}
+	sessionMutex.Lock()
+	defer sessionMutex.Unlock()
+	sessionTranscript = append(sessionTranscript, text)
+}
+
+// addSessionsMenu adds the tray item that opens the session archive
+func addSessionsMenu() *systray.MenuItem {
+	mSessions := systray.AddMenuItem("Open sessions folder", "Review archived session audio and transcripts")
+	if sessionArchive == nil {
+		mSessions.Hide()
+		return mSessions
+	}
+
+	go func() {
+		for range mSessions.ClickedCh {
+			err := os.MkdirAll(sessionArchive.Dir(), 0755)
+			if err == nil {
+				err = gui.Open(sessionArchive.Dir())
+			}
+			if err != nil {
+				log.Printf("❌ Failed to open sessions folder: %v", err)
+			}
+		}
+	}()
+	return mSessions
+}
+
+// deleteTodaysRecordings runs the "delete everything recorded today" command
+func deleteTodaysRecordings() {
+	deleted, err := audioStore.DeleteToday()
+	if err != nil {
+		log.Printf("❌ Failed to delete today's recordings: %v", err)
+		notifications.Notify(notify.Error, "❌ Failed to delete today's recordings")
+		return
+	}
+	notifications.Notify(notify.Info, fmt.Sprintf("🧹 Deleted %d recordings from today", deleted))
+}
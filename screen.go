@@ -0,0 +1,106 @@
+package main
+
+import (
+	"log"
+	"sync"
+
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/hotkey"
+	"voice-assistant/internal/intent"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/screen"
+)
+
+var (
+	pendingScreenshot *claude.Image // Captured by hotkey for the next question
+	screenshotMutex   sync.Mutex
+)
+
+// bindScreenHotkey adds Ctrl+Alt+S for asking about the screen
+func bindScreenHotkey(listener *hotkey.Listener) {
+	listener.Bind("Ctrl+Alt+S", []int{hotkey.VK_CTRL, hotkey.VK_ALT, hotkey.VK_S}, onScreenHotkey)
+}
+
+// onScreenHotkey captures the screen as it is now and starts listening for
+// the question about it
+func onScreenHotkey() {
+	if !appConfig.Screen.Enabled {
+		notifications.Notify(notify.Info, "Screen sharing is disabled - enable \"screen\" in params.json")
+		return
+	}
+
+	image, err := captureScreenshot()
+	if err != nil {
+		log.Printf("❌ Failed to capture screen: %v", err)
+		notifications.Notify(notify.Error, "❌ Failed to capture screen")
+		return
+	}
+
+	screenshotMutex.Lock()
+	pendingScreenshot = image
+	screenshotMutex.Unlock()
+
+	notifications.Notify(notify.Info, "🖥️ Screenshot attached - ask your question")
+	if stateMachine.Is(app.Idle) {
+		onF12Pressed()
+	}
+}
+
+// screenshotsFor returns the images to send with a question: a screenshot
+// taken by hotkey, or a fresh one when the question asks about the screen
+func screenshotsFor(text string) []claude.Image {
+	screenshotMutex.Lock()
+	image := pendingScreenshot
+	pendingScreenshot = nil
+	screenshotMutex.Unlock()
+
+	if image == nil && appConfig.Screen.Enabled {
+		match, ok := intentMatcher.Match(text)
+		if ok && match.Intent == intent.Screen {
+			var err error
+			image, err = captureScreenshot()
+			if err != nil {
+				log.Printf("❌ Failed to capture screen: %v", err)
+				notifications.Notify(notify.Error, "❌ Failed to capture screen")
+			}
+		}
+	}
+
+	if image == nil {
+		return nil
+	}
+	return []claude.Image{*image}
+}
+
+// captureScreenshot captures, downscales and encodes the configured target
+func captureScreenshot() (*claude.Image, error) {
+	img, err := screen.Capture(appConfig.Screen.Target)
+	if err != nil {
+		return nil, err
+	}
+	img = screen.Downscale(img, appConfig.Screen.MaxEdge)
+
+	data, err := screen.EncodeJPEG(img)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🖥️  Captured %dx%d screenshot (%d KB)", img.Bounds().Dx(), img.Bounds().Dy(), len(data)/1024)
+	return &claude.Image{MediaType: "image/jpeg", Data: data}, nil
+}
+
+// activeWindowContext describes the foreground window for Claude when the
+// user has opted in to sharing it
+func activeWindowContext() string {
+	if !appConfig.Privacy.ShareActiveWindow {
+		return ""
+	}
+
+	window, err := screen.ActiveWindow()
+	if err != nil {
+		log.Printf("⚠️  Failed to read active window: %v", err)
+		return ""
+	}
+	log.Printf("🪟 Active window: %s - %s", window.App, window.Title)
+	return window.Describe()
+}
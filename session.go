@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/notify"
+)
+
+// Recording session errors
+var (
+	ErrLoopbackBlocked = errors.New("system audio capture is blocked by privacy policy")
+	ErrConsentDeclined = errors.New("recording was not confirmed")
+)
+
+// beginRecordingSession enforces the privacy policy before a meeting or
+// loopback capture starts, and shows the recording indicator once allowed
+func beginRecordingSession(label string, loopback bool) error {
+	if loopback && appConfig.Privacy.BlockLoopbackCapture {
+		log.Printf("🚫 Loopback capture blocked by policy")
+		notifications.Notify(notify.Error, "🚫 System audio capture is disabled by policy")
+		return ErrLoopbackBlocked
+	}
+
+	if appConfig.Privacy.RequireRecordingConsent {
+		message := fmt.Sprintf("Start recording (%s)?\n\n"+
+			"Everyone in the conversation should know they are being recorded and transcribed.", label)
+		if loopback {
+			message += "\n\nAudio playing on this computer (calls, videos) will also be captured."
+		}
+
+		if !gui.Confirm("AI Assistant - Recording consent", message) {
+			log.Printf("🚫 Recording consent declined for %s", label)
+			return ErrConsentDeclined
+		}
+	}
+
+	log.Printf("🔴 Recording session started: %s", label)
+	gui.SetRecordingIndicator(true, label)
+	return nil
+}
+
+// endRecordingSession clears the recording indicator, unless system audio
+// is still being captured
+func endRecordingSession() {
+	log.Printf("⏹️  Recording session ended")
+	if appConfig.Audio.UsesLoopback() {
+		gui.SetRecordingIndicator(true, "system audio")
+		return
+	}
+	gui.SetRecordingIndicator(false, "")
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/shell"
+)
+
+var shellRunner *shell.Runner
+
+// setupShell offers Claude the command tool when it is enabled
+func setupShell() {
+	if !appConfig.Shell.Enabled {
+		return
+	}
+	shellRunner = shell.NewRunner(appConfig.Shell)
+
+	registerTool(claude.Tool{
+		Name: "run_command",
+		Description: "Run a command-line command on the user's computer, e.g. \"git status\". Only allow-listed " +
+			"commands run, and the user confirms each one. Summarize the output briefly for speaking " +
+			"instead of reading it verbatim. Known folders: " + strings.Join(shellRunner.Folders(), ", ") + ".",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"command": map[string]interface{}{"type": "string"},
+				"folder":  map[string]interface{}{"type": "string", "description": "Known folder name; omit for the home folder"},
+			},
+			"required": []string{"command"},
+		},
+	}, runCommandTool)
+}
+
+// runCommandTool runs a confirmed, allow-listed command
+func runCommandTool(input json.RawMessage) (string, error) {
+	var args struct {
+		Command string `json:"command"`
+		Folder  string `json:"folder"`
+	}
+	err := json.Unmarshal(input, &args)
+	if err != nil {
+		return "", fmt.Errorf("invalid input: %v", err)
+	}
+
+	output, err := shellRunner.Run(appContext, args.Command, args.Folder)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(output) == "" {
+		return "The command finished with no output", nil
+	}
+	return output, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"log"
+	"sync"
+)
+
+var shutdownOnce sync.Once
+
+// shutdown tears the assistant down exactly once, whichever of a signal,
+// Ctrl+Q or the tray's Quit comes first. Requests in flight are cancelled
+// and the inputs stopped before the sessions, playback and stores they
+// feed are closed, so nothing writes to a closed resource.
+func shutdown() {
+	shutdownOnce.Do(func() {
+		log.Println("Shutting down...")
+		stopApp()
+
+		// Inputs: nothing new starts after these
+		if hotkeyListener != nil {
+			hotkeyListener.Stop()
+		}
+		if networkMonitor != nil {
+			networkMonitor.Stop()
+		}
+		if stopConfigWatch != nil {
+			stopConfigWatch()
+		}
+		if commandRouter != nil {
+			commandRouter.Stop()
+		}
+		if remoteServer != nil {
+			remoteServer.Stop()
+		}
+		if briefingScheduler != nil {
+			briefingScheduler.Stop()
+		}
+		if pluginHost != nil {
+			pluginHost.Stop()
+		}
+		notifications.Close()
+
+		// Sessions and playback
+		if isMeetingActive() {
+			stopMeeting()
+		}
+		if audioPlayer != nil {
+			audioPlayer.Stop()
+		}
+		if earconPlayer != nil {
+			earconPlayer.Stop()
+		}
+		if speechService != nil {
+			speechService.Close()
+		}
+		if audioPlayer != nil {
+			audioPlayer.Close()
+		}
+		if earconPlayer != nil {
+			earconPlayer.Close()
+		}
+
+		// Storage
+		if audioStore != nil {
+			audioStore.Stop()
+		}
+		if sessionArchive != nil {
+			sessionArchive.Stop()
+		}
+		if historyStore != nil {
+			historyStore.Close()
+		}
+
+		// Never leave other applications turned down
+		if ducker != nil {
+			ducker.Restore()
+		}
+		log.Println("✅ Shutdown complete")
+	})
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/events"
+	"voice-assistant/internal/speech"
+)
+
+// setupSpeech creates the configured speech recognition backend and wires
+// it into the conversation loop
+func setupSpeech() {
+	provider, err := newSpeechProvider()
+	if err != nil {
+		log.Printf("❌ Failed to initialize speech recognition: %v", err)
+		return
+	}
+	if provider == nil {
+		return
+	}
+	speechService = provider
+	if service, ok := provider.(*speech.AzureWebSocketSpeechService); ok {
+		azureSpeechWebSocket = service
+		setRecognitionMode(speech.UseChat)
+		service.SetPrewarm(appConfig.Azure.Prewarm)
+	}
+	log.Printf("🎙️  Speech recognition: %s", speechService.Name())
+
+	// Set callbacks for speech recognition
+	speechService.SetCallbacks(onSpeechRecognized, onSpeechError)
+	speechService.SetTurnEndCallback(onTurnEnd)
+	speechService.SetHypothesisCallback(func(text string) {
+		eventBus.Publish(events.TranscriptPartial{Text: text})
+	})
+	speechService.SetProfanityFilter(speech.NewProfanityFilter(appConfig.Azure.Profanity, appConfig.Azure.ProfanityWords))
+	speechService.SetMaxDuration(listenLimit())
+
+	// Mute recognition while the assistant speaks through speakers
+	if appConfig.Audio.GateEcho() {
+		echoGate = audio.NewEchoGate(time.Duration(appConfig.Audio.EchoTailMs) * time.Millisecond)
+		speechService.SetEchoGate(echoGate)
+	}
+
+	// Listen to system audio instead of the microphone when configured
+	setupCaptureSource()
+
+	// Keep a short buffer of audio so the first word isn't clipped
+	preRoll := time.Duration(appConfig.Audio.PreRollMs) * time.Millisecond
+	err = speechService.EnablePreRoll(preRoll)
+	if err != nil {
+		log.Printf("⚠️  Failed to enable pre-roll buffer: %v", err)
+	}
+}
+
+// listenLimit is how long one session listens before stopping, 0 for no
+// limit. Long dictations roll over into new turns within it.
+func listenLimit() time.Duration {
+	return time.Duration(appConfig.Audio.MaxListenSeconds) * time.Second
+}
+
+// newSpeechProvider creates the backend named in config. It returns nil
+// without an error when Azure is selected but not configured.
+func newSpeechProvider() (speech.Provider, error) {
+	switch appConfig.STT.Provider {
+	case speech.ProviderAzure, "":
+		if !appConfig.Azure.IsConfigured() {
+			return nil, nil
+		}
+		service, err := speech.NewAzureWebSocketSpeechService(
+			appConfig.Azure.SubscriptionKey,
+			appConfig.Azure.Region,
+			appConfig.Azure.Language,
+		)
+		if err != nil {
+			return nil, err
+		}
+		service.SetSubscriptionKeys(appConfig.Azure.Keys(), appConfig.Azure.KeyRotation)
+		service.SetEndpoint(appConfig.Azure.STTHostName(), appConfig.Azure.EndpointID)
+		service.SetCandidateLanguages(appConfig.Azure.CandidateLanguages())
+		err = service.SetAudioFormat(appConfig.Azure.AudioFormat)
+		if err != nil {
+			log.Printf("⚠️  %v, streaming PCM", err)
+		}
+		return service, nil
+
+	case speech.ProviderWhisper:
+		whisper := appConfig.STT.Whisper
+		server := speech.WhisperServer{
+			Executable: whisper.ServerPath,
+			Model:      whisper.Model(),
+			Port:       whisper.Port,
+		}
+		return speech.NewWhisperService(whisper.ServerURL, server, appConfig.Azure.Language)
+
+	case speech.ProviderGoogle:
+		google := appConfig.STT.Google
+		if !google.IsConfigured() {
+			return nil, fmt.Errorf("Google STT needs a service account credentials_file")
+		}
+		return speech.NewGoogleService(google.CredentialsFile, appConfig.Azure.Language, google.Model)
+	}
+	return nil, fmt.Errorf("unknown speech provider %q", appConfig.STT.Provider)
+}
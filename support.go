@@ -0,0 +1,123 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/logbuffer"
+	"voice-assistant/internal/notify"
+)
+
+const appVersion = "1.0"
+
+// How much recent log output goes into a problem report
+const (
+	supportLogLines  = 5000
+	supportLogWindow = 30 * time.Minute
+)
+
+// logBuffer keeps recent log output for problem reports
+var logBuffer = logbuffer.New(supportLogLines)
+
+// addSupportMenu adds the "Report a problem" tray item
+func addSupportMenu() *systray.MenuItem {
+	mReport := systray.AddMenuItem("Report a problem…", "Save logs and settings to attach to a bug report")
+	go func() {
+		for range mReport.ClickedCh {
+			path, err := exportSupportBundle()
+			if err != nil {
+				log.Printf("❌ Failed to create support bundle: %v", err)
+				notifications.Notify(notify.Error, "❌ Failed to create support bundle")
+				continue
+			}
+			notifications.Notify(notify.Info, "🧰 Support bundle saved to "+path+"\nIt includes recent logs, which may contain what you said.")
+			gui.Open(filepath.Dir(path))
+		}
+	}()
+	return mReport
+}
+
+// exportSupportBundle zips recent logs, the settings with keys redacted,
+// version information and the audio devices
+func exportSupportBundle() (string, error) {
+	path := filepath.Join(config.GetConfigDir(), fmt.Sprintf("support-%s.zip", time.Now().Format("20060102-150405")))
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	settings, err := json.MarshalIndent(appConfig.Redacted(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	devices, err := audio.DescribeDevices()
+	if err != nil {
+		devices = []string{err.Error()}
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"logs.txt", logBuffer.Since(supportLogWindow)},
+		{"params.json", string(settings)},
+		{"version.txt", versionInfo()},
+		{"audio-devices.txt", strings.Join(devices, "\n") + "\n"},
+	}
+
+	archive := zip.NewWriter(file)
+	for _, f := range files {
+		writer, err := archive.Create(f.name)
+		if err != nil {
+			return "", err
+		}
+		_, err = writer.Write([]byte(f.content))
+		if err != nil {
+			return "", err
+		}
+	}
+	err = archive.Close()
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("🧰 Support bundle saved: %s", path)
+	return path, nil
+}
+
+// versionInfo describes the build and the services in use
+func versionInfo() string {
+	var info strings.Builder
+	fmt.Fprintf(&info, "AI Desktop Assistant %s\n", appVersion)
+	fmt.Fprintf(&info, "Config schema: %d\n", config.CurrentVersion)
+	fmt.Fprintf(&info, "Go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&info, "Created: %s\n", time.Now().Format(time.RFC3339))
+
+	speechName, ttsName, model := "none", "none", "none"
+	if speechService != nil {
+		speechName = speechService.Name()
+	}
+	if ttsProvider != nil {
+		ttsName = ttsProvider.Name()
+	}
+	if claudeClient != nil {
+		model = claudeClient.Model()
+	}
+	fmt.Fprintf(&info, "Speech recognition: %s\nText-to-speech: %s\nClaude model: %s\n", speechName, ttsName, model)
+	fmt.Fprintf(&info, "Profile: %s\n", profileName(appConfig.Profile))
+	return info.String()
+}
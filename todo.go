@@ -0,0 +1,92 @@
+package main
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/oauth"
+	"voice-assistant/internal/todo"
+)
+
+// todoProvider adds tasks; nil unless a todo provider is configured
+var (
+	todoProvider todo.Provider
+	todoQueue    *todo.Queue
+)
+
+// setupTodo creates the todo provider and sends any tasks queued while
+// offline
+func setupTodo() {
+	cfg := appConfig.Todo
+	if !cfg.IsConfigured() {
+		return
+	}
+
+	if cfg.Provider == config.TodoTodoist {
+		todoProvider = todo.NewTodoist(cfg.APIToken, cfg.Project, cfg.Labels)
+	} else {
+		todoProvider = todo.NewMicrosoftToDo(cfg.ClientID, cfg.Tenant, cfg.List)
+	}
+	todoQueue = todo.NewQueue(config.TodoQueuePath())
+	go flushTodoQueue()
+}
+
+// addTodo runs "add to my todo list", queueing the task when it can't be
+// sent right now
+func addTodo(text string) {
+	text = strings.TrimSpace(strings.TrimRight(text, ".!?"))
+	if text == "" {
+		notifications.Notify(notify.Info, "✅ Nothing to add - say \"add to my todo list\" followed by the task")
+		return
+	}
+
+	task := todo.Task{Content: text, Added: time.Now()}
+	err := todoProvider.Add(appContext, task)
+	if todo.IsOffline(err) {
+		log.Printf("⚠️  Couldn't reach %s, queueing task: %v", todoProvider.Name(), err)
+		err = todoQueue.Add(task)
+		if err != nil {
+			log.Printf("❌ %v", err)
+			notifications.Notify(notify.Error, "❌ Failed to save the task")
+			return
+		}
+		notifications.Notify(notify.Info, "✅ Saved, will add when back online: "+text)
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Failed to add task: %v", err)
+		notifications.Notify(notify.Error, "❌ Failed to add the task")
+		return
+	}
+	log.Printf("✅ Added task to %s", todoProvider.Name())
+	notifications.Notify(notify.Info, "✅ Added: "+text)
+}
+
+// flushTodoQueue sends tasks queued while offline
+func flushTodoQueue() {
+	if todoQueue == nil || todoQueue.Len() == 0 {
+		return
+	}
+
+	sent, err := todoQueue.Flush(appContext, todoProvider)
+	if err != nil {
+		log.Printf("⚠️  Todo queue: %v", err)
+	}
+	if sent > 0 {
+		log.Printf("✅ Added %d queued task(s) to %s", sent, todoProvider.Name())
+	}
+}
+
+// addTodoMenu adds the sign-in item for providers that need one
+func addTodoMenu() *systray.MenuItem {
+	var session *oauth.Session
+	if microsoft, ok := todoProvider.(*todo.MicrosoftToDo); ok {
+		session = microsoft.Session()
+	}
+	return addConnectMenu("Microsoft To Do", "Sign in so tasks can be added by voice", session)
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/commands"
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/lookup"
+	"voice-assistant/internal/notify"
+)
+
+// Longest timer Claude may set
+const maxTimerDuration = 24 * time.Hour
+
+var toolRegistry = claude.NewToolRegistry()
+
+// timer is a running set_timer countdown
+type timer struct {
+	label string
+	due   time.Time
+}
+
+// Timers that haven't finished yet, for the briefing
+var (
+	activeTimers []*timer
+	timersMutex  sync.Mutex
+)
+
+// setupTools registers the built-in tools and hands them to the Claude client
+func setupTools() {
+	registerTool(claude.Tool{
+		Name:        "get_time",
+		Description: "Get the current local date and time, optionally in another IANA time zone such as Europe/Paris.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"timezone": map[string]interface{}{"type": "string", "description": "IANA time zone name"},
+			},
+		},
+	}, getTimeTool)
+
+	registerTool(claude.Tool{
+		Name:        "open_url",
+		Description: "Open a web page in the user's default browser.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"url": map[string]interface{}{"type": "string", "description": "http or https URL"},
+			},
+			"required": []string{"url"},
+		},
+	}, openURLTool)
+
+	registerTool(claude.Tool{
+		Name:        "set_timer",
+		Description: "Start a countdown timer that shows a notification when it finishes.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"seconds": map[string]interface{}{"type": "integer", "description": "Timer length in seconds"},
+				"label":   map[string]interface{}{"type": "string", "description": "What the timer is for"},
+			},
+			"required": []string{"seconds"},
+		},
+	}, setTimerTool)
+
+	registerTool(claude.Tool{
+		Name:        "get_weather",
+		Description: "Get the current weather and today's high and low for a city or place.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"place": map[string]interface{}{"type": "string", "description": "City or place name"},
+				"units": map[string]interface{}{"type": "string", "enum": []string{"metric", "imperial"}},
+			},
+			"required": []string{"place"},
+		},
+	}, getWeatherTool)
+
+	registerTool(claude.Tool{
+		Name:        "convert_units",
+		Description: "Convert a value between units of length, mass, volume, speed, area or temperature, e.g. miles to km or F to C.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"value": map[string]interface{}{"type": "number"},
+				"from":  map[string]interface{}{"type": "string", "description": "Unit name or abbreviation"},
+				"to":    map[string]interface{}{"type": "string", "description": "Unit name or abbreviation"},
+			},
+			"required": []string{"value", "from", "to"},
+		},
+	}, convertUnitsTool)
+
+	registerTool(claude.Tool{
+		Name:        "convert_currency",
+		Description: "Convert an amount between currencies at today's reference exchange rate.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"amount": map[string]interface{}{"type": "number"},
+				"from":   map[string]interface{}{"type": "string", "description": "ISO 4217 code such as USD"},
+				"to":     map[string]interface{}{"type": "string", "description": "ISO 4217 code such as EUR"},
+			},
+			"required": []string{"amount", "from", "to"},
+		},
+	}, convertCurrencyTool)
+
+	setupActions()
+	setupCalendar()
+	setupEmail()
+	setupShell()
+	setupPlugins()
+
+	if claudeClient != nil {
+		claudeClient.SetToolRegistry(toolRegistry)
+		claudeClient.SetToolsEnabled(appConfig.Features.Tools)
+	}
+}
+
+// registerTool adds a tool unless kiosk mode doesn't allow it
+func registerTool(tool claude.Tool, handler claude.ToolHandler) {
+	if kioskMode && !contains(appConfig.Kiosk.AllowedTools, tool.Name) {
+		log.Printf("🔒 Tool %s disabled in kiosk mode", tool.Name)
+		return
+	}
+	toolRegistry.Register(tool, handler)
+}
+
+// runToolAction calls a registered tool for a voice command and speaks
+// its result. The slots, and args written as name=value, are its input;
+// values that read as numbers or booleans are passed as such.
+func runToolAction(action commands.Action, slots map[string]string) error {
+	if !appConfig.Features.Tools {
+		return fmt.Errorf("tools are turned off")
+	}
+
+	input := make(map[string]interface{}, len(slots)+len(action.Args))
+	for name, value := range slots {
+		input[name] = toolValue(value)
+	}
+	for _, arg := range action.Args {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Errorf("tool argument %q is not name=value", arg)
+		}
+		input[strings.TrimSpace(name)] = toolValue(commands.Fill(strings.TrimSpace(value), slots))
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	tool := commands.Fill(action.Target, slots)
+	log.Printf("🔧 Running tool %s: %s", tool, data)
+	result, err := toolRegistry.Call(tool, data)
+	if err != nil {
+		return err
+	}
+
+	rememberResponse(result)
+	notifications.Notify(notify.Response, result)
+	speakResponse(claude.Speakable(result))
+	return nil
+}
+
+// toolValue types a slot value for a tool's JSON input
+func toolValue(value string) interface{} {
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return number
+	}
+	if value == "true" || value == "false" {
+		return value == "true"
+	}
+	return value
+}
+
+// getTimeTool reports the current time
+func getTimeTool(input json.RawMessage) (string, error) {
+	var args struct {
+		Timezone string `json:"timezone"`
+	}
+	err := json.Unmarshal(input, &args)
+	if err != nil {
+		return "", fmt.Errorf("invalid input: %v", err)
+	}
+
+	now := time.Now()
+	if args.Timezone != "" {
+		location, err := time.LoadLocation(args.Timezone)
+		if err != nil {
+			return "", fmt.Errorf("unknown time zone %q", args.Timezone)
+		}
+		now = now.In(location)
+	}
+	return now.Format("Monday, January 2, 2006 15:04 MST"), nil
+}
+
+// openURLTool opens a web page in the browser
+func openURLTool(input json.RawMessage) (string, error) {
+	var args struct {
+		URL string `json:"url"`
+	}
+	err := json.Unmarshal(input, &args)
+	if err != nil {
+		return "", fmt.Errorf("invalid input: %v", err)
+	}
+
+	// Only web pages; never let a tool call launch local programs or files
+	u, err := url.Parse(args.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", fmt.Errorf("only http and https URLs can be opened")
+	}
+
+	err = gui.Open(u.String())
+	if err != nil {
+		return "", err
+	}
+	return "Opened " + u.String(), nil
+}
+
+// setTimerTool starts a timer that notifies when it expires
+func setTimerTool(input json.RawMessage) (string, error) {
+	var args struct {
+		Seconds int    `json:"seconds"`
+		Label   string `json:"label"`
+	}
+	err := json.Unmarshal(input, &args)
+	if err != nil {
+		return "", fmt.Errorf("invalid input: %v", err)
+	}
+
+	duration := time.Duration(args.Seconds) * time.Second
+	if duration <= 0 || duration > maxTimerDuration {
+		return "", fmt.Errorf("timer must be between 1 second and %v", maxTimerDuration)
+	}
+
+	label := args.Label
+	if label == "" {
+		label = "Timer"
+	}
+	t := &timer{label: label, due: time.Now().Add(duration)}
+	timersMutex.Lock()
+	activeTimers = append(activeTimers, t)
+	timersMutex.Unlock()
+
+	time.AfterFunc(duration, func() {
+		removeTimer(t)
+		log.Printf("⏰ Timer finished: %s", label)
+		notifications.Notify(notify.Reminder, "⏰ "+label+" is done")
+	})
+
+	log.Printf("⏰ Timer set for %v: %s", duration, label)
+	return fmt.Sprintf("Timer set for %v", duration), nil
+}
+
+// getWeatherTool reports the weather at a place
+func getWeatherTool(input json.RawMessage) (string, error) {
+	var args struct {
+		Place string `json:"place"`
+		Units string `json:"units"`
+	}
+	err := json.Unmarshal(input, &args)
+	if err != nil {
+		return "", fmt.Errorf("invalid input: %v", err)
+	}
+
+	weather, err := lookup.CurrentWeather(appContext, args.Place, args.Units == "imperial")
+	if err != nil {
+		return "", err
+	}
+	return weather.String(), nil
+}
+
+// convertUnitsTool converts between units
+func convertUnitsTool(input json.RawMessage) (string, error) {
+	var args struct {
+		Value float64 `json:"value"`
+		From  string  `json:"from"`
+		To    string  `json:"to"`
+	}
+	err := json.Unmarshal(input, &args)
+	if err != nil {
+		return "", fmt.Errorf("invalid input: %v", err)
+	}
+
+	result, err := lookup.ConvertUnits(args.Value, args.From, args.To)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%g %s = %.4g %s", args.Value, args.From, result, args.To), nil
+}
+
+// convertCurrencyTool converts between currencies
+func convertCurrencyTool(input json.RawMessage) (string, error) {
+	var args struct {
+		Amount float64 `json:"amount"`
+		From   string  `json:"from"`
+		To     string  `json:"to"`
+	}
+	err := json.Unmarshal(input, &args)
+	if err != nil {
+		return "", fmt.Errorf("invalid input: %v", err)
+	}
+
+	result, err := lookup.ConvertCurrency(appContext, args.Amount, args.From, args.To)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%.2f %s = %.2f %s", args.Amount, strings.ToUpper(args.From), result, strings.ToUpper(args.To)), nil
+}
+
+// removeTimer forgets a finished timer
+func removeTimer(t *timer) {
+	timersMutex.Lock()
+	defer timersMutex.Unlock()
+	for i, active := range activeTimers {
+		if active == t {
+			activeTimers = append(activeTimers[:i], activeTimers[i+1:]...)
+			return
+		}
+	}
+}
+
+// pendingTimers describes the timers still running
+func pendingTimers() []string {
+	timersMutex.Lock()
+	defer timersMutex.Unlock()
+
+	var timers []string
+	for _, t := range activeTimers {
+		timers = append(timers, fmt.Sprintf("%s at %s", t.label, t.due.Format("15:04")))
+	}
+	return timers
+}
+
+// contains reports whether list holds value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
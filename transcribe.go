@@ -0,0 +1,146 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/clipboard"
+	"voice-assistant/internal/gui"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/speech"
+)
+
+// Audio files the tray's file picker offers
+var transcribeFilters = []gui.FileFilter{
+	{Name: "Audio files (*.wav, *.mp3)", Pattern: "*.wav;*.mp3"},
+	{Name: "All files", Pattern: "*.*"},
+}
+
+// runTranscribe implements "voice-assistant transcribe [-o file | -clipboard] audio-file".
+// It returns the process exit code.
+func runTranscribe(args []string) int {
+	flags := flag.NewFlagSet("transcribe", flag.ContinueOnError)
+	output := flags.String("o", "", "Write the transcript to this file (default: next to the audio file)")
+	toClipboard := flags.Bool("clipboard", false, "Copy the transcript to the clipboard instead of writing a file")
+	if flags.Parse(args) != nil || flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: voice-assistant transcribe [-o file | -clipboard] audio-file")
+		return 2
+	}
+
+	service, err := newSpeechProvider()
+	if err != nil {
+		log.Printf("❌ Failed to initialize speech recognition: %v", err)
+		return 1
+	}
+	if service == nil {
+		log.Printf("❌ Speech recognition is not configured")
+		return 1
+	}
+	defer service.Close()
+
+	text, err := transcribeFile(service, flags.Arg(0))
+	if err != nil {
+		log.Printf("❌ %v", err)
+		return 1
+	}
+
+	if *toClipboard {
+		err = clipboard.WriteText(text)
+		if err != nil {
+			log.Printf("❌ Failed to copy transcript: %v", err)
+			return 1
+		}
+		log.Printf("📋 Transcript copied to clipboard")
+		return 0
+	}
+
+	path := *output
+	if path == "" {
+		path = transcriptPath(flags.Arg(0))
+	}
+	err = os.WriteFile(path, []byte(text+"\n"), 0644)
+	if err != nil {
+		log.Printf("❌ Failed to write transcript: %v", err)
+		return 1
+	}
+	log.Printf("📝 Transcript written to %s", path)
+	return 0
+}
+
+// transcribeFile decodes an audio file, converts it to 16kHz mono and runs
+// it through the speech backend
+func transcribeFile(service speech.Provider, path string) (string, error) {
+	log.Printf("📂 Transcribing %s with %s", path, service.Name())
+
+	samples, err := audio.ReadSpeechFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	text, err := service.TranscribePCM(appContext, samples)
+	if err != nil {
+		return "", fmt.Errorf("failed to transcribe %s: %v", path, err)
+	}
+	return text, nil
+}
+
+// transcriptPath puts the transcript next to the audio file, e.g.
+// meeting.mp3 becomes meeting.txt
+func transcriptPath(audioPath string) string {
+	if i := strings.LastIndex(audioPath, "."); i > strings.LastIndexAny(audioPath, `/\`) {
+		audioPath = audioPath[:i]
+	}
+	return audioPath + ".txt"
+}
+
+// addTranscribeMenu adds the "Transcribe file…" tray item
+func addTranscribeMenu() *systray.MenuItem {
+	mTranscribe := systray.AddMenuItem("Transcribe file…", "Turn a WAV or MP3 recording into text")
+	if speechService == nil {
+		mTranscribe.Disable()
+	}
+
+	go func() {
+		for range mTranscribe.ClickedCh {
+			transcribeFromTray()
+		}
+	}()
+	return mTranscribe
+}
+
+// transcribeFromTray asks for an audio file, then saves its transcript
+// next to it and copies it to the clipboard
+func transcribeFromTray() {
+	if speechService.IsListening() {
+		notifications.Notify(notify.Error, "⚠️ Stop listening before transcribing a file")
+		return
+	}
+
+	path, ok := gui.OpenFile("Transcribe audio file", transcribeFilters)
+	if !ok {
+		return
+	}
+
+	notifications.Notify(notify.Status, "📝 Transcribing "+path+"…")
+	text, err := transcribeFile(speechService, path)
+	if err != nil {
+		log.Printf("❌ %v", err)
+		notifications.Notify(notify.Error, "❌ Transcription failed")
+		return
+	}
+
+	output := transcriptPath(path)
+	err = os.WriteFile(output, []byte(text+"\n"), 0644)
+	if err != nil {
+		log.Printf("❌ Failed to write transcript: %v", err)
+	} else {
+		log.Printf("📝 Transcript written to %s", output)
+	}
+	copyToClipboard("transcript", text)
+}
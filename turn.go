@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"voice-assistant/internal/hotkey"
+)
+
+// appContext is cancelled on shutdown so requests in flight are abandoned
+var appContext, stopApp = context.WithCancel(context.Background())
+
+// The cancel function of the turn whose requests are in flight
+var (
+	turnCancel context.CancelFunc
+	turnID     int
+	turnMutex  sync.Mutex
+)
+
+// beginTurn returns the context for a turn's requests, cancelled by the
+// hotkey or on shutdown. Call the returned function once they finish.
+func beginTurn() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(appContext)
+
+	turnMutex.Lock()
+	turnID++
+	id := turnID
+	turnCancel = cancel
+	turnMutex.Unlock()
+
+	return ctx, func() {
+		turnMutex.Lock()
+		if turnID == id {
+			turnCancel = nil
+		}
+		turnMutex.Unlock()
+		cancel()
+	}
+}
+
+// cancelTurn abandons the requests of the turn in flight and reports
+// whether there was one
+func cancelTurn() bool {
+	turnMutex.Lock()
+	defer turnMutex.Unlock()
+
+	if turnCancel == nil {
+		return false
+	}
+	turnCancel()
+	turnCancel = nil
+	return true
+}
+
+// bindCancelHotkey binds Ctrl+Alt+X to cancel the turn in progress
+func bindCancelHotkey(listener *hotkey.Listener) {
+	listener.Bind("Ctrl+Alt+X", []int{hotkey.VK_CTRL, hotkey.VK_ALT, hotkey.VK_X}, onCancelHotkey)
+}
+
+// onCancelHotkey cancels whatever the assistant is doing for this turn
+func onCancelHotkey() {
+	if assistant == nil || !assistant.Abort() {
+		log.Printf("⏹️ Nothing to cancel")
+	}
+}
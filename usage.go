@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/config"
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/usage"
+)
+
+var (
+	usageTracker *usage.Tracker
+	mUsageToday  *systray.MenuItem
+	mUsageTotal  *systray.MenuItem
+)
+
+// setupUsageTracking starts accumulating token usage from the answering
+// client
+func setupUsageTracking() {
+	usageTracker = usage.NewTracker(filepath.Join(config.GetConfigDir(), "usage.json"))
+
+	if claudeClient != nil {
+		claudeClient.SetUsageCallback(recordUsage)
+	}
+	if llmClient != nil {
+		llmClient.SetUsageCallback(recordUsage)
+	}
+}
+
+// recordUsage adds the tokens of one answer, from any provider
+func recordUsage(model string, u claude.Usage) {
+	if usageTracker == nil {
+		return
+	}
+	usageTracker.Record(model, u.InputTokens, u.OutputTokens, u.CacheCreationInputTokens, u.CacheReadInputTokens)
+	updateUsageMenu()
+}
+
+// addUsageMenu adds the "Usage" submenu to the tray
+func addUsageMenu() *systray.MenuItem {
+	mUsage := systray.AddMenuItem("Usage", "Token usage and estimated cost")
+	mUsageToday = mUsage.AddSubMenuItem("", "Estimated cost today")
+	mUsageToday.Disable()
+	mUsageTotal = mUsage.AddSubMenuItem("", "Estimated cost since start")
+	mUsageTotal.Disable()
+	mExport := mUsage.AddSubMenuItem("Export usage…", "Write usage totals to a JSON file")
+	updateUsageMenu()
+
+	go func() {
+		for range mExport.ClickedCh {
+			exportUsage()
+		}
+	}()
+	return mUsage
+}
+
+// updateUsageMenu refreshes the cost shown in the tray
+func updateUsageMenu() {
+	if mUsageToday == nil {
+		return
+	}
+
+	today := usageTracker.Today()
+	session := usageTracker.Session()
+	mUsageToday.SetTitle(fmt.Sprintf("Today: $%.2f (%d requests)", today.CostUSD, today.Requests))
+	mUsageTotal.SetTitle(fmt.Sprintf("This session: $%.2f (%d in / %d out tokens)",
+		session.CostUSD, session.InputTokens, session.OutputTokens))
+}
+
+// exportUsage writes the usage totals next to params.json
+func exportUsage() {
+	path := filepath.Join(config.GetConfigDir(), fmt.Sprintf("usage-export-%s.json", time.Now().Format("20060102-150405")))
+
+	err := usageTracker.Export(path)
+	if err != nil {
+		log.Printf("❌ Failed to export usage: %v", err)
+		notifications.Notify(notify.Error, "❌ Failed to export usage")
+		return
+	}
+
+	log.Printf("💾 Usage exported to %s", path)
+	notifications.Notify(notify.Info, "💾 Usage exported to "+path)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"voice-assistant/internal/claude"
+	"voice-assistant/internal/clipboard"
+	"voice-assistant/internal/commands"
+	"voice-assistant/internal/intent"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/vision"
+)
+
+var (
+	// clipboardImagePhrase catches questions like "what does the chart on my
+	// clipboard show", matched after commands.Normalize
+	clipboardImagePhrase = regexp.MustCompile(`\b(?:image|picture|photo|screenshot|chart|diagram|graph)s? (?:on|in|from) (?:my|the) clipboard\b`)
+
+	// imagePathPattern finds image files named in a question, quoted when
+	// they contain spaces
+	imagePathPattern = regexp.MustCompile(`(?i)"([^"]+\.(?:png|jpe?g|gif))"|((?:[a-z]:\\|~[\\/]|\.{0,2}[\\/])?[^\s"'<>|*?]+\.(?:png|jpe?g|gif))\b`)
+
+	// spokenExtension turns "holiday dot png" from speech into "holiday.png"
+	spokenExtension = regexp.MustCompile(`(?i)\s+dot\s+(png|jpe?g|gif)\b`)
+)
+
+// imageFolders are searched, in order, for files named without a folder
+var imageFolders = []string{"Desktop", "Downloads", "Pictures", ""}
+
+// attachedImages returns the images to send with a question: a screenshot,
+// the image on the clipboard when the question is about it, and image
+// files the question names
+func attachedImages(text string) []claude.Image {
+	images := screenshotsFor(text)
+	if !appConfig.Vision.Enabled {
+		return images
+	}
+
+	if wantsClipboardImage(text) {
+		attached, err := clipboardImage()
+		if err != nil {
+			log.Printf("❌ Failed to attach the clipboard image: %v", err)
+			notifications.Notify(notify.Error, "❌ "+describeImageError(err, "No image on the clipboard"))
+		} else {
+			images = append(images, *attached)
+		}
+	}
+
+	limit := appConfig.Vision.MaxImages
+	for _, path := range imagePaths(text) {
+		if limit > 0 && len(images) >= limit {
+			log.Printf("⚠️  Only %d images can be attached to a question", limit)
+			break
+		}
+		attached, err := fileImage(path)
+		if err != nil {
+			log.Printf("❌ Failed to attach %s: %v", path, err)
+			notifications.Notify(notify.Error, "❌ "+describeImageError(err, "Couldn't find "+filepath.Base(path)))
+			continue
+		}
+		images = append(images, *attached)
+	}
+	return images
+}
+
+// wantsClipboardImage reports whether a question is about the clipboard
+func wantsClipboardImage(text string) bool {
+	match, ok := intentMatcher.Match(text)
+	if ok && match.Intent == intent.ClipboardImage {
+		return true
+	}
+	return clipboardImagePhrase.MatchString(commands.Normalize(text))
+}
+
+// clipboardImage reads, downscales and encodes the image on the clipboard
+func clipboardImage() (*claude.Image, error) {
+	img, err := clipboard.ReadImage()
+	if err != nil {
+		return nil, err
+	}
+	return prepareImage(img, "clipboard image")
+}
+
+// fileImage reads, downscales and encodes an image file
+func fileImage(path string) (*claude.Image, error) {
+	img, err := vision.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return prepareImage(img, filepath.Base(path))
+}
+
+// prepareImage fits an image to the configured size caps
+func prepareImage(img image.Image, name string) (*claude.Image, error) {
+	prepared, err := vision.Prepare(img, vision.Limits{
+		MaxEdge:  appConfig.Vision.MaxEdge,
+		MaxBytes: appConfig.Vision.MaxKB * 1024,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("🖼️  Attached %s (%dx%d, %d KB)", name, prepared.Width, prepared.Height, len(prepared.Data)/1024)
+	return &claude.Image{MediaType: prepared.MediaType, Data: prepared.Data}, nil
+}
+
+// imagePaths returns the existing image files a question names. Names
+// without a folder are looked for on the desktop and in the usual places.
+func imagePaths(text string) []string {
+	text = spokenExtension.ReplaceAllString(text, ".$1")
+
+	var paths []string
+	seen := map[string]bool{}
+	for _, match := range imagePathPattern.FindAllStringSubmatch(text, -1) {
+		name := match[1] + match[2]
+		if strings.Contains(name, "://") {
+			continue // A link, not a file
+		}
+		path, ok := resolveImagePath(name)
+		if !ok {
+			path = name // Reported as not found
+		}
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// resolveImagePath finds the file a name refers to
+func resolveImagePath(name string) (string, bool) {
+	home, _ := os.UserHomeDir()
+	if strings.HasPrefix(name, "~") && home != "" {
+		name = filepath.Join(home, name[1:])
+	}
+
+	candidates := []string{name}
+	if !filepath.IsAbs(name) && home != "" {
+		for _, folder := range imageFolders {
+			candidates = append(candidates, filepath.Join(home, folder, name))
+		}
+	}
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err == nil && !info.IsDir() && vision.IsImageFile(candidate) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// describeImageError explains a failed attachment, with fallback for the
+// common case of there being nothing to attach
+func describeImageError(err error, fallback string) string {
+	if errors.Is(err, clipboard.ErrNoImage) || errors.Is(err, os.ErrNotExist) {
+		return fallback
+	}
+	return fmt.Sprintf("Couldn't attach the image: %v", err)
+}
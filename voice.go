@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/getlantern/systray"
+
+	"voice-assistant/internal/app"
+	"voice-assistant/internal/audio"
+	"voice-assistant/internal/ducking"
+	"voice-assistant/internal/errs"
+	"voice-assistant/internal/events"
+	"voice-assistant/internal/metrics"
+	"voice-assistant/internal/notify"
+	"voice-assistant/internal/tts"
+)
+
+var (
+	ttsProvider tts.Provider
+	audioPlayer *audio.Player
+	ducker      *ducking.Ducker
+)
+
+// setupTTS creates the configured speech provider and the output player
+func setupTTS() {
+	var err error
+	ttsProvider, err = tts.NewProviderFromConfig(appConfig)
+	if err != nil {
+		log.Printf("⚠️  Text-to-speech disabled: %v", err)
+		return
+	}
+
+	audioPlayer, err = audio.NewPlayer()
+	if err != nil {
+		log.Printf("⚠️  Text-to-speech disabled: %v", err)
+		ttsProvider = nil
+		return
+	}
+	audioPlayer.SetDevice(appConfig.Audio.OutputDevice)
+	audioPlayer.SetVolume(appConfig.Audio.Volume)
+	log.Printf("🔈 Text-to-speech: %s", ttsProvider.Name())
+}
+
+// setupDucking lowers other applications' audio whenever the assistant is
+// listening, thinking or speaking, and restores it once idle
+func setupDucking() {
+	if appConfig.Audio.DuckPercent <= 0 {
+		return
+	}
+
+	ducker = ducking.NewDucker(appConfig.Audio.DuckPercent)
+
+	// COM calls are slow enough to run off the state machine, but must stay in order
+	active := make(chan bool, 16)
+	go func() {
+		for duck := range active {
+			if duck {
+				ducker.Duck()
+			} else {
+				ducker.Restore()
+			}
+		}
+	}()
+
+	events.On(eventBus, func(e events.StateChanged) {
+		switch e.To {
+		case app.Listening, app.Processing, app.Speaking:
+			// A transcribed call must stay audible
+			active <- !isMeetingActive()
+		default:
+			active <- false
+		}
+	})
+}
+
+// Volume steps offered in the tray
+var volumeSteps = []int{25, 50, 75, 100}
+
+// addOutputMenu adds the "Output device" and "Volume" submenus
+func addOutputMenu() (*systray.MenuItem, *systray.MenuItem) {
+	mDevice := systray.AddMenuItem("Output device", "Where spoken responses play")
+	mVolume := systray.AddMenuItem("Volume", "Playback volume for spoken responses")
+	if audioPlayer == nil {
+		mDevice.Disable()
+		mVolume.Disable()
+		return mDevice, mVolume
+	}
+
+	devices, err := audioPlayer.OutputDevices()
+	if err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	devices = append([]string{""}, devices...)
+
+	deviceItems := make([]*systray.MenuItem, len(devices))
+	for i, device := range devices {
+		title := device
+		if device == "" {
+			title = "System default"
+		}
+		deviceItems[i] = mDevice.AddSubMenuItemCheckbox(title, "Play responses on "+title, device == appConfig.Audio.OutputDevice)
+	}
+	for i := range deviceItems {
+		go func(index int) {
+			for range deviceItems[index].ClickedCh {
+				selectOutputDevice(devices[index])
+				checkOnly(deviceItems, index)
+			}
+		}(i)
+	}
+
+	volumeItems := make([]*systray.MenuItem, len(volumeSteps))
+	for i, step := range volumeSteps {
+		current := int(appConfig.Audio.Volume*100+0.5) == step
+		volumeItems[i] = mVolume.AddSubMenuItemCheckbox(fmt.Sprintf("%d%%", step), "Set playback volume", current)
+	}
+	for i := range volumeItems {
+		go func(index int) {
+			for range volumeItems[index].ClickedCh {
+				setVolume(float64(volumeSteps[index]) / 100)
+				checkOnly(volumeItems, index)
+			}
+		}(i)
+	}
+	return mDevice, mVolume
+}
+
+// selectOutputDevice plays responses on a device and remembers it
+func selectOutputDevice(device string) {
+	audioPlayer.SetDevice(device)
+	if earconPlayer != nil {
+		earconPlayer.SetDevice(device)
+	}
+	appConfig.Audio.OutputDevice = device
+	err := appConfig.Save()
+	if err != nil {
+		log.Printf("Failed to save output device: %v", err)
+	}
+	log.Printf("🔈 Output device: %q", device)
+}
+
+// setVolume changes the playback volume and remembers it
+func setVolume(volume float64) {
+	audioPlayer.SetVolume(volume)
+	if earconPlayer != nil {
+		earconPlayer.SetVolume(volume)
+	}
+	appConfig.Audio.Volume = volume
+	err := appConfig.Save()
+	if err != nil {
+		log.Printf("Failed to save volume: %v", err)
+	}
+	log.Printf("🔈 Volume: %.0f%%", volume*100)
+}
+
+// checkOnly checks one item of a radio-style submenu and unchecks the rest
+func checkOnly(items []*systray.MenuItem, index int) {
+	for i, item := range items {
+		if i == index {
+			item.Check()
+		} else {
+			item.Uncheck()
+		}
+	}
+}
+
+// speakResponse delivers a response aloud and returns once playback is done
+func speakResponse(text string) {
+	speakResponseAt(text, 1.0)
+}
+
+// speakResponseAt speaks text at a rate relative to the configured speed
+func speakResponseAt(text string, rate float64) {
+	if !appConfig.Features.TTS || ttsProvider == nil || text == "" {
+		return
+	}
+
+	options := tts.Options{
+		Voice:    appConfig.TTS.Voice,
+		Rate:     appConfig.TTS.Rate * rate,
+		Language: replyLanguage(),
+	}
+	if voice := appConfig.ActivePersona().Voice; voice != "" {
+		options.Voice = voice
+	}
+
+	// Streaming providers start playing before synthesis has finished.
+	// Answers at the normal rate are kept for replaying.
+	if streamer, ok := ttsProvider.(tts.Streamer); ok {
+		synthesized := make(chan []int16, 64)
+		chunks := make(chan []int16, 64)
+		spoken := teeSamples(synthesized, chunks)
+		go func() {
+			err := streamer.Stream(text, options, synthesized)
+			samples := <-spoken
+			if err != nil {
+				reportSynthesisError(err)
+			} else if rate == 1.0 {
+				rememberSpoken(text, samples, streamer.SampleRate())
+			}
+		}()
+		playSpeech(func() error {
+			return audioPlayer.PlayStream(chunks, streamer.SampleRate())
+		})
+		return
+	}
+
+	speech, err := ttsProvider.Synthesize(text, options)
+	if err != nil {
+		reportSynthesisError(err)
+		return
+	}
+	if rate == 1.0 {
+		rememberSpoken(text, speech.Samples, speech.SampleRate)
+	}
+	playSpeech(func() error {
+		return audioPlayer.Play(speech.Samples, speech.SampleRate)
+	})
+}
+
+// reportSynthesisError logs a failed synthesis, telling the user when it's
+// something they can fix rather than failing silently
+func reportSynthesisError(err error) {
+	log.Printf("❌ Speech synthesis failed: %v", err)
+	if hint := errs.Hint(err); hint != "" {
+		notifications.Notify(notify.Error, hint)
+	}
+}
+
+// playSpeech runs a playback with the microphone gated and the state set
+func playSpeech(play func() error) {
+	if echoGate != nil {
+		echoGate.PlaybackStarted()
+		defer echoGate.PlaybackEnded()
+	}
+
+	setState(app.Speaking, "playing response")
+	metrics.Mark(metrics.MarkTTSStart)
+	metrics.EndInteraction()
+	err := play()
+	if err != nil {
+		log.Printf("❌ Playback failed: %v", err)
+	}
+}